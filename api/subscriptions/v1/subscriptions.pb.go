@@ -0,0 +1,3608 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: subscriptions/v1/subscriptions.proto
+
+package subscriptionsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Subscription struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceName string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Price       int64                  `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	UserId      string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate   string                 `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// empty for subscriptions without an end
+	FinishDate string `protobuf:"bytes,6,opt,name=finish_date,json=finishDate,proto3" json:"finish_date,omitempty"`
+	// day of month the billing periods start on, 0 means the day of start_date
+	BillingDay int32 `protobuf:"varint,7,opt,name=billing_day,json=billingDay,proto3" json:"billing_day,omitempty"`
+	// trial length in days from start_date and its monthly price
+	TrialDays  int32 `protobuf:"varint,8,opt,name=trial_days,json=trialDays,proto3" json:"trial_days,omitempty"`
+	TrialPrice int64 `protobuf:"varint,9,opt,name=trial_price,json=trialPrice,proto3" json:"trial_price,omitempty"`
+	// months after the trial charged intro_price instead of price
+	IntroMonths int32 `protobuf:"varint,10,opt,name=intro_months,json=introMonths,proto3" json:"intro_months,omitempty"`
+	IntroPrice  int64 `protobuf:"varint,11,opt,name=intro_price,json=introPrice,proto3" json:"intro_price,omitempty"`
+	// managed by Pause, Resume and DeletePause, ignored by Create and Update
+	Pauses []*Pause `protobuf:"bytes,12,rep,name=pauses,proto3" json:"pauses,omitempty"`
+	// managed by AddDiscount and DeleteDiscount, ignored by Create and Update
+	Discounts []*Discount `protobuf:"bytes,13,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	// catalog entry service_name resolved to, set by Create and Update, 0 for names missing from the catalog
+	ServiceId int32 `protobuf:"varint,14,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// labels like "team:platform", stored lowercased and sorted
+	Tags []string `protobuf:"bytes,15,rep,name=tags,proto3" json:"tags,omitempty"`
+	// managed by AddPriceChange and DeletePriceChange, ignored by Create and Update
+	PriceChanges  []*PriceChange `protobuf:"bytes,16,rep,name=price_changes,json=priceChanges,proto3" json:"price_changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Subscription) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Subscription) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *Subscription) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Subscription) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Subscription) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Subscription) GetFinishDate() string {
+	if x != nil {
+		return x.FinishDate
+	}
+	return ""
+}
+
+func (x *Subscription) GetBillingDay() int32 {
+	if x != nil {
+		return x.BillingDay
+	}
+	return 0
+}
+
+func (x *Subscription) GetTrialDays() int32 {
+	if x != nil {
+		return x.TrialDays
+	}
+	return 0
+}
+
+func (x *Subscription) GetTrialPrice() int64 {
+	if x != nil {
+		return x.TrialPrice
+	}
+	return 0
+}
+
+func (x *Subscription) GetIntroMonths() int32 {
+	if x != nil {
+		return x.IntroMonths
+	}
+	return 0
+}
+
+func (x *Subscription) GetIntroPrice() int64 {
+	if x != nil {
+		return x.IntroPrice
+	}
+	return 0
+}
+
+func (x *Subscription) GetPauses() []*Pause {
+	if x != nil {
+		return x.Pauses
+	}
+	return nil
+}
+
+func (x *Subscription) GetDiscounts() []*Discount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
+
+func (x *Subscription) GetServiceId() int32 {
+	if x != nil {
+		return x.ServiceId
+	}
+	return 0
+}
+
+func (x *Subscription) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Subscription) GetPriceChanges() []*PriceChange {
+	if x != nil {
+		return x.PriceChanges
+	}
+	return nil
+}
+
+type Pause struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubscriptionId int32                  `protobuf:"varint,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	StartDate      string                 `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// empty until the subscription is resumed
+	FinishDate    string `protobuf:"bytes,4,opt,name=finish_date,json=finishDate,proto3" json:"finish_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pause) Reset() {
+	*x = Pause{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pause) ProtoMessage() {}
+
+func (x *Pause) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pause.ProtoReflect.Descriptor instead.
+func (*Pause) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Pause) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Pause) GetSubscriptionId() int32 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *Pause) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Pause) GetFinishDate() string {
+	if x != nil {
+		return x.FinishDate
+	}
+	return ""
+}
+
+type Discount struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubscriptionId int32                  `protobuf:"varint,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	// "percent" or "fixed"
+	Kind string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// percent off or amount off the monthly price
+	Amount int64 `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// first month of the discount, any day of it is accepted
+	StartMonth string `protobuf:"bytes,5,opt,name=start_month,json=startMonth,proto3" json:"start_month,omitempty"`
+	// number of months, 0 means until deleted
+	Months int32 `protobuf:"varint,6,opt,name=months,proto3" json:"months,omitempty"`
+	// coupon or promo code, informational only
+	Code          string `protobuf:"bytes,7,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Discount) Reset() {
+	*x = Discount{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Discount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Discount) ProtoMessage() {}
+
+func (x *Discount) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Discount.ProtoReflect.Descriptor instead.
+func (*Discount) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Discount) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Discount) GetSubscriptionId() int32 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *Discount) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Discount) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Discount) GetStartMonth() string {
+	if x != nil {
+		return x.StartMonth
+	}
+	return ""
+}
+
+func (x *Discount) GetMonths() int32 {
+	if x != nil {
+		return x.Months
+	}
+	return 0
+}
+
+func (x *Discount) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type PriceChange struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubscriptionId int32                  `protobuf:"varint,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	// first day of the new regular price
+	EffectiveDate string `protobuf:"bytes,3,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	Price         int64  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PriceChange) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PriceChange) GetSubscriptionId() int32 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *PriceChange) GetEffectiveDate() string {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return ""
+}
+
+func (x *PriceChange) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type CreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRequest) Reset() {
+	*x = CreateRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRequest) ProtoMessage() {}
+
+func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateRequest) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type CreateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// overlapping subscriptions of the same user and service, when the server checks for duplicates
+	DuplicateIds  []int32 `protobuf:"varint,2,rep,packed,name=duplicate_ids,json=duplicateIds,proto3" json:"duplicate_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateResponse) Reset() {
+	*x = CreateResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResponse) ProtoMessage() {}
+
+func (x *CreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CreateResponse) GetDuplicateIds() []int32 {
+	if x != nil {
+		return x.DuplicateIds
+	}
+	return nil
+}
+
+type ReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadRequest) Reset() {
+	*x = ReadRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRequest) ProtoMessage() {}
+
+func (x *ReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRequest.ProtoReflect.Descriptor instead.
+func (*ReadRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReadRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadResponse) Reset() {
+	*x = ReadResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadResponse) ProtoMessage() {}
+
+func (x *ReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadResponse.ProtoReflect.Descriptor instead.
+func (*ReadResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReadResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type UpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateRequest) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type UpdateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// overlapping subscriptions of the same user and service, when the server checks for duplicates
+	DuplicateIds  []int32 `protobuf:"varint,1,rep,packed,name=duplicate_ids,json=duplicateIds,proto3" json:"duplicate_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateResponse) Reset() {
+	*x = UpdateResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateResponse) ProtoMessage() {}
+
+func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateResponse) GetDuplicateIds() []int32 {
+	if x != nil {
+		return x.DuplicateIds
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{11}
+}
+
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type SumRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	FilterFrom  string                 `protobuf:"bytes,1,opt,name=filter_from,json=filterFrom,proto3" json:"filter_from,omitempty"`
+	FilterTo    string                 `protobuf:"bytes,2,opt,name=filter_to,json=filterTo,proto3" json:"filter_to,omitempty"`
+	UserId      string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName string                 `protobuf:"bytes,4,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// "monthly" (default) or "prorated"
+	Mode          string `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SumRequest) Reset() {
+	*x = SumRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SumRequest) ProtoMessage() {}
+
+func (x *SumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SumRequest.ProtoReflect.Descriptor instead.
+func (*SumRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SumRequest) GetFilterFrom() string {
+	if x != nil {
+		return x.FilterFrom
+	}
+	return ""
+}
+
+func (x *SumRequest) GetFilterTo() string {
+	if x != nil {
+		return x.FilterTo
+	}
+	return ""
+}
+
+func (x *SumRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SumRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *SumRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type SumResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// charged amount after discounts
+	Sum int64 `protobuf:"varint,1,opt,name=sum,proto3" json:"sum,omitempty"`
+	// amount before discounts and the discounts, sum is gross minus discount
+	Gross         int64 `protobuf:"varint,2,opt,name=gross,proto3" json:"gross,omitempty"`
+	Discount      int64 `protobuf:"varint,3,opt,name=discount,proto3" json:"discount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SumResponse) Reset() {
+	*x = SumResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SumResponse) ProtoMessage() {}
+
+func (x *SumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SumResponse.ProtoReflect.Descriptor instead.
+func (*SumResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SumResponse) GetSum() int64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *SumResponse) GetGross() int64 {
+	if x != nil {
+		return x.Gross
+	}
+	return 0
+}
+
+func (x *SumResponse) GetDiscount() int64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+type ReportRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	FilterFrom  string                 `protobuf:"bytes,1,opt,name=filter_from,json=filterFrom,proto3" json:"filter_from,omitempty"`
+	FilterTo    string                 `protobuf:"bytes,2,opt,name=filter_to,json=filterTo,proto3" json:"filter_to,omitempty"`
+	UserId      string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName string                 `protobuf:"bytes,4,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// "monthly" (default) or "prorated"
+	Mode string `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	// "category", "tag" or "tag:KEY" for the values of KEY:VALUE tags
+	GroupBy string `protobuf:"bytes,6,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	// tag filter like "team:platform AND NOT (env:test OR env:dev)", empty selects all
+	Tags          string `protobuf:"bytes,7,opt,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportRequest) Reset() {
+	*x = ReportRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportRequest) ProtoMessage() {}
+
+func (x *ReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportRequest.ProtoReflect.Descriptor instead.
+func (*ReportRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReportRequest) GetFilterFrom() string {
+	if x != nil {
+		return x.FilterFrom
+	}
+	return ""
+}
+
+func (x *ReportRequest) GetFilterTo() string {
+	if x != nil {
+		return x.FilterTo
+	}
+	return ""
+}
+
+func (x *ReportRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReportRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ReportRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ReportRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *ReportRequest) GetTags() string {
+	if x != nil {
+		return x.Tags
+	}
+	return ""
+}
+
+type ReportGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// category, tag or tag value, empty for subscriptions without them
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Subscriptions int32  `protobuf:"varint,2,opt,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	Gross         int64  `protobuf:"varint,3,opt,name=gross,proto3" json:"gross,omitempty"`
+	Discount      int64  `protobuf:"varint,4,opt,name=discount,proto3" json:"discount,omitempty"`
+	Net           int64  `protobuf:"varint,5,opt,name=net,proto3" json:"net,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportGroup) Reset() {
+	*x = ReportGroup{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportGroup) ProtoMessage() {}
+
+func (x *ReportGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportGroup.ProtoReflect.Descriptor instead.
+func (*ReportGroup) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReportGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReportGroup) GetSubscriptions() int32 {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return 0
+}
+
+func (x *ReportGroup) GetGross() int64 {
+	if x != nil {
+		return x.Gross
+	}
+	return 0
+}
+
+func (x *ReportGroup) GetDiscount() int64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *ReportGroup) GetNet() int64 {
+	if x != nil {
+		return x.Net
+	}
+	return 0
+}
+
+type ReportResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	GroupBy string                 `protobuf:"bytes,1,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	// ordered by net spend, a subscription with several tags is counted in each of their groups
+	Groups        []*ReportGroup `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	Gross         int64          `protobuf:"varint,3,opt,name=gross,proto3" json:"gross,omitempty"`
+	Discount      int64          `protobuf:"varint,4,opt,name=discount,proto3" json:"discount,omitempty"`
+	Net           int64          `protobuf:"varint,5,opt,name=net,proto3" json:"net,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportResponse) Reset() {
+	*x = ReportResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportResponse) ProtoMessage() {}
+
+func (x *ReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportResponse.ProtoReflect.Descriptor instead.
+func (*ReportResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReportResponse) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *ReportResponse) GetGroups() []*ReportGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *ReportResponse) GetGross() int64 {
+	if x != nil {
+		return x.Gross
+	}
+	return 0
+}
+
+func (x *ReportResponse) GetDiscount() int64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *ReportResponse) GetNet() int64 {
+	if x != nil {
+		return x.Net
+	}
+	return 0
+}
+
+type ForecastRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// first month, any day of it is accepted, the current month when empty
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// number of months, 12 when unset
+	Months        int32  `protobuf:"varint,2,opt,name=months,proto3" json:"months,omitempty"`
+	UserId        string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName   string `protobuf:"bytes,4,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForecastRequest) Reset() {
+	*x = ForecastRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastRequest) ProtoMessage() {}
+
+func (x *ForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastRequest.ProtoReflect.Descriptor instead.
+func (*ForecastRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ForecastRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ForecastRequest) GetMonths() int32 {
+	if x != nil {
+		return x.Months
+	}
+	return 0
+}
+
+func (x *ForecastRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ForecastRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+type ForecastMonth struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// first day of the month
+	Month         string `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"`
+	Subscriptions int32  `protobuf:"varint,2,opt,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	Gross         int64  `protobuf:"varint,3,opt,name=gross,proto3" json:"gross,omitempty"`
+	Discount      int64  `protobuf:"varint,4,opt,name=discount,proto3" json:"discount,omitempty"`
+	Net           int64  `protobuf:"varint,5,opt,name=net,proto3" json:"net,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForecastMonth) Reset() {
+	*x = ForecastMonth{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastMonth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastMonth) ProtoMessage() {}
+
+func (x *ForecastMonth) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastMonth.ProtoReflect.Descriptor instead.
+func (*ForecastMonth) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ForecastMonth) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *ForecastMonth) GetSubscriptions() int32 {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return 0
+}
+
+func (x *ForecastMonth) GetGross() int64 {
+	if x != nil {
+		return x.Gross
+	}
+	return 0
+}
+
+func (x *ForecastMonth) GetDiscount() int64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *ForecastMonth) GetNet() int64 {
+	if x != nil {
+		return x.Net
+	}
+	return 0
+}
+
+type ForecastChange struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Month          string                 `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"`
+	SubscriptionId int32                  `protobuf:"varint,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	ServiceName    string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	UserId         string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// started, finished, trial_ended, intro_ended, price_changed, discount_started, discount_ended, paused or resumed
+	Reasons []string `protobuf:"bytes,5,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	// net charges of the previous and of this month
+	Before        int64 `protobuf:"varint,6,opt,name=before,proto3" json:"before,omitempty"`
+	After         int64 `protobuf:"varint,7,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForecastChange) Reset() {
+	*x = ForecastChange{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastChange) ProtoMessage() {}
+
+func (x *ForecastChange) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastChange.ProtoReflect.Descriptor instead.
+func (*ForecastChange) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ForecastChange) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *ForecastChange) GetSubscriptionId() int32 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *ForecastChange) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ForecastChange) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ForecastChange) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *ForecastChange) GetBefore() int64 {
+	if x != nil {
+		return x.Before
+	}
+	return 0
+}
+
+func (x *ForecastChange) GetAfter() int64 {
+	if x != nil {
+		return x.After
+	}
+	return 0
+}
+
+type ForecastResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Months []*ForecastMonth       `protobuf:"bytes,1,rep,name=months,proto3" json:"months,omitempty"`
+	// the first month is compared with the month before it
+	Changes       []*ForecastChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForecastResponse) Reset() {
+	*x = ForecastResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastResponse) ProtoMessage() {}
+
+func (x *ForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastResponse.ProtoReflect.Descriptor instead.
+func (*ForecastResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ForecastResponse) GetMonths() []*ForecastMonth {
+	if x != nil {
+		return x.Months
+	}
+	return nil
+}
+
+func (x *ForecastResponse) GetChanges() []*ForecastChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type CompareRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FilterFrom string                 `protobuf:"bytes,1,opt,name=filter_from,json=filterFrom,proto3" json:"filter_from,omitempty"`
+	FilterTo   string                 `protobuf:"bytes,2,opt,name=filter_to,json=filterTo,proto3" json:"filter_to,omitempty"`
+	// base period, both or neither are set
+	BaseFrom string `protobuf:"bytes,3,opt,name=base_from,json=baseFrom,proto3" json:"base_from,omitempty"`
+	BaseTo   string `protobuf:"bytes,4,opt,name=base_to,json=baseTo,proto3" json:"base_to,omitempty"`
+	// base period when base_from and base_to are empty: "previous" (default) or "year"
+	Against     string `protobuf:"bytes,5,opt,name=against,proto3" json:"against,omitempty"`
+	UserId      string `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName string `protobuf:"bytes,7,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// "monthly" (default) or "prorated"
+	Mode          string `protobuf:"bytes,8,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareRequest) Reset() {
+	*x = CompareRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareRequest) ProtoMessage() {}
+
+func (x *CompareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareRequest.ProtoReflect.Descriptor instead.
+func (*CompareRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CompareRequest) GetFilterFrom() string {
+	if x != nil {
+		return x.FilterFrom
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetFilterTo() string {
+	if x != nil {
+		return x.FilterTo
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetBaseFrom() string {
+	if x != nil {
+		return x.BaseFrom
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetBaseTo() string {
+	if x != nil {
+		return x.BaseTo
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetAgainst() string {
+	if x != nil {
+		return x.Against
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type ComparisonTotals struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gross         int64                  `protobuf:"varint,1,opt,name=gross,proto3" json:"gross,omitempty"`
+	Discount      int64                  `protobuf:"varint,2,opt,name=discount,proto3" json:"discount,omitempty"`
+	Net           int64                  `protobuf:"varint,3,opt,name=net,proto3" json:"net,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComparisonTotals) Reset() {
+	*x = ComparisonTotals{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComparisonTotals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparisonTotals) ProtoMessage() {}
+
+func (x *ComparisonTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparisonTotals.ProtoReflect.Descriptor instead.
+func (*ComparisonTotals) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ComparisonTotals) GetGross() int64 {
+	if x != nil {
+		return x.Gross
+	}
+	return 0
+}
+
+func (x *ComparisonTotals) GetDiscount() int64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *ComparisonTotals) GetNet() int64 {
+	if x != nil {
+		return x.Net
+	}
+	return 0
+}
+
+type ComparisonGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// started, ended, price_changed or unchanged
+	Kind          string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Subscriptions int32  `protobuf:"varint,2,opt,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	Base          int64  `protobuf:"varint,3,opt,name=base,proto3" json:"base,omitempty"`
+	Current       int64  `protobuf:"varint,4,opt,name=current,proto3" json:"current,omitempty"`
+	Delta         int64  `protobuf:"varint,5,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComparisonGroup) Reset() {
+	*x = ComparisonGroup{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComparisonGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparisonGroup) ProtoMessage() {}
+
+func (x *ComparisonGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparisonGroup.ProtoReflect.Descriptor instead.
+func (*ComparisonGroup) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ComparisonGroup) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ComparisonGroup) GetSubscriptions() int32 {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return 0
+}
+
+func (x *ComparisonGroup) GetBase() int64 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+func (x *ComparisonGroup) GetCurrent() int64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *ComparisonGroup) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type SubscriptionDelta struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId int32                  `protobuf:"varint,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	ServiceName    string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Kind           string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	// net charges of the base and of the current period
+	Base          int64 `protobuf:"varint,5,opt,name=base,proto3" json:"base,omitempty"`
+	Current       int64 `protobuf:"varint,6,opt,name=current,proto3" json:"current,omitempty"`
+	Delta         int64 `protobuf:"varint,7,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscriptionDelta) Reset() {
+	*x = SubscriptionDelta{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriptionDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionDelta) ProtoMessage() {}
+
+func (x *SubscriptionDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionDelta.ProtoReflect.Descriptor instead.
+func (*SubscriptionDelta) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SubscriptionDelta) GetSubscriptionId() int32 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *SubscriptionDelta) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *SubscriptionDelta) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SubscriptionDelta) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SubscriptionDelta) GetBase() int64 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+func (x *SubscriptionDelta) GetCurrent() int64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *SubscriptionDelta) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type CompareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseFrom      string                 `protobuf:"bytes,1,opt,name=base_from,json=baseFrom,proto3" json:"base_from,omitempty"`
+	BaseTo        string                 `protobuf:"bytes,2,opt,name=base_to,json=baseTo,proto3" json:"base_to,omitempty"`
+	FilterFrom    string                 `protobuf:"bytes,3,opt,name=filter_from,json=filterFrom,proto3" json:"filter_from,omitempty"`
+	FilterTo      string                 `protobuf:"bytes,4,opt,name=filter_to,json=filterTo,proto3" json:"filter_to,omitempty"`
+	BaseTotals    *ComparisonTotals      `protobuf:"bytes,5,opt,name=base_totals,json=baseTotals,proto3" json:"base_totals,omitempty"`
+	CurrentTotals *ComparisonTotals      `protobuf:"bytes,6,opt,name=current_totals,json=currentTotals,proto3" json:"current_totals,omitempty"`
+	Delta         *ComparisonTotals      `protobuf:"bytes,7,opt,name=delta,proto3" json:"delta,omitempty"`
+	// net delta relative to the base net spend, unset when the base spend is zero
+	Percent *float64 `protobuf:"fixed64,8,opt,name=percent,proto3,oneof" json:"percent,omitempty"`
+	// every kind in the order started, ended, price_changed, unchanged, the deltas add up to delta.net
+	Attribution   []*ComparisonGroup   `protobuf:"bytes,9,rep,name=attribution,proto3" json:"attribution,omitempty"`
+	Subscriptions []*SubscriptionDelta `protobuf:"bytes,10,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareResponse) Reset() {
+	*x = CompareResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareResponse) ProtoMessage() {}
+
+func (x *CompareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareResponse.ProtoReflect.Descriptor instead.
+func (*CompareResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CompareResponse) GetBaseFrom() string {
+	if x != nil {
+		return x.BaseFrom
+	}
+	return ""
+}
+
+func (x *CompareResponse) GetBaseTo() string {
+	if x != nil {
+		return x.BaseTo
+	}
+	return ""
+}
+
+func (x *CompareResponse) GetFilterFrom() string {
+	if x != nil {
+		return x.FilterFrom
+	}
+	return ""
+}
+
+func (x *CompareResponse) GetFilterTo() string {
+	if x != nil {
+		return x.FilterTo
+	}
+	return ""
+}
+
+func (x *CompareResponse) GetBaseTotals() *ComparisonTotals {
+	if x != nil {
+		return x.BaseTotals
+	}
+	return nil
+}
+
+func (x *CompareResponse) GetCurrentTotals() *ComparisonTotals {
+	if x != nil {
+		return x.CurrentTotals
+	}
+	return nil
+}
+
+func (x *CompareResponse) GetDelta() *ComparisonTotals {
+	if x != nil {
+		return x.Delta
+	}
+	return nil
+}
+
+func (x *CompareResponse) GetPercent() float64 {
+	if x != nil && x.Percent != nil {
+		return *x.Percent
+	}
+	return 0
+}
+
+func (x *CompareResponse) GetAttribution() []*ComparisonGroup {
+	if x != nil {
+		return x.Attribution
+	}
+	return nil
+}
+
+func (x *CompareResponse) GetSubscriptions() []*SubscriptionDelta {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+type TrialsEndingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unset means the TRIAL_WARNING_DAYS of the server
+	Days          *int32 `protobuf:"varint,1,opt,name=days,proto3,oneof" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrialsEndingRequest) Reset() {
+	*x = TrialsEndingRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrialsEndingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrialsEndingRequest) ProtoMessage() {}
+
+func (x *TrialsEndingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrialsEndingRequest.ProtoReflect.Descriptor instead.
+func (*TrialsEndingRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TrialsEndingRequest) GetDays() int32 {
+	if x != nil && x.Days != nil {
+		return *x.Days
+	}
+	return 0
+}
+
+type TrialsEndingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*Subscription        `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrialsEndingResponse) Reset() {
+	*x = TrialsEndingResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrialsEndingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrialsEndingResponse) ProtoMessage() {}
+
+func (x *TrialsEndingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrialsEndingResponse.ProtoReflect.Descriptor instead.
+func (*TrialsEndingResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TrialsEndingResponse) GetSubscriptions() []*Subscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+type PauseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pause         *Pause                 `protobuf:"bytes,1,opt,name=pause,proto3" json:"pause,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseRequest) Reset() {
+	*x = PauseRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseRequest) ProtoMessage() {}
+
+func (x *PauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseRequest.ProtoReflect.Descriptor instead.
+func (*PauseRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PauseRequest) GetPause() *Pause {
+	if x != nil {
+		return x.Pause
+	}
+	return nil
+}
+
+type PauseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseResponse) Reset() {
+	*x = PauseResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseResponse) ProtoMessage() {}
+
+func (x *PauseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseResponse.ProtoReflect.Descriptor instead.
+func (*PauseResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PauseResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ResumeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId int32                  `protobuf:"varint,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	// first billed day
+	Date          string `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeRequest) Reset() {
+	*x = ResumeRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeRequest) ProtoMessage() {}
+
+func (x *ResumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeRequest.ProtoReflect.Descriptor instead.
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ResumeRequest) GetSubscriptionId() int32 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *ResumeRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type ResumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeResponse) Reset() {
+	*x = ResumeResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeResponse) ProtoMessage() {}
+
+func (x *ResumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeResponse.ProtoReflect.Descriptor instead.
+func (*ResumeResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{33}
+}
+
+type DeletePauseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePauseRequest) Reset() {
+	*x = DeletePauseRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePauseRequest) ProtoMessage() {}
+
+func (x *DeletePauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePauseRequest.ProtoReflect.Descriptor instead.
+func (*DeletePauseRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeletePauseRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeletePauseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePauseResponse) Reset() {
+	*x = DeletePauseResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePauseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePauseResponse) ProtoMessage() {}
+
+func (x *DeletePauseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePauseResponse.ProtoReflect.Descriptor instead.
+func (*DeletePauseResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{35}
+}
+
+type AddDiscountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Discount      *Discount              `protobuf:"bytes,1,opt,name=discount,proto3" json:"discount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDiscountRequest) Reset() {
+	*x = AddDiscountRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDiscountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDiscountRequest) ProtoMessage() {}
+
+func (x *AddDiscountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDiscountRequest.ProtoReflect.Descriptor instead.
+func (*AddDiscountRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AddDiscountRequest) GetDiscount() *Discount {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+type AddDiscountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDiscountResponse) Reset() {
+	*x = AddDiscountResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDiscountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDiscountResponse) ProtoMessage() {}
+
+func (x *AddDiscountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDiscountResponse.ProtoReflect.Descriptor instead.
+func (*AddDiscountResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AddDiscountResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteDiscountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDiscountRequest) Reset() {
+	*x = DeleteDiscountRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDiscountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDiscountRequest) ProtoMessage() {}
+
+func (x *DeleteDiscountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDiscountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDiscountRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteDiscountRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteDiscountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDiscountResponse) Reset() {
+	*x = DeleteDiscountResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDiscountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDiscountResponse) ProtoMessage() {}
+
+func (x *DeleteDiscountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDiscountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDiscountResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{39}
+}
+
+type AddPriceChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceChange   *PriceChange           `protobuf:"bytes,1,opt,name=price_change,json=priceChange,proto3" json:"price_change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPriceChangeRequest) Reset() {
+	*x = AddPriceChangeRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPriceChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPriceChangeRequest) ProtoMessage() {}
+
+func (x *AddPriceChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPriceChangeRequest.ProtoReflect.Descriptor instead.
+func (*AddPriceChangeRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AddPriceChangeRequest) GetPriceChange() *PriceChange {
+	if x != nil {
+		return x.PriceChange
+	}
+	return nil
+}
+
+type AddPriceChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPriceChangeResponse) Reset() {
+	*x = AddPriceChangeResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPriceChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPriceChangeResponse) ProtoMessage() {}
+
+func (x *AddPriceChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPriceChangeResponse.ProtoReflect.Descriptor instead.
+func (*AddPriceChangeResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AddPriceChangeResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeletePriceChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePriceChangeRequest) Reset() {
+	*x = DeletePriceChangeRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePriceChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePriceChangeRequest) ProtoMessage() {}
+
+func (x *DeletePriceChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePriceChangeRequest.ProtoReflect.Descriptor instead.
+func (*DeletePriceChangeRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DeletePriceChangeRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeletePriceChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePriceChangeResponse) Reset() {
+	*x = DeletePriceChangeResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePriceChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePriceChangeResponse) ProtoMessage() {}
+
+func (x *DeletePriceChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePriceChangeResponse.ProtoReflect.Descriptor instead.
+func (*DeletePriceChangeResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{43}
+}
+
+type DuplicatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// all users when empty
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicatesRequest) Reset() {
+	*x = DuplicatesRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicatesRequest) ProtoMessage() {}
+
+func (x *DuplicatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicatesRequest.ProtoReflect.Descriptor instead.
+func (*DuplicatesRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DuplicatesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type Overlap struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the pair of duplicates, the earlier one first
+	SubscriptionIds []int32 `protobuf:"varint,1,rep,packed,name=subscription_ids,json=subscriptionIds,proto3" json:"subscription_ids,omitempty"`
+	// "same_service" or "similar_name"
+	Match string `protobuf:"bytes,2,opt,name=match,proto3" json:"match,omitempty"`
+	From  string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// empty when neither of them finishes
+	To            string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Overlap) Reset() {
+	*x = Overlap{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Overlap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Overlap) ProtoMessage() {}
+
+func (x *Overlap) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Overlap.ProtoReflect.Descriptor instead.
+func (*Overlap) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Overlap) GetSubscriptionIds() []int32 {
+	if x != nil {
+		return x.SubscriptionIds
+	}
+	return nil
+}
+
+func (x *Overlap) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *Overlap) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Overlap) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type DuplicateGroup struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// name of the earliest subscription
+	ServiceName string `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// ordered by start date
+	Subscriptions []*Subscription `protobuf:"bytes,3,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	Overlaps      []*Overlap      `protobuf:"bytes,4,rep,name=overlaps,proto3" json:"overlaps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateGroup) Reset() {
+	*x = DuplicateGroup{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateGroup) ProtoMessage() {}
+
+func (x *DuplicateGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateGroup.ProtoReflect.Descriptor instead.
+func (*DuplicateGroup) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DuplicateGroup) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DuplicateGroup) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *DuplicateGroup) GetSubscriptions() []*Subscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *DuplicateGroup) GetOverlaps() []*Overlap {
+	if x != nil {
+		return x.Overlaps
+	}
+	return nil
+}
+
+type DuplicatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*DuplicateGroup      `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicatesResponse) Reset() {
+	*x = DuplicatesResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicatesResponse) ProtoMessage() {}
+
+func (x *DuplicatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicatesResponse.ProtoReflect.Descriptor instead.
+func (*DuplicatesResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DuplicatesResponse) GetGroups() []*DuplicateGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type MergeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the subscription that stays
+	Into          int32   `protobuf:"varint,1,opt,name=into,proto3" json:"into,omitempty"`
+	Ids           []int32 `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeRequest) Reset() {
+	*x = MergeRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeRequest) ProtoMessage() {}
+
+func (x *MergeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeRequest.ProtoReflect.Descriptor instead.
+func (*MergeRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *MergeRequest) GetInto() int32 {
+	if x != nil {
+		return x.Into
+	}
+	return 0
+}
+
+func (x *MergeRequest) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type MergeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeResponse) Reset() {
+	*x = MergeResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeResponse) ProtoMessage() {}
+
+func (x *MergeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeResponse.ProtoReflect.Descriptor instead.
+func (*MergeResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *MergeResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type MergesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergesRequest) Reset() {
+	*x = MergesRequest{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergesRequest) ProtoMessage() {}
+
+func (x *MergesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergesRequest.ProtoReflect.Descriptor instead.
+func (*MergesRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *MergesRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type MergedRecord struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubscriptionId int32                  `protobuf:"varint,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	MergedId       int32                  `protobuf:"varint,3,opt,name=merged_id,json=mergedId,proto3" json:"merged_id,omitempty"`
+	// the deleted subscription with its pauses, discounts and price changes
+	Record *Subscription `protobuf:"bytes,4,opt,name=record,proto3" json:"record,omitempty"`
+	// RFC 3339
+	MergedAt      string `protobuf:"bytes,5,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergedRecord) Reset() {
+	*x = MergedRecord{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergedRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergedRecord) ProtoMessage() {}
+
+func (x *MergedRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergedRecord.ProtoReflect.Descriptor instead.
+func (*MergedRecord) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *MergedRecord) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MergedRecord) GetSubscriptionId() int32 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *MergedRecord) GetMergedId() int32 {
+	if x != nil {
+		return x.MergedId
+	}
+	return 0
+}
+
+func (x *MergedRecord) GetRecord() *Subscription {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *MergedRecord) GetMergedAt() string {
+	if x != nil {
+		return x.MergedAt
+	}
+	return ""
+}
+
+type MergesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*MergedRecord        `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergesResponse) Reset() {
+	*x = MergesResponse{}
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergesResponse) ProtoMessage() {}
+
+func (x *MergesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_v1_subscriptions_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergesResponse.ProtoReflect.Descriptor instead.
+func (*MergesResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_v1_subscriptions_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *MergesResponse) GetRecords() []*MergedRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+var File_subscriptions_v1_subscriptions_proto protoreflect.FileDescriptor
+
+const file_subscriptions_v1_subscriptions_proto_rawDesc = "" +
+	"\n" +
+	"$subscriptions/v1/subscriptions.proto\x12\x10subscriptions.v1\"\xb7\x04\n" +
+	"\fSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x03R\x05price\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\tR\tstartDate\x12\x1f\n" +
+	"\vfinish_date\x18\x06 \x01(\tR\n" +
+	"finishDate\x12\x1f\n" +
+	"\vbilling_day\x18\a \x01(\x05R\n" +
+	"billingDay\x12\x1d\n" +
+	"\n" +
+	"trial_days\x18\b \x01(\x05R\ttrialDays\x12\x1f\n" +
+	"\vtrial_price\x18\t \x01(\x03R\n" +
+	"trialPrice\x12!\n" +
+	"\fintro_months\x18\n" +
+	" \x01(\x05R\vintroMonths\x12\x1f\n" +
+	"\vintro_price\x18\v \x01(\x03R\n" +
+	"introPrice\x12/\n" +
+	"\x06pauses\x18\f \x03(\v2\x17.subscriptions.v1.PauseR\x06pauses\x128\n" +
+	"\tdiscounts\x18\r \x03(\v2\x1a.subscriptions.v1.DiscountR\tdiscounts\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x0e \x01(\x05R\tserviceId\x12\x12\n" +
+	"\x04tags\x18\x0f \x03(\tR\x04tags\x12B\n" +
+	"\rprice_changes\x18\x10 \x03(\v2\x1d.subscriptions.v1.PriceChangeR\fpriceChanges\"\x80\x01\n" +
+	"\x05Pause\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\x05R\x0esubscriptionId\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\tR\tstartDate\x12\x1f\n" +
+	"\vfinish_date\x18\x04 \x01(\tR\n" +
+	"finishDate\"\xbc\x01\n" +
+	"\bDiscount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\x05R\x0esubscriptionId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x1f\n" +
+	"\vstart_month\x18\x05 \x01(\tR\n" +
+	"startMonth\x12\x16\n" +
+	"\x06months\x18\x06 \x01(\x05R\x06months\x12\x12\n" +
+	"\x04code\x18\a \x01(\tR\x04code\"\x83\x01\n" +
+	"\vPriceChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\x05R\x0esubscriptionId\x12%\n" +
+	"\x0eeffective_date\x18\x03 \x01(\tR\reffectiveDate\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\"S\n" +
+	"\rCreateRequest\x12B\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1e.subscriptions.v1.SubscriptionR\fsubscription\"E\n" +
+	"\x0eCreateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12#\n" +
+	"\rduplicate_ids\x18\x02 \x03(\x05R\fduplicateIds\"\x1d\n" +
+	"\vReadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"R\n" +
+	"\fReadResponse\x12B\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1e.subscriptions.v1.SubscriptionR\fsubscription\"S\n" +
+	"\rUpdateRequest\x12B\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1e.subscriptions.v1.SubscriptionR\fsubscription\"5\n" +
+	"\x0eUpdateResponse\x12#\n" +
+	"\rduplicate_ids\x18\x01 \x03(\x05R\fduplicateIds\"\x1f\n" +
+	"\rDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\x10\n" +
+	"\x0eDeleteResponse\"I\n" +
+	"\vListRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\"R\n" +
+	"\fListResponse\x12B\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1e.subscriptions.v1.SubscriptionR\fsubscription\"\x9a\x01\n" +
+	"\n" +
+	"SumRequest\x12\x1f\n" +
+	"\vfilter_from\x18\x01 \x01(\tR\n" +
+	"filterFrom\x12\x1b\n" +
+	"\tfilter_to\x18\x02 \x01(\tR\bfilterTo\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x04 \x01(\tR\vserviceName\x12\x12\n" +
+	"\x04mode\x18\x05 \x01(\tR\x04mode\"Q\n" +
+	"\vSumResponse\x12\x10\n" +
+	"\x03sum\x18\x01 \x01(\x03R\x03sum\x12\x14\n" +
+	"\x05gross\x18\x02 \x01(\x03R\x05gross\x12\x1a\n" +
+	"\bdiscount\x18\x03 \x01(\x03R\bdiscount\"\xcc\x01\n" +
+	"\rReportRequest\x12\x1f\n" +
+	"\vfilter_from\x18\x01 \x01(\tR\n" +
+	"filterFrom\x12\x1b\n" +
+	"\tfilter_to\x18\x02 \x01(\tR\bfilterTo\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x04 \x01(\tR\vserviceName\x12\x12\n" +
+	"\x04mode\x18\x05 \x01(\tR\x04mode\x12\x19\n" +
+	"\bgroup_by\x18\x06 \x01(\tR\agroupBy\x12\x12\n" +
+	"\x04tags\x18\a \x01(\tR\x04tags\"\x8b\x01\n" +
+	"\vReportGroup\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12$\n" +
+	"\rsubscriptions\x18\x02 \x01(\x05R\rsubscriptions\x12\x14\n" +
+	"\x05gross\x18\x03 \x01(\x03R\x05gross\x12\x1a\n" +
+	"\bdiscount\x18\x04 \x01(\x03R\bdiscount\x12\x10\n" +
+	"\x03net\x18\x05 \x01(\x03R\x03net\"\xa6\x01\n" +
+	"\x0eReportResponse\x12\x19\n" +
+	"\bgroup_by\x18\x01 \x01(\tR\agroupBy\x125\n" +
+	"\x06groups\x18\x02 \x03(\v2\x1d.subscriptions.v1.ReportGroupR\x06groups\x12\x14\n" +
+	"\x05gross\x18\x03 \x01(\x03R\x05gross\x12\x1a\n" +
+	"\bdiscount\x18\x04 \x01(\x03R\bdiscount\x12\x10\n" +
+	"\x03net\x18\x05 \x01(\x03R\x03net\"y\n" +
+	"\x0fForecastRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x16\n" +
+	"\x06months\x18\x02 \x01(\x05R\x06months\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x04 \x01(\tR\vserviceName\"\x8f\x01\n" +
+	"\rForecastMonth\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\x12$\n" +
+	"\rsubscriptions\x18\x02 \x01(\x05R\rsubscriptions\x12\x14\n" +
+	"\x05gross\x18\x03 \x01(\x03R\x05gross\x12\x1a\n" +
+	"\bdiscount\x18\x04 \x01(\x03R\bdiscount\x12\x10\n" +
+	"\x03net\x18\x05 \x01(\x03R\x03net\"\xd3\x01\n" +
+	"\x0eForecastChange\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\x05R\x0esubscriptionId\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x18\n" +
+	"\areasons\x18\x05 \x03(\tR\areasons\x12\x16\n" +
+	"\x06before\x18\x06 \x01(\x03R\x06before\x12\x14\n" +
+	"\x05after\x18\a \x01(\x03R\x05after\"\x87\x01\n" +
+	"\x10ForecastResponse\x127\n" +
+	"\x06months\x18\x01 \x03(\v2\x1f.subscriptions.v1.ForecastMonthR\x06months\x12:\n" +
+	"\achanges\x18\x02 \x03(\v2 .subscriptions.v1.ForecastChangeR\achanges\"\xee\x01\n" +
+	"\x0eCompareRequest\x12\x1f\n" +
+	"\vfilter_from\x18\x01 \x01(\tR\n" +
+	"filterFrom\x12\x1b\n" +
+	"\tfilter_to\x18\x02 \x01(\tR\bfilterTo\x12\x1b\n" +
+	"\tbase_from\x18\x03 \x01(\tR\bbaseFrom\x12\x17\n" +
+	"\abase_to\x18\x04 \x01(\tR\x06baseTo\x12\x18\n" +
+	"\aagainst\x18\x05 \x01(\tR\aagainst\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\a \x01(\tR\vserviceName\x12\x12\n" +
+	"\x04mode\x18\b \x01(\tR\x04mode\"V\n" +
+	"\x10ComparisonTotals\x12\x14\n" +
+	"\x05gross\x18\x01 \x01(\x03R\x05gross\x12\x1a\n" +
+	"\bdiscount\x18\x02 \x01(\x03R\bdiscount\x12\x10\n" +
+	"\x03net\x18\x03 \x01(\x03R\x03net\"\x8f\x01\n" +
+	"\x0fComparisonGroup\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12$\n" +
+	"\rsubscriptions\x18\x02 \x01(\x05R\rsubscriptions\x12\x12\n" +
+	"\x04base\x18\x03 \x01(\x03R\x04base\x12\x18\n" +
+	"\acurrent\x18\x04 \x01(\x03R\acurrent\x12\x14\n" +
+	"\x05delta\x18\x05 \x01(\x03R\x05delta\"\xd0\x01\n" +
+	"\x11SubscriptionDelta\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\x05R\x0esubscriptionId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04base\x18\x05 \x01(\x03R\x04base\x12\x18\n" +
+	"\acurrent\x18\x06 \x01(\x03R\acurrent\x12\x14\n" +
+	"\x05delta\x18\a \x01(\x03R\x05delta\"\x8a\x04\n" +
+	"\x0fCompareResponse\x12\x1b\n" +
+	"\tbase_from\x18\x01 \x01(\tR\bbaseFrom\x12\x17\n" +
+	"\abase_to\x18\x02 \x01(\tR\x06baseTo\x12\x1f\n" +
+	"\vfilter_from\x18\x03 \x01(\tR\n" +
+	"filterFrom\x12\x1b\n" +
+	"\tfilter_to\x18\x04 \x01(\tR\bfilterTo\x12C\n" +
+	"\vbase_totals\x18\x05 \x01(\v2\".subscriptions.v1.ComparisonTotalsR\n" +
+	"baseTotals\x12I\n" +
+	"\x0ecurrent_totals\x18\x06 \x01(\v2\".subscriptions.v1.ComparisonTotalsR\rcurrentTotals\x128\n" +
+	"\x05delta\x18\a \x01(\v2\".subscriptions.v1.ComparisonTotalsR\x05delta\x12\x1d\n" +
+	"\apercent\x18\b \x01(\x01H\x00R\apercent\x88\x01\x01\x12C\n" +
+	"\vattribution\x18\t \x03(\v2!.subscriptions.v1.ComparisonGroupR\vattribution\x12I\n" +
+	"\rsubscriptions\x18\n" +
+	" \x03(\v2#.subscriptions.v1.SubscriptionDeltaR\rsubscriptionsB\n" +
+	"\n" +
+	"\b_percent\"7\n" +
+	"\x13TrialsEndingRequest\x12\x17\n" +
+	"\x04days\x18\x01 \x01(\x05H\x00R\x04days\x88\x01\x01B\a\n" +
+	"\x05_days\"\\\n" +
+	"\x14TrialsEndingResponse\x12D\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2\x1e.subscriptions.v1.SubscriptionR\rsubscriptions\"=\n" +
+	"\fPauseRequest\x12-\n" +
+	"\x05pause\x18\x01 \x01(\v2\x17.subscriptions.v1.PauseR\x05pause\"\x1f\n" +
+	"\rPauseResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"L\n" +
+	"\rResumeRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\x05R\x0esubscriptionId\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\"\x10\n" +
+	"\x0eResumeResponse\"$\n" +
+	"\x12DeletePauseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\x15\n" +
+	"\x13DeletePauseResponse\"L\n" +
+	"\x12AddDiscountRequest\x126\n" +
+	"\bdiscount\x18\x01 \x01(\v2\x1a.subscriptions.v1.DiscountR\bdiscount\"%\n" +
+	"\x13AddDiscountResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"'\n" +
+	"\x15DeleteDiscountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\x18\n" +
+	"\x16DeleteDiscountResponse\"Y\n" +
+	"\x15AddPriceChangeRequest\x12@\n" +
+	"\fprice_change\x18\x01 \x01(\v2\x1d.subscriptions.v1.PriceChangeR\vpriceChange\"(\n" +
+	"\x16AddPriceChangeResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"*\n" +
+	"\x18DeletePriceChangeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\x1b\n" +
+	"\x19DeletePriceChangeResponse\",\n" +
+	"\x11DuplicatesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"n\n" +
+	"\aOverlap\x12)\n" +
+	"\x10subscription_ids\x18\x01 \x03(\x05R\x0fsubscriptionIds\x12\x14\n" +
+	"\x05match\x18\x02 \x01(\tR\x05match\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\"\xc9\x01\n" +
+	"\x0eDuplicateGroup\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12D\n" +
+	"\rsubscriptions\x18\x03 \x03(\v2\x1e.subscriptions.v1.SubscriptionR\rsubscriptions\x125\n" +
+	"\boverlaps\x18\x04 \x03(\v2\x19.subscriptions.v1.OverlapR\boverlaps\"N\n" +
+	"\x12DuplicatesResponse\x128\n" +
+	"\x06groups\x18\x01 \x03(\v2 .subscriptions.v1.DuplicateGroupR\x06groups\"4\n" +
+	"\fMergeRequest\x12\x12\n" +
+	"\x04into\x18\x01 \x01(\x05R\x04into\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\x05R\x03ids\"S\n" +
+	"\rMergeResponse\x12B\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1e.subscriptions.v1.SubscriptionR\fsubscription\"\x1f\n" +
+	"\rMergesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\xb9\x01\n" +
+	"\fMergedRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\x05R\x0esubscriptionId\x12\x1b\n" +
+	"\tmerged_id\x18\x03 \x01(\x05R\bmergedId\x126\n" +
+	"\x06record\x18\x04 \x01(\v2\x1e.subscriptions.v1.SubscriptionR\x06record\x12\x1b\n" +
+	"\tmerged_at\x18\x05 \x01(\tR\bmergedAt\"J\n" +
+	"\x0eMergesResponse\x128\n" +
+	"\arecords\x18\x01 \x03(\v2\x1e.subscriptions.v1.MergedRecordR\arecords2\x96\r\n" +
+	"\x13SubscriptionService\x12K\n" +
+	"\x06Create\x12\x1f.subscriptions.v1.CreateRequest\x1a .subscriptions.v1.CreateResponse\x12E\n" +
+	"\x04Read\x12\x1d.subscriptions.v1.ReadRequest\x1a\x1e.subscriptions.v1.ReadResponse\x12K\n" +
+	"\x06Update\x12\x1f.subscriptions.v1.UpdateRequest\x1a .subscriptions.v1.UpdateResponse\x12K\n" +
+	"\x06Delete\x12\x1f.subscriptions.v1.DeleteRequest\x1a .subscriptions.v1.DeleteResponse\x12G\n" +
+	"\x04List\x12\x1d.subscriptions.v1.ListRequest\x1a\x1e.subscriptions.v1.ListResponse0\x01\x12B\n" +
+	"\x03Sum\x12\x1c.subscriptions.v1.SumRequest\x1a\x1d.subscriptions.v1.SumResponse\x12K\n" +
+	"\x06Report\x12\x1f.subscriptions.v1.ReportRequest\x1a .subscriptions.v1.ReportResponse\x12Q\n" +
+	"\bForecast\x12!.subscriptions.v1.ForecastRequest\x1a\".subscriptions.v1.ForecastResponse\x12N\n" +
+	"\aCompare\x12 .subscriptions.v1.CompareRequest\x1a!.subscriptions.v1.CompareResponse\x12]\n" +
+	"\fTrialsEnding\x12%.subscriptions.v1.TrialsEndingRequest\x1a&.subscriptions.v1.TrialsEndingResponse\x12H\n" +
+	"\x05Pause\x12\x1e.subscriptions.v1.PauseRequest\x1a\x1f.subscriptions.v1.PauseResponse\x12K\n" +
+	"\x06Resume\x12\x1f.subscriptions.v1.ResumeRequest\x1a .subscriptions.v1.ResumeResponse\x12Z\n" +
+	"\vDeletePause\x12$.subscriptions.v1.DeletePauseRequest\x1a%.subscriptions.v1.DeletePauseResponse\x12Z\n" +
+	"\vAddDiscount\x12$.subscriptions.v1.AddDiscountRequest\x1a%.subscriptions.v1.AddDiscountResponse\x12c\n" +
+	"\x0eDeleteDiscount\x12'.subscriptions.v1.DeleteDiscountRequest\x1a(.subscriptions.v1.DeleteDiscountResponse\x12c\n" +
+	"\x0eAddPriceChange\x12'.subscriptions.v1.AddPriceChangeRequest\x1a(.subscriptions.v1.AddPriceChangeResponse\x12l\n" +
+	"\x11DeletePriceChange\x12*.subscriptions.v1.DeletePriceChangeRequest\x1a+.subscriptions.v1.DeletePriceChangeResponse\x12W\n" +
+	"\n" +
+	"Duplicates\x12#.subscriptions.v1.DuplicatesRequest\x1a$.subscriptions.v1.DuplicatesResponse\x12H\n" +
+	"\x05Merge\x12\x1e.subscriptions.v1.MergeRequest\x1a\x1f.subscriptions.v1.MergeResponse\x12K\n" +
+	"\x06Merges\x12\x1f.subscriptions.v1.MergesRequest\x1a .subscriptions.v1.MergesResponseBPZNgithub.com/zakharova-e/subscriptions-info/api/subscriptions/v1;subscriptionsv1b\x06proto3"
+
+var (
+	file_subscriptions_v1_subscriptions_proto_rawDescOnce sync.Once
+	file_subscriptions_v1_subscriptions_proto_rawDescData []byte
+)
+
+func file_subscriptions_v1_subscriptions_proto_rawDescGZIP() []byte {
+	file_subscriptions_v1_subscriptions_proto_rawDescOnce.Do(func() {
+		file_subscriptions_v1_subscriptions_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_subscriptions_v1_subscriptions_proto_rawDesc), len(file_subscriptions_v1_subscriptions_proto_rawDesc)))
+	})
+	return file_subscriptions_v1_subscriptions_proto_rawDescData
+}
+
+var file_subscriptions_v1_subscriptions_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
+var file_subscriptions_v1_subscriptions_proto_goTypes = []any{
+	(*Subscription)(nil),              // 0: subscriptions.v1.Subscription
+	(*Pause)(nil),                     // 1: subscriptions.v1.Pause
+	(*Discount)(nil),                  // 2: subscriptions.v1.Discount
+	(*PriceChange)(nil),               // 3: subscriptions.v1.PriceChange
+	(*CreateRequest)(nil),             // 4: subscriptions.v1.CreateRequest
+	(*CreateResponse)(nil),            // 5: subscriptions.v1.CreateResponse
+	(*ReadRequest)(nil),               // 6: subscriptions.v1.ReadRequest
+	(*ReadResponse)(nil),              // 7: subscriptions.v1.ReadResponse
+	(*UpdateRequest)(nil),             // 8: subscriptions.v1.UpdateRequest
+	(*UpdateResponse)(nil),            // 9: subscriptions.v1.UpdateResponse
+	(*DeleteRequest)(nil),             // 10: subscriptions.v1.DeleteRequest
+	(*DeleteResponse)(nil),            // 11: subscriptions.v1.DeleteResponse
+	(*ListRequest)(nil),               // 12: subscriptions.v1.ListRequest
+	(*ListResponse)(nil),              // 13: subscriptions.v1.ListResponse
+	(*SumRequest)(nil),                // 14: subscriptions.v1.SumRequest
+	(*SumResponse)(nil),               // 15: subscriptions.v1.SumResponse
+	(*ReportRequest)(nil),             // 16: subscriptions.v1.ReportRequest
+	(*ReportGroup)(nil),               // 17: subscriptions.v1.ReportGroup
+	(*ReportResponse)(nil),            // 18: subscriptions.v1.ReportResponse
+	(*ForecastRequest)(nil),           // 19: subscriptions.v1.ForecastRequest
+	(*ForecastMonth)(nil),             // 20: subscriptions.v1.ForecastMonth
+	(*ForecastChange)(nil),            // 21: subscriptions.v1.ForecastChange
+	(*ForecastResponse)(nil),          // 22: subscriptions.v1.ForecastResponse
+	(*CompareRequest)(nil),            // 23: subscriptions.v1.CompareRequest
+	(*ComparisonTotals)(nil),          // 24: subscriptions.v1.ComparisonTotals
+	(*ComparisonGroup)(nil),           // 25: subscriptions.v1.ComparisonGroup
+	(*SubscriptionDelta)(nil),         // 26: subscriptions.v1.SubscriptionDelta
+	(*CompareResponse)(nil),           // 27: subscriptions.v1.CompareResponse
+	(*TrialsEndingRequest)(nil),       // 28: subscriptions.v1.TrialsEndingRequest
+	(*TrialsEndingResponse)(nil),      // 29: subscriptions.v1.TrialsEndingResponse
+	(*PauseRequest)(nil),              // 30: subscriptions.v1.PauseRequest
+	(*PauseResponse)(nil),             // 31: subscriptions.v1.PauseResponse
+	(*ResumeRequest)(nil),             // 32: subscriptions.v1.ResumeRequest
+	(*ResumeResponse)(nil),            // 33: subscriptions.v1.ResumeResponse
+	(*DeletePauseRequest)(nil),        // 34: subscriptions.v1.DeletePauseRequest
+	(*DeletePauseResponse)(nil),       // 35: subscriptions.v1.DeletePauseResponse
+	(*AddDiscountRequest)(nil),        // 36: subscriptions.v1.AddDiscountRequest
+	(*AddDiscountResponse)(nil),       // 37: subscriptions.v1.AddDiscountResponse
+	(*DeleteDiscountRequest)(nil),     // 38: subscriptions.v1.DeleteDiscountRequest
+	(*DeleteDiscountResponse)(nil),    // 39: subscriptions.v1.DeleteDiscountResponse
+	(*AddPriceChangeRequest)(nil),     // 40: subscriptions.v1.AddPriceChangeRequest
+	(*AddPriceChangeResponse)(nil),    // 41: subscriptions.v1.AddPriceChangeResponse
+	(*DeletePriceChangeRequest)(nil),  // 42: subscriptions.v1.DeletePriceChangeRequest
+	(*DeletePriceChangeResponse)(nil), // 43: subscriptions.v1.DeletePriceChangeResponse
+	(*DuplicatesRequest)(nil),         // 44: subscriptions.v1.DuplicatesRequest
+	(*Overlap)(nil),                   // 45: subscriptions.v1.Overlap
+	(*DuplicateGroup)(nil),            // 46: subscriptions.v1.DuplicateGroup
+	(*DuplicatesResponse)(nil),        // 47: subscriptions.v1.DuplicatesResponse
+	(*MergeRequest)(nil),              // 48: subscriptions.v1.MergeRequest
+	(*MergeResponse)(nil),             // 49: subscriptions.v1.MergeResponse
+	(*MergesRequest)(nil),             // 50: subscriptions.v1.MergesRequest
+	(*MergedRecord)(nil),              // 51: subscriptions.v1.MergedRecord
+	(*MergesResponse)(nil),            // 52: subscriptions.v1.MergesResponse
+}
+var file_subscriptions_v1_subscriptions_proto_depIdxs = []int32{
+	1,  // 0: subscriptions.v1.Subscription.pauses:type_name -> subscriptions.v1.Pause
+	2,  // 1: subscriptions.v1.Subscription.discounts:type_name -> subscriptions.v1.Discount
+	3,  // 2: subscriptions.v1.Subscription.price_changes:type_name -> subscriptions.v1.PriceChange
+	0,  // 3: subscriptions.v1.CreateRequest.subscription:type_name -> subscriptions.v1.Subscription
+	0,  // 4: subscriptions.v1.ReadResponse.subscription:type_name -> subscriptions.v1.Subscription
+	0,  // 5: subscriptions.v1.UpdateRequest.subscription:type_name -> subscriptions.v1.Subscription
+	0,  // 6: subscriptions.v1.ListResponse.subscription:type_name -> subscriptions.v1.Subscription
+	17, // 7: subscriptions.v1.ReportResponse.groups:type_name -> subscriptions.v1.ReportGroup
+	20, // 8: subscriptions.v1.ForecastResponse.months:type_name -> subscriptions.v1.ForecastMonth
+	21, // 9: subscriptions.v1.ForecastResponse.changes:type_name -> subscriptions.v1.ForecastChange
+	24, // 10: subscriptions.v1.CompareResponse.base_totals:type_name -> subscriptions.v1.ComparisonTotals
+	24, // 11: subscriptions.v1.CompareResponse.current_totals:type_name -> subscriptions.v1.ComparisonTotals
+	24, // 12: subscriptions.v1.CompareResponse.delta:type_name -> subscriptions.v1.ComparisonTotals
+	25, // 13: subscriptions.v1.CompareResponse.attribution:type_name -> subscriptions.v1.ComparisonGroup
+	26, // 14: subscriptions.v1.CompareResponse.subscriptions:type_name -> subscriptions.v1.SubscriptionDelta
+	0,  // 15: subscriptions.v1.TrialsEndingResponse.subscriptions:type_name -> subscriptions.v1.Subscription
+	1,  // 16: subscriptions.v1.PauseRequest.pause:type_name -> subscriptions.v1.Pause
+	2,  // 17: subscriptions.v1.AddDiscountRequest.discount:type_name -> subscriptions.v1.Discount
+	3,  // 18: subscriptions.v1.AddPriceChangeRequest.price_change:type_name -> subscriptions.v1.PriceChange
+	0,  // 19: subscriptions.v1.DuplicateGroup.subscriptions:type_name -> subscriptions.v1.Subscription
+	45, // 20: subscriptions.v1.DuplicateGroup.overlaps:type_name -> subscriptions.v1.Overlap
+	46, // 21: subscriptions.v1.DuplicatesResponse.groups:type_name -> subscriptions.v1.DuplicateGroup
+	0,  // 22: subscriptions.v1.MergeResponse.subscription:type_name -> subscriptions.v1.Subscription
+	0,  // 23: subscriptions.v1.MergedRecord.record:type_name -> subscriptions.v1.Subscription
+	51, // 24: subscriptions.v1.MergesResponse.records:type_name -> subscriptions.v1.MergedRecord
+	4,  // 25: subscriptions.v1.SubscriptionService.Create:input_type -> subscriptions.v1.CreateRequest
+	6,  // 26: subscriptions.v1.SubscriptionService.Read:input_type -> subscriptions.v1.ReadRequest
+	8,  // 27: subscriptions.v1.SubscriptionService.Update:input_type -> subscriptions.v1.UpdateRequest
+	10, // 28: subscriptions.v1.SubscriptionService.Delete:input_type -> subscriptions.v1.DeleteRequest
+	12, // 29: subscriptions.v1.SubscriptionService.List:input_type -> subscriptions.v1.ListRequest
+	14, // 30: subscriptions.v1.SubscriptionService.Sum:input_type -> subscriptions.v1.SumRequest
+	16, // 31: subscriptions.v1.SubscriptionService.Report:input_type -> subscriptions.v1.ReportRequest
+	19, // 32: subscriptions.v1.SubscriptionService.Forecast:input_type -> subscriptions.v1.ForecastRequest
+	23, // 33: subscriptions.v1.SubscriptionService.Compare:input_type -> subscriptions.v1.CompareRequest
+	28, // 34: subscriptions.v1.SubscriptionService.TrialsEnding:input_type -> subscriptions.v1.TrialsEndingRequest
+	30, // 35: subscriptions.v1.SubscriptionService.Pause:input_type -> subscriptions.v1.PauseRequest
+	32, // 36: subscriptions.v1.SubscriptionService.Resume:input_type -> subscriptions.v1.ResumeRequest
+	34, // 37: subscriptions.v1.SubscriptionService.DeletePause:input_type -> subscriptions.v1.DeletePauseRequest
+	36, // 38: subscriptions.v1.SubscriptionService.AddDiscount:input_type -> subscriptions.v1.AddDiscountRequest
+	38, // 39: subscriptions.v1.SubscriptionService.DeleteDiscount:input_type -> subscriptions.v1.DeleteDiscountRequest
+	40, // 40: subscriptions.v1.SubscriptionService.AddPriceChange:input_type -> subscriptions.v1.AddPriceChangeRequest
+	42, // 41: subscriptions.v1.SubscriptionService.DeletePriceChange:input_type -> subscriptions.v1.DeletePriceChangeRequest
+	44, // 42: subscriptions.v1.SubscriptionService.Duplicates:input_type -> subscriptions.v1.DuplicatesRequest
+	48, // 43: subscriptions.v1.SubscriptionService.Merge:input_type -> subscriptions.v1.MergeRequest
+	50, // 44: subscriptions.v1.SubscriptionService.Merges:input_type -> subscriptions.v1.MergesRequest
+	5,  // 45: subscriptions.v1.SubscriptionService.Create:output_type -> subscriptions.v1.CreateResponse
+	7,  // 46: subscriptions.v1.SubscriptionService.Read:output_type -> subscriptions.v1.ReadResponse
+	9,  // 47: subscriptions.v1.SubscriptionService.Update:output_type -> subscriptions.v1.UpdateResponse
+	11, // 48: subscriptions.v1.SubscriptionService.Delete:output_type -> subscriptions.v1.DeleteResponse
+	13, // 49: subscriptions.v1.SubscriptionService.List:output_type -> subscriptions.v1.ListResponse
+	15, // 50: subscriptions.v1.SubscriptionService.Sum:output_type -> subscriptions.v1.SumResponse
+	18, // 51: subscriptions.v1.SubscriptionService.Report:output_type -> subscriptions.v1.ReportResponse
+	22, // 52: subscriptions.v1.SubscriptionService.Forecast:output_type -> subscriptions.v1.ForecastResponse
+	27, // 53: subscriptions.v1.SubscriptionService.Compare:output_type -> subscriptions.v1.CompareResponse
+	29, // 54: subscriptions.v1.SubscriptionService.TrialsEnding:output_type -> subscriptions.v1.TrialsEndingResponse
+	31, // 55: subscriptions.v1.SubscriptionService.Pause:output_type -> subscriptions.v1.PauseResponse
+	33, // 56: subscriptions.v1.SubscriptionService.Resume:output_type -> subscriptions.v1.ResumeResponse
+	35, // 57: subscriptions.v1.SubscriptionService.DeletePause:output_type -> subscriptions.v1.DeletePauseResponse
+	37, // 58: subscriptions.v1.SubscriptionService.AddDiscount:output_type -> subscriptions.v1.AddDiscountResponse
+	39, // 59: subscriptions.v1.SubscriptionService.DeleteDiscount:output_type -> subscriptions.v1.DeleteDiscountResponse
+	41, // 60: subscriptions.v1.SubscriptionService.AddPriceChange:output_type -> subscriptions.v1.AddPriceChangeResponse
+	43, // 61: subscriptions.v1.SubscriptionService.DeletePriceChange:output_type -> subscriptions.v1.DeletePriceChangeResponse
+	47, // 62: subscriptions.v1.SubscriptionService.Duplicates:output_type -> subscriptions.v1.DuplicatesResponse
+	49, // 63: subscriptions.v1.SubscriptionService.Merge:output_type -> subscriptions.v1.MergeResponse
+	52, // 64: subscriptions.v1.SubscriptionService.Merges:output_type -> subscriptions.v1.MergesResponse
+	45, // [45:65] is the sub-list for method output_type
+	25, // [25:45] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_subscriptions_v1_subscriptions_proto_init() }
+func file_subscriptions_v1_subscriptions_proto_init() {
+	if File_subscriptions_v1_subscriptions_proto != nil {
+		return
+	}
+	file_subscriptions_v1_subscriptions_proto_msgTypes[27].OneofWrappers = []any{}
+	file_subscriptions_v1_subscriptions_proto_msgTypes[28].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_subscriptions_v1_subscriptions_proto_rawDesc), len(file_subscriptions_v1_subscriptions_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   53,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_subscriptions_v1_subscriptions_proto_goTypes,
+		DependencyIndexes: file_subscriptions_v1_subscriptions_proto_depIdxs,
+		MessageInfos:      file_subscriptions_v1_subscriptions_proto_msgTypes,
+	}.Build()
+	File_subscriptions_v1_subscriptions_proto = out.File
+	file_subscriptions_v1_subscriptions_proto_goTypes = nil
+	file_subscriptions_v1_subscriptions_proto_depIdxs = nil
+}
@@ -1,10 +1,41 @@
 package main
 
 import (
-	_ "github.com/zakharova-e/subscriptions-info/internal/connections"
-	"github.com/zakharova-e/subscriptions-info/internal/web"
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/zakharova-e/subscriptions-info/internal/app"
+	"github.com/zakharova-e/subscriptions-info/internal/config"
+	"github.com/zakharova-e/subscriptions-info/internal/logging"
 )
 
 func main() {
-	web.Run()
+	cfg := config.Load()
+	if err := logging.Setup(cfg.Log); err != nil {
+		slog.Error("logger setup failed", "error", err)
+		os.Exit(1)
+	}
+
+	flag.BoolVar(&cfg.AutoMigrate, "auto-migrate", cfg.AutoMigrate, "apply pending migrations on server start")
+	flag.Parse()
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(cfg.DB, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	application, err := app.New(context.Background(), cfg)
+	if err != nil {
+		slog.Error("application start failed", "error", err)
+		os.Exit(1)
+	}
+	if err := application.Run(); err != nil {
+		slog.Error("application stopped with error", "error", err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+
+	"github.com/zakharova-e/subscriptions-info/internal/config"
+	"github.com/zakharova-e/subscriptions-info/internal/connections"
+)
+
+const migrateUsage = `usage: subscriptionsService migrate <command>
+
+commands:
+  up            apply all pending migrations
+  down N        roll back N migrations
+  goto VERSION  migrate up or down to VERSION
+  status        print the current and the latest available version
+  force VERSION set VERSION without running migrations, used to recover a dirty schema
+`
+
+// migrator is the part of *migrate.Migrate used by the subcommand
+type migrator interface {
+	Up() error
+	Steps(n int) error
+	Migrate(version uint) error
+	Force(version int) error
+	Version() (version uint, dirty bool, err error)
+}
+
+type migrateCommand struct {
+	name  string
+	value int
+}
+
+// runMigrate executes the migrate subcommand, output is written to out
+func runMigrate(cfg config.DB, args []string, out io.Writer) error {
+	command, err := parseMigrateCommand(args)
+	if err != nil {
+		return err
+	}
+	m, err := connections.NewMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	return applyMigrateCommand(m, command, out)
+}
+
+// parseMigrateCommand checks the arguments before a connection to the database is opened
+func parseMigrateCommand(args []string) (migrateCommand, error) {
+	if len(args) == 0 {
+		return migrateCommand{}, errors.New(migrateUsage)
+	}
+	command := migrateCommand{name: args[0]}
+	var err error
+	switch command.name {
+	case "up", "status":
+	case "down":
+		command.value, err = intArgument(args, "N", 1)
+	case "goto", "force":
+		command.value, err = intArgument(args, "VERSION", 0)
+	default:
+		err = fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+	return command, err
+}
+
+func applyMigrateCommand(m migrator, command migrateCommand, out io.Writer) error {
+	var err error
+	switch command.name {
+	case "up":
+		err = m.Up()
+	case "down":
+		err = m.Steps(-command.value)
+	case "goto":
+		err = m.Migrate(uint(command.value))
+	case "force":
+		err = m.Force(command.value)
+	case "status":
+		return printMigrationStatus(m, out)
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", command.name, migrateUsage)
+	}
+	if errors.Is(err, migrate.ErrNoChange) {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+	return printMigrationStatus(m, out)
+}
+
+func printMigrationStatus(m migrator, out io.Writer) error {
+	versions, err := connections.MigrationVersions()
+	if err != nil {
+		return err
+	}
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		version, err = 0, nil
+	}
+	if err != nil {
+		return err
+	}
+	pending := 0
+	for _, v := range versions {
+		if v > version {
+			pending++
+		}
+	}
+	_, err = fmt.Fprintf(out, "current version: %d\ndirty: %t\nlatest version: %d\npending: %d\n",
+		version, dirty, versions[len(versions)-1], pending)
+	return err
+}
+
+// intArgument reads the number after the command, it must be at least minimum
+func intArgument(args []string, name string, minimum int) (int, error) {
+	if len(args) < 2 {
+		return 0, fmt.Errorf("%s %s: argument is required", args[0], name)
+	}
+	value, err := strconv.Atoi(args[1])
+	if err != nil || value < minimum {
+		return 0, fmt.Errorf("%s %s: %q is not a number of at least %d", args[0], name, args[1], minimum)
+	}
+	return value, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+// fakeMigrator records the calls and answers each of them with err
+type fakeMigrator struct {
+	calls []string
+	err   error
+}
+
+func (m *fakeMigrator) record(call string) error {
+	m.calls = append(m.calls, call)
+	return m.err
+}
+
+func (m *fakeMigrator) Up() error {
+	return m.record("up")
+}
+
+func (m *fakeMigrator) Steps(n int) error {
+	return m.record("steps " + strconv.Itoa(n))
+}
+
+func (m *fakeMigrator) Migrate(version uint) error {
+	return m.record("migrate " + strconv.Itoa(int(version)))
+}
+
+func (m *fakeMigrator) Force(version int) error {
+	return m.record("force " + strconv.Itoa(version))
+}
+
+func (m *fakeMigrator) Version() (uint, bool, error) {
+	return 2, false, nil
+}
+
+func TestParseMigrateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    migrateCommand
+		wantErr string
+	}{
+		{"test without command", nil, migrateCommand{}, "usage: subscriptionsService migrate"},
+		{"test with up", []string{"up"}, migrateCommand{name: "up"}, ""},
+		{"test with status", []string{"status"}, migrateCommand{name: "status"}, ""},
+		{"test with down", []string{"down", "2"}, migrateCommand{name: "down", value: 2}, ""},
+		{"test with down without steps", []string{"down"}, migrateCommand{name: "down"}, "down N: argument is required"},
+		{"test with down zero", []string{"down", "0"}, migrateCommand{name: "down"}, `down N: "0" is not a number of at least 1`},
+		{"test with negative down", []string{"down", "-1"}, migrateCommand{name: "down"}, `down N: "-1" is not a number of at least 1`},
+		{"test with goto", []string{"goto", "20250101000000"}, migrateCommand{name: "goto", value: 20250101000000}, ""},
+		{"test with goto zero", []string{"goto", "0"}, migrateCommand{name: "goto"}, ""},
+		{"test with goto not a number", []string{"goto", "latest"}, migrateCommand{name: "goto"}, `goto VERSION: "latest" is not a number of at least 0`},
+		{"test with force", []string{"force", "3"}, migrateCommand{name: "force", value: 3}, ""},
+		{"test with force without version", []string{"force"}, migrateCommand{name: "force"}, "force VERSION: argument is required"},
+		{"test with unknown command", []string{"redo"}, migrateCommand{name: "redo"}, `unknown migrate command "redo"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMigrateCommand(tt.args)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("parseMigrateCommand() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("parseMigrateCommand() error = %v, want %q", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseMigrateCommand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyMigrateCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		command    migrateCommand
+		err        error
+		wantCalls  []string
+		wantErr    bool
+		wantStatus bool
+	}{
+		{"test with up", migrateCommand{name: "up"}, nil, []string{"up"}, false, true},
+		{"test with up without changes", migrateCommand{name: "up"}, migrate.ErrNoChange, []string{"up"}, false, true},
+		{"test with failing up", migrateCommand{name: "up"}, errors.New("dirty database"), []string{"up"}, true, false},
+		{"test with down", migrateCommand{name: "down", value: 2}, nil, []string{"steps -2"}, false, true},
+		{"test with goto", migrateCommand{name: "goto", value: 5}, nil, []string{"migrate 5"}, false, true},
+		{"test with force", migrateCommand{name: "force", value: 3}, nil, []string{"force 3"}, false, true},
+		{"test with status", migrateCommand{name: "status"}, nil, nil, false, true},
+		{"test with unknown command", migrateCommand{name: "redo"}, nil, nil, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &fakeMigrator{err: tt.err}
+			var out bytes.Buffer
+			err := applyMigrateCommand(m, tt.command, &out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyMigrateCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(m.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", m.calls, tt.wantCalls)
+			}
+			if got := strings.HasPrefix(out.String(), "current version: 2\ndirty: false\nlatest version: "); got != tt.wantStatus {
+				t.Errorf("status printed = %v, want %v, output %q", got, tt.wantStatus, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func budgetsCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "budgets", "")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	statuses, err := c.client.Budgets(ctx)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, statuses)
+	}
+	return printBudgets(c.out, statuses)
+}
+
+// budgetCommand manages budgets: add, delete and alerts
+func budgetCommand(ctx context.Context, c *cli, args []string) error {
+	if len(args) == 0 {
+		return errors.New("budget action is required: add, delete or alerts")
+	}
+	action, args := args[0], args[1:]
+	switch action {
+	case "add":
+		fs := newFlagSet(c, "budget add", "-scope user|service|tag -target VALUE -amount N [-threshold PERCENT]...")
+		var thresholds listFlag
+		scope := fs.String("scope", "", "what the budget covers: user, service or tag")
+		target := fs.String("target", "", "user id, service name or tag")
+		amount := fs.Int("amount", 0, "monthly limit")
+		fs.Var(&thresholds, "threshold", "alert when the projected spend reaches this percent of the amount, repeat for several, 80 and 100 by default")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() != 0 {
+			return errors.New("budget add takes no arguments")
+		}
+		budget := models.Budget{Scope: models.BudgetScope(*scope), Target: *target, Amount: *amount}
+		for _, value := range thresholds {
+			threshold, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+			if err != nil {
+				return fmt.Errorf("-threshold must be a percent, got %q", value)
+			}
+			budget.Thresholds = append(budget.Thresholds, threshold)
+		}
+		id, err := c.client.CreateBudget(ctx, budget)
+		if err != nil {
+			return err
+		}
+		if c.jsonOutput {
+			return printJSON(c.out, map[string]int32{"id": id})
+		}
+		_, err = fmt.Fprintf(c.out, "created budget %d\n", id)
+		return err
+	case "delete":
+		fs := newFlagSet(c, "budget delete", "ID")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		id, err := idArgument(fs)
+		if err != nil {
+			return err
+		}
+		if err := c.client.DeleteBudget(ctx, id); err != nil {
+			return err
+		}
+		if c.jsonOutput {
+			return printJSON(c.out, map[string]int32{"deleted": id})
+		}
+		_, err = fmt.Fprintf(c.out, "deleted budget %d\n", id)
+		return err
+	case "alerts":
+		fs := newFlagSet(c, "budget alerts", "[-id N]")
+		id := fs.Int("id", 0, "only alerts of this budget")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		alerts, err := c.client.BudgetAlerts(ctx, int32(*id))
+		if err != nil {
+			return err
+		}
+		if c.jsonOutput {
+			return printJSON(c.out, alerts)
+		}
+		return printBudgetAlerts(c.out, alerts)
+	default:
+		return fmt.Errorf("unknown budget action %q", action)
+	}
+}
+
+func printBudgets(out io.Writer, statuses []models.BudgetStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSCOPE\tTARGET\tAMOUNT\tSPENT\tPROJECTED\tREACHED")
+	for _, status := range statuses {
+		reached := make([]string, len(status.Reached))
+		for i, threshold := range status.Reached {
+			reached[i] = strconv.Itoa(threshold) + "%"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%d\t%s\n", status.Budget.Id, status.Budget.Scope, status.Budget.Target,
+			status.Budget.Amount, status.Spent, status.Projected, dash(strings.Join(reached, ", ")))
+	}
+	return w.Flush()
+}
+
+func printBudgetAlerts(out io.Writer, alerts []models.BudgetAlert) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tBUDGET\tSCOPE\tTARGET\tMONTH\tTHRESHOLD\tAMOUNT\tPROJECTED\tRAISED")
+	for _, alert := range alerts {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%d%%\t%d\t%d\t%s\n", alert.Id, alert.BudgetId, alert.Scope, alert.Target,
+			alert.Month.Format("01-2006"), alert.Threshold, alert.Amount, alert.Projected, alert.CreatedAt.Format("2006-01-02 15:04"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+// listFlag collects the values of a flag given several times
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func servicesCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "services", "")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	services, err := c.client.Services(ctx)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, services)
+	}
+	return printServices(c.out, services)
+}
+
+// serviceCommand manages the catalog: add, update, delete and resolve
+func serviceCommand(ctx context.Context, c *cli, args []string) error {
+	if len(args) == 0 {
+		return errors.New("service action is required: add, update, delete or resolve")
+	}
+	action, args := args[0], args[1:]
+	switch action {
+	case "add", "update":
+		return saveService(ctx, c, action, args)
+	case "delete":
+		fs := newFlagSet(c, "service delete", "ID")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		id, err := idArgument(fs)
+		if err != nil {
+			return err
+		}
+		if err := c.client.DeleteService(ctx, id); err != nil {
+			return err
+		}
+		if c.jsonOutput {
+			return printJSON(c.out, map[string]int32{"deleted": id})
+		}
+		_, err = fmt.Fprintf(c.out, "deleted service %d\n", id)
+		return err
+	case "resolve":
+		fs := newFlagSet(c, "service resolve", "NAME")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return errors.New("exactly one service name is required")
+		}
+		service, err := c.client.ResolveService(ctx, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		if c.jsonOutput {
+			return printJSON(c.out, service)
+		}
+		return printServices(c.out, []models.Service{*service})
+	default:
+		return fmt.Errorf("unknown service action %q", action)
+	}
+}
+
+// saveService creates an entry from the flags, or updates the entry given by id with the flags that are set
+func saveService(ctx context.Context, c *cli, action string, args []string) error {
+	usage := "-name NAME [-alias NAME]... [-category NAME] [-url URL] [-plan NAME=PRICE]..."
+	if action == "update" {
+		usage = "[-name NAME] [-alias NAME]... [-category NAME] [-url URL] [-plan NAME=PRICE]... ID"
+	}
+	fs := newFlagSet(c, "service "+action, usage)
+	var aliases, plans listFlag
+	name := fs.String("name", "", "canonical service name")
+	fs.Var(&aliases, "alias", "other spelling of the name, repeat for several, replaces all aliases on update")
+	category := fs.String("category", "", "category, e.g. streaming, cloud or software")
+	vendorURL := fs.String("url", "", "vendor site")
+	fs.Var(&plans, "plan", "default plan as NAME=PRICE, repeat for several, replaces all plans on update")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	service := &models.Service{}
+	if action == "update" {
+		id, err := idArgument(fs)
+		if err != nil {
+			return err
+		}
+		if service, err = c.client.Service(ctx, id); err != nil {
+			return err
+		}
+	} else if fs.NArg() != 0 {
+		return errors.New("service add takes no arguments")
+	}
+	var errPlan error
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "name":
+			service.Name = *name
+		case "alias":
+			service.Aliases = aliases
+		case "category":
+			service.Category = *category
+		case "url":
+			service.VendorURL = *vendorURL
+		case "plan":
+			service.Plans = nil
+			for _, value := range plans {
+				planName, price, ok := strings.Cut(value, "=")
+				priceValue, err := strconv.Atoi(price)
+				if !ok || err != nil {
+					errPlan = fmt.Errorf("-plan must be NAME=PRICE, got %q", value)
+					return
+				}
+				service.Plans = append(service.Plans, models.Plan{Name: planName, Price: priceValue})
+			}
+		}
+	})
+	if errPlan != nil {
+		return errPlan
+	}
+	if action == "update" {
+		if err := c.client.UpdateService(ctx, *service); err != nil {
+			return err
+		}
+	} else {
+		id, err := c.client.CreateService(ctx, *service)
+		if err != nil {
+			return err
+		}
+		service.Id = id
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, service)
+	}
+	_, err := fmt.Fprintf(c.out, "saved service %d %s\n", service.Id, service.Name)
+	return err
+}
+
+func printServices(out io.Writer, services []models.Service) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tCATEGORY\tALIASES\tPLANS\tURL")
+	for _, service := range services {
+		plans := make([]string, len(service.Plans))
+		for i, plan := range service.Plans {
+			plans[i] = fmt.Sprintf("%s=%d", plan.Name, plan.Price)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", service.Id, service.Name, dash(service.Category),
+			dash(strings.Join(service.Aliases, ", ")), dash(strings.Join(plans, ", ")), dash(service.VendorURL))
+	}
+	return w.Flush()
+}
+
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,706 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/zakharova-e/subscriptions-info/internal/client"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+// monthFormat labels the months of the spend chart
+const monthFormat = "01-2006"
+
+// newFlagSet creates subcommand flags, --json is accepted after the command as well
+func newFlagSet(c *cli, name string, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: subsctl %s %s\n", name, usage)
+		fs.PrintDefaults()
+	}
+	fs.BoolVar(&c.jsonOutput, "json", c.jsonOutput, "print json instead of tables")
+	return fs
+}
+
+func idArgument(fs *flag.FlagSet) (int32, error) {
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 0, errors.New("exactly one subscription id is required")
+	}
+	id, err := strconv.ParseInt(fs.Arg(0), 10, 32)
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("invalid subscription id %q", fs.Arg(0))
+	}
+	return int32(id), nil
+}
+
+// parseStart accepts the api date formats, a bare month means its first day
+func parseStart(name string, value string) (time.Time, error) {
+	date, err := models.ParseStartDate(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("-%s must be YYYY-MM-DD or MM-YYYY, got %q", name, value)
+	}
+	return date, nil
+}
+
+// parseFinish accepts the api date formats, a bare month means its last day
+func parseFinish(name string, value string) (sql.NullTime, error) {
+	date, err := models.ParseFinishDate(value)
+	if err != nil || !date.Valid {
+		return sql.NullTime{}, fmt.Errorf("-%s must be YYYY-MM-DD or MM-YYYY, got %q", name, value)
+	}
+	return date, nil
+}
+
+// subscriptionFlags binds flags describing subscription fields
+type subscriptionFlags struct {
+	service     string
+	price       int
+	user        string
+	start       string
+	finish      string
+	billingDay  int
+	trialDays   int
+	trialPrice  int
+	introMonths int
+	introPrice  int
+	tags        listFlag
+}
+
+func (f *subscriptionFlags) bind(fs *flag.FlagSet) {
+	fs.StringVar(&f.service, "service", "", "service name")
+	fs.IntVar(&f.price, "price", 0, "monthly price")
+	fs.StringVar(&f.user, "user", "", "user id (uuid)")
+	fs.StringVar(&f.start, "start", "", "start date, YYYY-MM-DD or MM-YYYY for the first day")
+	fs.StringVar(&f.finish, "finish", "", "finish date, YYYY-MM-DD or MM-YYYY for the last day")
+	fs.IntVar(&f.billingDay, "billing-day", 0, "day of month the billing periods start on, the start day by default")
+	fs.IntVar(&f.trialDays, "trial-days", 0, "trial length in days from the start date")
+	fs.IntVar(&f.trialPrice, "trial-price", 0, "monthly price during the trial")
+	fs.IntVar(&f.introMonths, "intro-months", 0, "months after the trial charged the intro price")
+	fs.IntVar(&f.introPrice, "intro-price", 0, "monthly price during the intro months")
+	fs.Var(&f.tags, "tag", "label like team:platform, repeat for several, replaces all tags on update")
+}
+
+// apply copies flags that were set on the command line into item
+func (f *subscriptionFlags) apply(fs *flag.FlagSet, item *models.Subscription) error {
+	var err error
+	fs.Visit(func(fl *flag.Flag) {
+		if err != nil {
+			return
+		}
+		switch fl.Name {
+		case "service":
+			item.ServiceName = f.service
+		case "price":
+			item.Price = f.price
+		case "user":
+			item.UserId = f.user
+		case "start":
+			item.StartDate, err = parseStart("start", f.start)
+		case "finish":
+			item.FinishDate, err = parseFinish("finish", f.finish)
+		case "billing-day":
+			item.BillingDay = f.billingDay
+		case "trial-days":
+			item.TrialDays = f.trialDays
+		case "trial-price":
+			item.TrialPrice = f.trialPrice
+		case "intro-months":
+			item.IntroMonths = f.introMonths
+		case "intro-price":
+			item.IntroPrice = f.introPrice
+		case "tag":
+			item.Tags = f.tags
+		}
+	})
+	return err
+}
+
+func createCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "create", "-service NAME -price N -user UUID -start DATE [-finish DATE] [-billing-day N] [-trial-days N -trial-price N] [-intro-months N -intro-price N] [-tag TAG]...")
+	var f subscriptionFlags
+	f.bind(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var item models.Subscription
+	if err := f.apply(fs, &item); err != nil {
+		return err
+	}
+	id, err := c.client.Create(ctx, item)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, map[string]int32{"id": id})
+	}
+	_, err = fmt.Fprintf(c.out, "created subscription %d\n", id)
+	return err
+}
+
+func getCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "get", "ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := idArgument(fs)
+	if err != nil {
+		return err
+	}
+	item, err := c.client.Read(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, item)
+	}
+	return printTable(c.out, []models.Subscription{*item})
+}
+
+func updateCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "update", "[-service NAME] [-price N] [-user UUID] [-start DATE] [-finish DATE | -no-finish] [-billing-day N] [-tag TAG]... ID")
+	var f subscriptionFlags
+	f.bind(fs)
+	noFinish := fs.Bool("no-finish", false, "remove the finish date")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := idArgument(fs)
+	if err != nil {
+		return err
+	}
+	item, err := c.client.Read(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := f.apply(fs, item); err != nil {
+		return err
+	}
+	if *noFinish {
+		item.FinishDate = sql.NullTime{}
+	}
+	if err := c.client.Update(ctx, *item); err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, item)
+	}
+	return printTable(c.out, []models.Subscription{*item})
+}
+
+func deleteCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "delete", "ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := idArgument(fs)
+	if err != nil {
+		return err
+	}
+	if err := c.client.Delete(ctx, id); err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, map[string]int32{"deleted": id})
+	}
+	_, err = fmt.Fprintf(c.out, "deleted subscription %d\n", id)
+	return err
+}
+
+func filterFlags(fs *flag.FlagSet) *client.Filter {
+	var filter client.Filter
+	fs.StringVar(&filter.UserId, "user", "", "only subscriptions of this user id")
+	fs.StringVar(&filter.ServiceName, "service", "", "only subscriptions of this service")
+	return &filter
+}
+
+func listCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "list", "[-page N | -all] [-user UUID] [-service NAME]")
+	page := fs.Int("page", 1, "page number")
+	all := fs.Bool("all", false, "fetch every page")
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *all {
+		items, err := c.client.ListAll(ctx, *filter)
+		if err != nil {
+			return err
+		}
+		if c.jsonOutput {
+			return printJSON(c.out, items)
+		}
+		return printTable(c.out, items)
+	}
+	list, err := c.client.List(ctx, *page, *filter)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, list)
+	}
+	if err := printTable(c.out, list.List); err != nil {
+		return err
+	}
+	pages := (list.Total + list.PerPage - 1) / max(list.PerPage, 1)
+	_, err = fmt.Fprintf(c.out, "page %d of %d, %d subscriptions total\n", list.Page, pages, list.Total)
+	return err
+}
+
+func sumCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "sum", "-from DATE -to DATE [-user UUID] [-service NAME] [-prorated] [-totals]")
+	from := fs.String("from", "", "first day of the period, YYYY-MM-DD or MM-YYYY for the whole month")
+	to := fs.String("to", "", "last day of the period, YYYY-MM-DD or MM-YYYY for the whole month")
+	prorated := fs.Bool("prorated", false, "charge partial billing periods by days instead of whole months")
+	totals := fs.Bool("totals", false, "show the sum before discounts and the discounts too")
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if _, err := parseStart("from", *from); err != nil {
+		return err
+	}
+	if _, err := parseFinish("to", *to); err != nil {
+		return err
+	}
+	mode := models.SumModeMonthly
+	if *prorated {
+		mode = models.SumModeProrated
+	}
+	if *totals {
+		sum, err := c.client.SumTotals(ctx, *from, *to, *filter, mode)
+		if err != nil {
+			return err
+		}
+		if c.jsonOutput {
+			return printJSON(c.out, map[string]any{"from": *from, "to": *to, "gross": sum.Gross, "discount": sum.Discount, "net": sum.Net})
+		}
+		_, err = fmt.Fprintf(c.out, "gross    %d\ndiscount %d\nnet      %d\n", sum.Gross, sum.Discount, sum.Net)
+		return err
+	}
+	sum, err := c.client.Sum(ctx, *from, *to, *filter, mode)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, map[string]any{"from": *from, "to": *to, "sum": sum})
+	}
+	_, err = fmt.Fprintf(c.out, "%d\n", sum)
+	return err
+}
+
+func reportCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "report", "-from DATE -to DATE -group category|tag|tag:KEY [-tags EXPR] [-user UUID] [-service NAME] [-prorated]")
+	from := fs.String("from", "", "first day of the period, YYYY-MM-DD or MM-YYYY for the whole month")
+	to := fs.String("to", "", "last day of the period, YYYY-MM-DD or MM-YYYY for the whole month")
+	group := fs.String("group", "", "category, tag, or tag:KEY for the values of KEY:VALUE tags")
+	tags := fs.String("tags", "", `only subscriptions whose tags match, e.g. "team:platform AND NOT (env:test OR env:dev)"`)
+	prorated := fs.Bool("prorated", false, "charge partial billing periods by days instead of whole months")
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if _, err := parseStart("from", *from); err != nil {
+		return err
+	}
+	if _, err := parseFinish("to", *to); err != nil {
+		return err
+	}
+	if _, err := models.ParseReportGroup(*group); err != nil {
+		return fmt.Errorf("-group %w", err)
+	}
+	if _, err := models.ParseTagFilter(*tags); err != nil {
+		return fmt.Errorf("-tags %w", err)
+	}
+	mode := models.SumModeMonthly
+	if *prorated {
+		mode = models.SumModeProrated
+	}
+	report, err := c.client.Report(ctx, *from, *to, *filter, mode, *group, *tags)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, report)
+	}
+	return printReport(c.out, report)
+}
+
+func compareCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "compare", "-from DATE -to DATE [-base-from DATE -base-to DATE | -year] [-user UUID] [-service NAME] [-prorated] [-all]")
+	from := fs.String("from", "", "first day of the period, YYYY-MM-DD or MM-YYYY for the whole month")
+	to := fs.String("to", "", "last day of the period, YYYY-MM-DD or MM-YYYY for the whole month")
+	baseFrom := fs.String("base-from", "", "first day of the base period, the previous period by default")
+	baseTo := fs.String("base-to", "", "last day of the base period")
+	year := fs.Bool("year", false, "compare with the same period a year earlier")
+	prorated := fs.Bool("prorated", false, "charge partial billing periods by days instead of whole months")
+	all := fs.Bool("all", false, "list unchanged subscriptions too")
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if _, err := parseStart("from", *from); err != nil {
+		return err
+	}
+	if _, err := parseFinish("to", *to); err != nil {
+		return err
+	}
+	if (*baseFrom == "") != (*baseTo == "") {
+		return errors.New("-base-from and -base-to must be given together")
+	}
+	if *baseFrom != "" {
+		if *year {
+			return errors.New("-year cannot be combined with -base-from and -base-to")
+		}
+		if _, err := parseStart("base-from", *baseFrom); err != nil {
+			return err
+		}
+		if _, err := parseFinish("base-to", *baseTo); err != nil {
+			return err
+		}
+	}
+	against := models.ComparePrevious
+	if *year {
+		against = models.CompareYear
+	}
+	mode := models.SumModeMonthly
+	if *prorated {
+		mode = models.SumModeProrated
+	}
+	comparison, err := c.client.Compare(ctx, *from, *to, *baseFrom, *baseTo, against, *filter, mode)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, comparison)
+	}
+	return printComparison(c.out, comparison, *all)
+}
+
+func trialsCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "trials", "[-days N]")
+	days := fs.Int("days", -1, "days ahead, the server default when not set")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	items, err := c.client.TrialsEnding(ctx, *days)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, items)
+	}
+	return printTable(c.out, items)
+}
+
+func pauseCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "pause", "-from DATE [-until DATE] ID")
+	from := fs.String("from", "", "first paused day, YYYY-MM-DD")
+	until := fs.String("until", "", "last paused day, until resumed when not set")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := idArgument(fs)
+	if err != nil {
+		return err
+	}
+	pause := models.Pause{SubscriptionId: id}
+	if pause.StartDate, err = parseStart("from", *from); err != nil {
+		return err
+	}
+	if *until != "" {
+		if pause.FinishDate, err = parseFinish("until", *until); err != nil {
+			return err
+		}
+	}
+	pauseId, err := c.client.Pause(ctx, pause)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, map[string]int32{"id": pauseId})
+	}
+	_, err = fmt.Fprintf(c.out, "created pause %d of subscription %d\n", pauseId, id)
+	return err
+}
+
+func resumeCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "resume", "-date DATE ID")
+	date := fs.String("date", "", "first billed day, YYYY-MM-DD")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := idArgument(fs)
+	if err != nil {
+		return err
+	}
+	if _, err := parseStart("date", *date); err != nil {
+		return err
+	}
+	if err := c.client.Resume(ctx, id, *date); err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, map[string]int32{"resumed": id})
+	}
+	_, err = fmt.Fprintf(c.out, "resumed subscription %d\n", id)
+	return err
+}
+
+func unpauseCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "unpause", "PAUSE_ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := idArgument(fs)
+	if err != nil {
+		return err
+	}
+	if err := c.client.DeletePause(ctx, id); err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, map[string]int32{"deleted": id})
+	}
+	_, err = fmt.Fprintf(c.out, "deleted pause %d\n", id)
+	return err
+}
+
+func discountCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "discount", "(-percent N | -fixed N) -from MONTH [-months N] [-code CODE] ID")
+	percent := fs.Int("percent", 0, "percent off the monthly price")
+	fixed := fs.Int("fixed", 0, "amount off the monthly price")
+	from := fs.String("from", "", "first discounted month, YYYY-MM-DD or MM-YYYY")
+	months := fs.Int("months", 0, "number of discounted months, 0 until deleted")
+	code := fs.String("code", "", "coupon or promo code")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := idArgument(fs)
+	if err != nil {
+		return err
+	}
+	discount := models.Discount{SubscriptionId: id, Months: *months, Code: *code}
+	switch {
+	case *percent != 0 && *fixed != 0:
+		return errors.New("-percent and -fixed are mutually exclusive")
+	case *percent != 0:
+		discount.Kind, discount.Amount = models.DiscountPercent, *percent
+	case *fixed != 0:
+		discount.Kind, discount.Amount = models.DiscountFixed, *fixed
+	default:
+		return errors.New("-percent or -fixed is required")
+	}
+	startMonth, err := parseStart("from", *from)
+	if err != nil {
+		return err
+	}
+	discount.StartMonth = models.MonthOf(startMonth)
+	discountId, err := c.client.AddDiscount(ctx, discount)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, map[string]int32{"id": discountId})
+	}
+	_, err = fmt.Fprintf(c.out, "created discount %d of subscription %d\n", discountId, id)
+	return err
+}
+
+func undiscountCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "undiscount", "DISCOUNT_ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := idArgument(fs)
+	if err != nil {
+		return err
+	}
+	if err := c.client.DeleteDiscount(ctx, id); err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, map[string]int32{"deleted": id})
+	}
+	_, err = fmt.Fprintf(c.out, "deleted discount %d\n", id)
+	return err
+}
+
+func repriceCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "reprice", "-from DATE -price N ID")
+	from := fs.String("from", "", "first day of the new price, YYYY-MM-DD or MM-YYYY for the first day")
+	price := fs.Int("price", -1, "new regular monthly price")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := idArgument(fs)
+	if err != nil {
+		return err
+	}
+	if *price < 0 {
+		return errors.New("-price is required")
+	}
+	effectiveDate, err := parseStart("from", *from)
+	if err != nil {
+		return err
+	}
+	changeId, err := c.client.AddPriceChange(ctx, models.PriceChange{SubscriptionId: id, EffectiveDate: effectiveDate, Price: *price})
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, map[string]int32{"id": changeId})
+	}
+	_, err = fmt.Fprintf(c.out, "created price change %d of subscription %d\n", changeId, id)
+	return err
+}
+
+func unrepriceCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "unreprice", "PRICE_CHANGE_ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := idArgument(fs)
+	if err != nil {
+		return err
+	}
+	if err := c.client.DeletePriceChange(ctx, id); err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, map[string]int32{"deleted": id})
+	}
+	_, err = fmt.Fprintf(c.out, "deleted price change %d\n", id)
+	return err
+}
+
+func duplicatesCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "duplicates", "[-user UUID]")
+	userId := fs.String("user", "", "only subscriptions of this user id")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	groups, err := c.client.Duplicates(ctx, *userId)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, groups)
+	}
+	return printDuplicates(c.out, groups)
+}
+
+func mergeCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "merge", "-into ID ID...")
+	into := fs.Int("into", 0, "id of the subscription that is kept")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *into < 1 {
+		fs.Usage()
+		return errors.New("-into is required")
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("at least one subscription id to merge is required")
+	}
+	ids := make([]int32, fs.NArg())
+	for i, arg := range fs.Args() {
+		id, err := strconv.ParseInt(arg, 10, 32)
+		if err != nil || id < 1 {
+			return fmt.Errorf("invalid subscription id %q", arg)
+		}
+		ids[i] = int32(id)
+	}
+	item, err := c.client.Merge(ctx, int32(*into), ids)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, item)
+	}
+	return printTable(c.out, []models.Subscription{*item})
+}
+
+func mergesCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "merges", "ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := idArgument(fs)
+	if err != nil {
+		return err
+	}
+	records, err := c.client.Merges(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, records)
+	}
+	items := make([]models.Subscription, len(records))
+	for i, record := range records {
+		items[i] = record.Record
+	}
+	return printTable(c.out, items)
+}
+
+func forecastCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "forecast", "[-months N] [-from MONTH] [-user UUID] [-service NAME] [-changes]")
+	months := fs.Int("months", 12, "number of months")
+	from := fs.String("from", "", "first month, YYYY-MM-DD or MM-YYYY, the current month by default")
+	changes := fs.Bool("changes", false, "list the subscriptions whose charge changes too")
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *from != "" {
+		if _, err := parseStart("from", *from); err != nil {
+			return err
+		}
+	}
+	forecast, err := c.client.Forecast(ctx, *from, *months, *filter)
+	if err != nil {
+		return err
+	}
+	if c.jsonOutput {
+		return printJSON(c.out, forecast)
+	}
+	return printForecast(c.out, forecast, *changes)
+}
+
+func exportCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "export", "[-format csv|json] [-o FILE] [-user UUID] [-service NAME]")
+	format := fs.String("format", "csv", "csv or json")
+	output := fs.String("o", "", "output file, stdout by default")
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "csv" && *format != "json" {
+		return fmt.Errorf("unknown export format %q", *format)
+	}
+	items, err := c.client.ListAll(ctx, *filter)
+	if err != nil {
+		return err
+	}
+	out := c.out
+	if *output != "" {
+		file, errCreate := os.Create(*output)
+		if errCreate != nil {
+			return errCreate
+		}
+		defer file.Close()
+		out = file
+	}
+	if *format == "json" {
+		return printJSON(out, items)
+	}
+	return printCSV(out, items)
+}
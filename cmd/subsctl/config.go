@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const defaultServerURL = "http://localhost:8080"
+
+// fileConfig is stored as json in the user config dir, e.g. ~/.config/subsctl/config.json
+type fileConfig struct {
+	ServerURL string `json:"server_url"`
+	APIKey    string `json:"api_key"`
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "subsctl", "config.json")
+}
+
+// loadConfig reads the config file, a missing file is not an error
+func loadConfig(path string) (fileConfig, error) {
+	cfg := fileConfig{ServerURL: defaultServerURL}
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	if cfg.ServerURL == "" {
+		cfg.ServerURL = defaultServerURL
+	}
+	return cfg, nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	tests := []struct {
+		name    string
+		path    string
+		want    fileConfig
+		wantErr bool
+	}{
+		{"test without path", "", fileConfig{ServerURL: defaultServerURL}, false},
+		{"test with missing file", filepath.Join(dir, "missing.json"), fileConfig{ServerURL: defaultServerURL}, false},
+		{"test with full file", write("full.json", `{"server_url": "https://subs.example.com", "api_key": "secret"}`),
+			fileConfig{ServerURL: "https://subs.example.com", APIKey: "secret"}, false},
+		{"test with key only", write("key.json", `{"api_key": "secret"}`), fileConfig{ServerURL: defaultServerURL, APIKey: "secret"}, false},
+		{"test with invalid json", write("invalid.json", `server_url = "https://subs.example.com"`), fileConfig{}, true},
+		{"test with directory", dir, fileConfig{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadConfig(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("loadConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/zakharova-e/subscriptions-info/internal/client"
+)
+
+const usage = `usage: subsctl [global flags] <command> [flags] [args]
+
+commands:
+  create     create a subscription
+  get        print a subscription by id
+  update     change fields of a subscription by id
+  delete     delete a subscription by id
+  list       list subscriptions, filtered and paginated
+  sum        total spend for a period
+  report     spend for a period grouped by category or tag
+  forecast   projected spend of the coming months
+  compare    spend for a period against the previous one or a year earlier
+  trials     subscriptions with trials ending soon
+  pause      pause billing of a subscription by id
+  resume     resume billing of a paused subscription by id
+  unpause    delete a pause by its id
+  discount   add a discount to a subscription by id
+  undiscount delete a discount by its id
+  reprice    schedule a new price of a subscription by id
+  unreprice  delete a price change by its id
+  duplicates overlapping subscriptions of the same service
+  merge      merge duplicate subscriptions into one by id
+  merges     subscriptions merged into a subscription by id
+  services   list the service catalog
+  service    add, update, delete or resolve catalog entries
+  budgets    list budgets with the spend of the current month
+  budget     add or delete budgets, list their alerts
+  export     write all subscriptions as csv or json
+  tui        browse and edit subscriptions interactively
+
+global flags:
+`
+
+// cli keeps what every command needs
+type cli struct {
+	client     *client.Client
+	jsonOutput bool
+	out        io.Writer
+}
+
+type command func(ctx context.Context, c *cli, args []string) error
+
+var commands = map[string]command{
+	"create":     createCommand,
+	"get":        getCommand,
+	"update":     updateCommand,
+	"delete":     deleteCommand,
+	"list":       listCommand,
+	"sum":        sumCommand,
+	"report":     reportCommand,
+	"forecast":   forecastCommand,
+	"compare":    compareCommand,
+	"trials":     trialsCommand,
+	"pause":      pauseCommand,
+	"resume":     resumeCommand,
+	"unpause":    unpauseCommand,
+	"discount":   discountCommand,
+	"undiscount": undiscountCommand,
+	"reprice":    repriceCommand,
+	"unreprice":  unrepriceCommand,
+	"duplicates": duplicatesCommand,
+	"merge":      mergeCommand,
+	"merges":     mergesCommand,
+	"services":   servicesCommand,
+	"service":    serviceCommand,
+	"budgets":    budgetsCommand,
+	"budget":     budgetCommand,
+	"export":     exportCommand,
+	"tui":        tuiCommand,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "subsctl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	global := flag.NewFlagSet("subsctl", flag.ContinueOnError)
+	global.Usage = func() {
+		fmt.Fprint(global.Output(), usage)
+		global.PrintDefaults()
+	}
+	configPath := global.String("config", defaultConfigPath(), "config file with server_url and api_key")
+	serverURL := global.String("server", "", "server url, overrides the config file")
+	apiKey := global.String("api-key", "", "api key, overrides the config file")
+	c := &cli{out: out}
+	global.BoolVar(&c.jsonOutput, "json", false, "print json instead of tables")
+	if err := global.Parse(args); err != nil {
+		return err
+	}
+	if global.NArg() == 0 {
+		global.Usage()
+		return errors.New("command is required")
+	}
+	cmd, ok := commands[global.Arg(0)]
+	if !ok {
+		global.Usage()
+		return fmt.Errorf("unknown command %q", global.Arg(0))
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("config %s: %w", *configPath, err)
+	}
+	if *serverURL != "" {
+		cfg.ServerURL = *serverURL
+	}
+	if *apiKey != "" {
+		cfg.APIKey = *apiKey
+	}
+	c.client = client.New(cfg.ServerURL, cfg.APIKey)
+	return cmd(ctx, c, global.Args()[1:])
+}
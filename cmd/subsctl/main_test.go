@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// apiServer records the api keys of the requests it gets
+func apiServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("X-API-Key")+strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &keys
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	fileServer, fileKeys := apiServer(t)
+	flagServer, flagKeys := apiServer(t)
+	configPath := filepath.Join(dir, "config.json")
+	config := `{"server_url": "` + fileServer.URL + `", "api_key": "file-key"}`
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalidPath := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalidPath, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		args         []string
+		wantErr      string
+		wantOut      string
+		wantFileKeys []string
+		wantFlagKeys []string
+	}{
+		{"test without command", []string{"-config", configPath}, "command is required", "", nil, nil},
+		{"test with unknown command", []string{"-config", configPath, "nope"}, `unknown command "nope"`, "", nil, nil},
+		{"test with invalid config", []string{"-config", invalidPath, "delete", "7"}, "config " + invalidPath, "", nil, nil},
+		{"test with config file", []string{"-config", configPath, "delete", "7"}, "", "deleted subscription 7\n",
+			[]string{"file-key"}, nil},
+		{"test with server flag", []string{"-config", configPath, "-server", flagServer.URL, "delete", "7"}, "", "deleted subscription 7\n",
+			nil, []string{"file-key"}},
+		{"test with api key flag", []string{"-config", configPath, "-api-key", "flag-key", "delete", "7"}, "", "deleted subscription 7\n",
+			[]string{"flag-key"}, nil},
+		{"test with missing config and flags", []string{"-config", filepath.Join(dir, "missing.json"), "-server", flagServer.URL,
+			"-api-key", "flag-key", "-json", "delete", "7"}, "", "{\n  \"deleted\": 7\n}\n", nil, []string{"flag-key"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*fileKeys, *flagKeys = nil, nil
+			var out bytes.Buffer
+			err := run(t.Context(), tt.args, &out)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("run() error = %v, want %q", err, tt.wantErr)
+			}
+			if out.String() != tt.wantOut {
+				t.Errorf("run() output = %q, want %q", out.String(), tt.wantOut)
+			}
+			if strings.Join(*fileKeys, ",") != strings.Join(tt.wantFileKeys, ",") ||
+				strings.Join(*flagKeys, ",") != strings.Join(tt.wantFlagKeys, ",") {
+				t.Errorf("config file server got keys %v, flag server got %v, want %v and %v", *fileKeys, *flagKeys, tt.wantFileKeys, tt.wantFlagKeys)
+			}
+		})
+	}
+}
+
+// TestDependencies keeps the server side out of the client binary
+func TestDependencies(t *testing.T) {
+	out, err := exec.Command("go", "list", "-deps", ".").Output()
+	if err != nil {
+		t.Skipf("go list: %v", err)
+	}
+	forbidden := []string{
+		"github.com/jackc/pgx/v5",
+		"github.com/prometheus/client_golang/prometheus",
+		"go.opentelemetry.io/otel",
+		"github.com/zakharova-e/subscriptions-info/internal/connections",
+		"github.com/zakharova-e/subscriptions-info/internal/subscriptions",
+	}
+	for _, dependency := range strings.Fields(string(out)) {
+		if dependency == "github.com/zakharova-e/subscriptions-info/internal/subscriptions/models" {
+			continue
+		}
+		for _, prefix := range forbidden {
+			if dependency == prefix || strings.HasPrefix(dependency, prefix+"/") {
+				t.Errorf("subsctl depends on %s", dependency)
+			}
+		}
+	}
+}
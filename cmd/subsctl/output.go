@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+var columns = []string{"ID", "SERVICE", "PRICE", "USER", "START", "FINISH", "BILLING DAY"}
+
+func printJSON(out io.Writer, value any) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func row(item models.Subscription) []string {
+	finish := "-"
+	if item.FinishDate.Valid {
+		finish = models.FormatDate(item.FinishDate.Time)
+	}
+	return []string{
+		strconv.Itoa(int(item.Id)),
+		item.ServiceName,
+		strconv.Itoa(item.Price),
+		item.UserId,
+		models.FormatDate(item.StartDate),
+		finish,
+		strconv.Itoa(item.BillingDay),
+	}
+}
+
+func printTable(out io.Writer, items []models.Subscription) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	writeLine := func(cells []string) {
+		for i, cell := range cells {
+			if i > 0 {
+				fmt.Fprint(w, "\t")
+			}
+			fmt.Fprint(w, cell)
+		}
+		fmt.Fprintln(w)
+	}
+	writeLine(columns)
+	for _, item := range items {
+		writeLine(row(item))
+	}
+	return w.Flush()
+}
+
+func printReport(out io.Writer, report *models.SpendReport) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tSUBSCRIPTIONS\tGROSS\tDISCOUNT\tNET\n", strings.ToUpper(string(report.GroupBy)))
+	for _, group := range report.Groups {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", dash(group.Name), group.Subscriptions, group.Gross, group.Discount, group.Net)
+	}
+	fmt.Fprintf(w, "TOTAL\t\t%d\t%d\t%d\n", report.Total.Gross, report.Total.Discount, report.Total.Net)
+	return w.Flush()
+}
+
+func printComparison(out io.Writer, comparison *models.Comparison, all bool) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PERIOD\tFROM\tTO\tGROSS\tDISCOUNT\tNET")
+	fmt.Fprintf(w, "base\t%s\t%s\t%d\t%d\t%d\n", models.FormatDate(comparison.Base.From), models.FormatDate(comparison.Base.To),
+		comparison.BaseTotals.Gross, comparison.BaseTotals.Discount, comparison.BaseTotals.Net)
+	fmt.Fprintf(w, "current\t%s\t%s\t%d\t%d\t%d\n", models.FormatDate(comparison.Current.From), models.FormatDate(comparison.Current.To),
+		comparison.CurrentTotals.Gross, comparison.CurrentTotals.Discount, comparison.CurrentTotals.Net)
+	percent := "-"
+	if comparison.Percent != nil {
+		percent = fmt.Sprintf("%+.1f%%", *comparison.Percent)
+	}
+	fmt.Fprintf(w, "delta\t\t%s\t%+d\t%+d\t%+d\n", percent, comparison.Delta.Gross, comparison.Delta.Discount, comparison.Delta.Net)
+	fmt.Fprintln(w, "\nKIND\tSUBSCRIPTIONS\tBASE\tCURRENT\tDELTA")
+	for _, group := range comparison.Attribution {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%+d\n", group.Kind, group.Subscriptions, group.Base, group.Current, group.Delta)
+	}
+	fmt.Fprintln(w, "\nID\tSERVICE\tKIND\tBASE\tCURRENT\tDELTA")
+	for _, delta := range comparison.Subscriptions {
+		if delta.Kind == models.ComparisonUnchanged && !all {
+			continue
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%+d\n", delta.SubscriptionId, delta.ServiceName, delta.Kind, delta.Base, delta.Current, delta.Delta)
+	}
+	return w.Flush()
+}
+
+func printDuplicates(out io.Writer, groups []models.DuplicateGroup) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USER\tSERVICE\tIDS\tMATCH\tOVERLAP FROM\tOVERLAP TO")
+	for _, group := range groups {
+		for _, overlap := range group.Overlaps {
+			to := "-"
+			if overlap.To.Valid {
+				to = models.FormatDate(overlap.To.Time)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d, %d\t%s\t%s\t%s\n", group.UserId, group.ServiceName, overlap.SubscriptionIds[0], overlap.SubscriptionIds[1],
+				overlap.Match, models.FormatDate(overlap.From), to)
+		}
+	}
+	return w.Flush()
+}
+
+func printForecast(out io.Writer, forecast *models.Forecast, changes bool) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MONTH\tSUBSCRIPTIONS\tGROSS\tDISCOUNT\tNET")
+	for _, month := range forecast.Months {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", month.Month.Format("01-2006"), month.Subscriptions, month.Gross, month.Discount, month.Net)
+	}
+	if changes {
+		fmt.Fprintln(w, "\nMONTH\tID\tSERVICE\tBEFORE\tAFTER\tREASONS")
+		for _, change := range forecast.Changes {
+			reasons := make([]string, len(change.Reasons))
+			for i, reason := range change.Reasons {
+				reasons[i] = string(reason)
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%d\t%s\n", change.Month.Format("01-2006"), change.SubscriptionId, change.ServiceName,
+				change.Before, change.After, dash(strings.Join(reasons, ", ")))
+		}
+	}
+	return w.Flush()
+}
+
+func printCSV(out io.Writer, items []models.Subscription) error {
+	w := csv.NewWriter(out)
+	if err := w.Write(columns); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := w.Write(row(item)); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
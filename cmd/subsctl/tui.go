@@ -0,0 +1,348 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/zakharova-e/subscriptions-info/internal/client"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+type tuiMode int
+
+const (
+	modeList tuiMode = iota
+	modeFilter
+	modeForm
+	modeConfirmDelete
+	modeChart
+)
+
+var (
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	helpStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+)
+
+type loadedMsg struct {
+	items []models.Subscription
+	err   error
+}
+
+type savedMsg struct {
+	message string
+	err     error
+}
+
+type tuiModel struct {
+	ctx    context.Context
+	client *client.Client
+	filter client.Filter
+
+	items   []models.Subscription
+	visible []models.Subscription
+	cursor  int
+
+	mode        tuiMode
+	filterInput textinput.Model
+	form        *subscriptionForm
+	chart       *spendChart
+	status      string
+	statusErr   bool
+	width       int
+	height      int
+}
+
+func tuiCommand(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "tui", "[-user UUID] [-service NAME]")
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	filterInput := textinput.New()
+	filterInput.Prompt = "/"
+	filterInput.Placeholder = "service, user or id"
+	model := &tuiModel{ctx: ctx, client: c.client, filter: *filter, filterInput: filterInput}
+	_, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	return err
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return m.load()
+}
+
+func (m *tuiModel) load() tea.Cmd {
+	return func() tea.Msg {
+		items, err := m.client.ListAll(m.ctx, m.filter)
+		return loadedMsg{items: items, err: err}
+	}
+}
+
+func (m *tuiModel) selected() *models.Subscription {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return nil
+	}
+	return &m.visible[m.cursor]
+}
+
+// applyFilter keeps items whose id, service or user contains the filter text
+func (m *tuiModel) applyFilter() {
+	text := strings.ToLower(strings.TrimSpace(m.filterInput.Value()))
+	m.visible = m.visible[:0]
+	for _, item := range m.items {
+		if text == "" ||
+			strings.Contains(strings.ToLower(item.ServiceName), text) ||
+			strings.Contains(strings.ToLower(item.UserId), text) ||
+			strconv.Itoa(int(item.Id)) == text {
+			m.visible = append(m.visible, item)
+		}
+	}
+	m.cursor = min(m.cursor, max(len(m.visible)-1, 0))
+}
+
+func (m *tuiModel) setStatus(message string, err error) {
+	if err != nil {
+		m.status, m.statusErr = err.Error(), true
+		return
+	}
+	m.status, m.statusErr = message, false
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case loadedMsg:
+		if msg.err != nil {
+			m.setStatus("", msg.err)
+			return m, nil
+		}
+		m.items = msg.items
+		m.visible = make([]models.Subscription, 0, len(m.items))
+		m.applyFilter()
+		m.setStatus(fmt.Sprintf("loaded %d subscriptions", len(m.items)), nil)
+		return m, nil
+	case savedMsg:
+		m.setStatus(msg.message, msg.err)
+		if msg.err != nil {
+			return m, nil
+		}
+		m.mode, m.form = modeList, nil
+		return m, m.load()
+	case chartMsg:
+		if m.chart != nil && m.chart.title == msg.title {
+			m.chart.months, m.chart.err = msg.months, msg.err
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case modeFilter:
+			return m.updateFilter(msg)
+		case modeForm:
+			return m.updateForm(msg)
+		case modeConfirmDelete:
+			return m.updateConfirmDelete(msg)
+		case modeChart:
+			if msg.String() == "esc" || msg.String() == "q" {
+				m.mode, m.chart = modeList, nil
+			}
+			return m, nil
+		}
+		return m.updateList(msg)
+	}
+	return m, nil
+}
+
+func (m *tuiModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "up", "k":
+		m.cursor = max(m.cursor-1, 0)
+	case "down", "j":
+		m.cursor = min(m.cursor+1, max(len(m.visible)-1, 0))
+	case "/":
+		m.mode = modeFilter
+		return m, m.filterInput.Focus()
+	case "r":
+		return m, m.load()
+	case "n":
+		m.form = newSubscriptionForm(nil)
+		m.mode = modeForm
+		return m, m.form.focus()
+	case "e":
+		if item := m.selected(); item != nil {
+			m.form = newSubscriptionForm(item)
+			m.mode = modeForm
+			return m, m.form.focus()
+		}
+	case "d":
+		if m.selected() != nil {
+			m.mode = modeConfirmDelete
+		}
+	case "s":
+		if item := m.selected(); item != nil {
+			m.chart = &spendChart{title: "service " + item.ServiceName}
+			m.mode = modeChart
+			return m, m.loadChart(m.chart.title, client.Filter{ServiceName: item.ServiceName})
+		}
+	case "u":
+		if item := m.selected(); item != nil {
+			m.chart = &spendChart{title: "user " + item.UserId}
+			m.mode = modeChart
+			return m, m.loadChart(m.chart.title, client.Filter{UserId: item.UserId})
+		}
+	}
+	return m, nil
+}
+
+func (m *tuiModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.mode = modeList
+		m.filterInput.Blur()
+		return m, nil
+	case "esc":
+		m.mode = modeList
+		m.filterInput.Blur()
+		m.filterInput.SetValue("")
+		m.applyFilter()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	m.applyFilter()
+	return m, cmd
+}
+
+func (m *tuiModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode, m.form = modeList, nil
+		return m, nil
+	case "ctrl+s":
+		return m, m.save()
+	case "enter":
+		if m.form.last() {
+			return m, m.save()
+		}
+		return m, m.form.next()
+	}
+	return m, m.form.update(msg)
+}
+
+func (m *tuiModel) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mode = modeList
+	item := m.selected()
+	if msg.String() != "y" || item == nil {
+		return m, nil
+	}
+	id := item.Id
+	return m, func() tea.Msg {
+		err := m.client.Delete(m.ctx, id)
+		return savedMsg{message: fmt.Sprintf("deleted subscription %d", id), err: err}
+	}
+}
+
+func (m *tuiModel) save() tea.Cmd {
+	item, err := m.form.subscription()
+	if err != nil {
+		m.setStatus("", err)
+		return nil
+	}
+	return func() tea.Msg {
+		if item.Id == 0 {
+			id, errCreate := m.client.Create(m.ctx, item)
+			return savedMsg{message: fmt.Sprintf("created subscription %d", id), err: errCreate}
+		}
+		errUpdate := m.client.Update(m.ctx, item)
+		return savedMsg{message: fmt.Sprintf("updated subscription %d", item.Id), err: errUpdate}
+	}
+}
+
+func (m *tuiModel) View() string {
+	var body string
+	switch m.mode {
+	case modeForm:
+		body = paneStyle.Render(m.form.view())
+	case modeChart:
+		body = paneStyle.Render(m.chart.view(max(m.width-30, 20)))
+	default:
+		listWidth := max(m.width/2-2, 40)
+		body = lipgloss.JoinHorizontal(lipgloss.Top,
+			paneStyle.Width(listWidth).Render(m.listView()),
+			paneStyle.Render(m.detailView()),
+		)
+	}
+	status := helpStyle.Render(m.status)
+	if m.statusErr {
+		status = errorStyle.Render(m.status)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, body, status, helpStyle.Render(m.help()))
+}
+
+func (m *tuiModel) listView() string {
+	var b strings.Builder
+	if m.mode == modeFilter || m.filterInput.Value() != "" {
+		b.WriteString(m.filterInput.View() + "\n")
+	}
+	rows := max(m.height-8, 5)
+	start := max(m.cursor-rows+1, 0)
+	for i := start; i < len(m.visible) && i < start+rows; i++ {
+		item := m.visible[i]
+		line := fmt.Sprintf("%5d  %-20.20s %7d", item.Id, item.ServiceName, item.Price)
+		if i == m.cursor {
+			line = selectedStyle.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(m.visible) == 0 {
+		b.WriteString("no subscriptions\n")
+	}
+	return b.String()
+}
+
+func (m *tuiModel) detailView() string {
+	item := m.selected()
+	if item == nil {
+		return "nothing selected"
+	}
+	if m.mode == modeConfirmDelete {
+		return errorStyle.Render(fmt.Sprintf("delete subscription %d? (y/n)", item.Id))
+	}
+	cells := row(*item)
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(item.ServiceName) + "\n\n")
+	for i, column := range columns {
+		fmt.Fprintf(&b, "%-12s %s\n", strings.ToLower(column), cells[i])
+	}
+	return b.String()
+}
+
+func (m *tuiModel) help() string {
+	switch m.mode {
+	case modeFilter:
+		return "type to filter • enter keep • esc clear"
+	case modeForm:
+		return "tab/shift+tab move • enter next/save • ctrl+s save • esc cancel"
+	case modeConfirmDelete:
+		return "y delete • any key cancel"
+	case modeChart:
+		return "esc back"
+	}
+	return "↑/↓ move • / filter • n new • e edit • d delete • s service chart • u user chart • r reload • q quit"
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zakharova-e/subscriptions-info/internal/client"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+const chartMonths = 12
+
+type monthSpend struct {
+	month time.Time
+	sum   int
+}
+
+type chartMsg struct {
+	title  string
+	months []monthSpend
+	err    error
+}
+
+// spendChart shows the spend of the last chartMonths months of a user or a service
+type spendChart struct {
+	title  string
+	months []monthSpend
+	err    error
+}
+
+// loadChart asks the sum endpoint for every month separately
+func (m *tuiModel) loadChart(title string, filter client.Filter) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		months := make([]monthSpend, 0, chartMonths)
+		for i := chartMonths - 1; i >= 0; i-- {
+			month := current.AddDate(0, -i, 0)
+			label := month.Format(monthFormat)
+			sum, err := m.client.Sum(m.ctx, label, label, filter, models.SumModeMonthly)
+			if err != nil {
+				return chartMsg{title: title, err: err}
+			}
+			months = append(months, monthSpend{month: month, sum: sum})
+		}
+		return chartMsg{title: title, months: months}
+	}
+}
+
+func (c *spendChart) view(width int) string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("monthly spend, "+c.title) + "\n\n")
+	if c.err != nil {
+		return b.String() + errorStyle.Render(c.err.Error())
+	}
+	if c.months == nil {
+		return b.String() + "loading..."
+	}
+	peak := 1
+	for _, m := range c.months {
+		peak = max(peak, m.sum)
+	}
+	barWidth := max(width-20, 10)
+	for _, m := range c.months {
+		bar := strings.Repeat("█", m.sum*barWidth/peak)
+		fmt.Fprintf(&b, "%s %s %d\n", m.month.Format(monthFormat), selectedStyle.Render(bar), m.sum)
+	}
+	return b.String()
+}
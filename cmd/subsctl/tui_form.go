@@ -0,0 +1,118 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+var formLabels = []string{"service", "price", "user id", "start (YYYY-MM-DD)", "finish (YYYY-MM-DD, optional)", "billing day (optional)"}
+
+// subscriptionForm edits a new subscription or a copy of an existing one
+type subscriptionForm struct {
+	// base keeps the fields the form does not edit
+	base   models.Subscription
+	id     int32
+	inputs []textinput.Model
+	active int
+}
+
+func newSubscriptionForm(item *models.Subscription) *subscriptionForm {
+	form := &subscriptionForm{inputs: make([]textinput.Model, len(formLabels))}
+	for i := range form.inputs {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Width = 40
+		form.inputs[i] = input
+	}
+	if item != nil {
+		form.base = *item
+		form.id = item.Id
+		cells := row(*item)
+		for i, cell := range cells[1:] {
+			if cell != "-" {
+				form.inputs[i].SetValue(cell)
+			}
+		}
+	}
+	return form
+}
+
+func (f *subscriptionForm) focus() tea.Cmd {
+	for i := range f.inputs {
+		f.inputs[i].Blur()
+	}
+	return f.inputs[f.active].Focus()
+}
+
+func (f *subscriptionForm) last() bool {
+	return f.active == len(f.inputs)-1
+}
+
+func (f *subscriptionForm) next() tea.Cmd {
+	f.active = (f.active + 1) % len(f.inputs)
+	return f.focus()
+}
+
+func (f *subscriptionForm) update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "tab", "down":
+		return f.next()
+	case "shift+tab", "up":
+		f.active = (f.active - 1 + len(f.inputs)) % len(f.inputs)
+		return f.focus()
+	}
+	var cmd tea.Cmd
+	f.inputs[f.active], cmd = f.inputs[f.active].Update(msg)
+	return cmd
+}
+
+// subscription converts the form into a request item, the server validates business rules
+func (f *subscriptionForm) subscription() (models.Subscription, error) {
+	value := func(i int) string { return strings.TrimSpace(f.inputs[i].Value()) }
+	item := f.base
+	item.Id, item.ServiceName, item.UserId = f.id, value(0), value(2)
+	item.FinishDate, item.BillingDay = sql.NullTime{}, 0
+	price, err := strconv.Atoi(value(1))
+	if err != nil {
+		return item, fmt.Errorf("price must be a number")
+	}
+	item.Price = price
+	if item.StartDate, err = parseStart("start", value(3)); err != nil {
+		return item, err
+	}
+	if value(4) != "" {
+		if item.FinishDate, err = parseFinish("finish", value(4)); err != nil {
+			return item, err
+		}
+	}
+	if value(5) != "" {
+		if item.BillingDay, err = strconv.Atoi(value(5)); err != nil {
+			return item, fmt.Errorf("billing day must be a number")
+		}
+	}
+	return item, nil
+}
+
+func (f *subscriptionForm) view() string {
+	var b strings.Builder
+	title := "new subscription"
+	if f.id != 0 {
+		title = fmt.Sprintf("edit subscription %d", f.id)
+	}
+	b.WriteString(titleStyle.Render(title) + "\n\n")
+	for i, input := range f.inputs {
+		label := fmt.Sprintf("%-28s", formLabels[i])
+		if i == f.active {
+			label = selectedStyle.Render(label)
+		}
+		b.WriteString(label + input.View() + "\n")
+	}
+	return b.String()
+}
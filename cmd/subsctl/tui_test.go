@@ -0,0 +1,102 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+var tuiItems = []models.Subscription{
+	{Id: 1, ServiceName: "Yandex Plus", UserId: "aaaaaaaa-aaaa-4aaa-8aaa-aaaaaaaaaaaa"},
+	{Id: 2, ServiceName: "Netflix", UserId: "aaaaaaaa-aaaa-4aaa-8aaa-aaaaaaaaaaaa"},
+	{Id: 12, ServiceName: "Spotify", UserId: "bbbbbbbb-bbbb-4bbb-8bbb-bbbbbbbbbbbb"},
+	{Id: 21, ServiceName: "Kinopoisk", UserId: "bbbbbbbb-bbbb-4bbb-8bbb-bbbbbbbbbbbb"},
+}
+
+func newTestModel(filter string, cursor int) *tuiModel {
+	m := &tuiModel{filterInput: textinput.New(), cursor: cursor}
+	m.filterInput.SetValue(filter)
+	m.items = tuiItems
+	m.visible = make([]models.Subscription, 0, len(m.items))
+	m.applyFilter()
+	return m
+}
+
+func visibleIds(m *tuiModel) []int64 {
+	ids := []int64{}
+	for _, item := range m.visible {
+		ids = append(ids, int64(item.Id))
+	}
+	return ids
+}
+
+func TestTuiModel_applyFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     string
+		cursor     int
+		wantIds    []int64
+		wantCursor int
+	}{
+		{"test without filter", "", 2, []int64{1, 2, 12, 21}, 2},
+		{"test with blank filter", "   ", 3, []int64{1, 2, 12, 21}, 3},
+		{"test with service in other case", "netFLIX", 0, []int64{2}, 0},
+		{"test with part of the service", "i", 1, []int64{2, 12, 21}, 1},
+		{"test with user", "BBBB", 0, []int64{12, 21}, 0},
+		{"test with id", "12", 0, []int64{12}, 0},
+		{"test with part of an id", "1", 0, []int64{1}, 0},
+		{"test with cursor past the filtered items", "BBBB", 3, []int64{12, 21}, 1},
+		{"test without matches", "hbo", 2, []int64{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(tt.filter, tt.cursor)
+			if got := visibleIds(m); !reflect.DeepEqual(got, tt.wantIds) {
+				t.Errorf("visible = %v, want %v", got, tt.wantIds)
+			}
+			if m.cursor != tt.wantCursor {
+				t.Errorf("cursor = %d, want %d", m.cursor, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestTuiModel_updateListCursor(t *testing.T) {
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	up := tea.KeyMsg{Type: tea.KeyUp}
+	j := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}
+	k := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")}
+	tests := []struct {
+		name       string
+		filter     string
+		cursor     int
+		keys       []tea.KeyMsg
+		wantCursor int
+		wantNil    bool
+	}{
+		{"test with down", "", 0, []tea.KeyMsg{down, j}, 2, false},
+		{"test with down at the last item", "", 3, []tea.KeyMsg{down, j}, 3, false},
+		{"test with up", "", 3, []tea.KeyMsg{up, k}, 1, false},
+		{"test with up at the first item", "", 0, []tea.KeyMsg{up, k}, 0, false},
+		{"test with down in filtered list", "BBBB", 0, []tea.KeyMsg{down, down, down}, 1, false},
+		{"test with empty list", "hbo", 0, []tea.KeyMsg{down, up, down}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(tt.filter, tt.cursor)
+			for _, key := range tt.keys {
+				m.updateList(key)
+			}
+			if m.cursor != tt.wantCursor {
+				t.Errorf("cursor = %d, want %d", m.cursor, tt.wantCursor)
+			}
+			if got := m.selected() == nil; got != tt.wantNil {
+				t.Errorf("selected() is nil = %v, want %v", got, tt.wantNil)
+			}
+		})
+	}
+}
@@ -15,37 +15,46 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/subscription/create": {
-            "post": {
-                "consumes": [
-                    "application/json"
+        "/budget/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "budgets"
                 ],
-                "summary": "record creation",
+                "summary": "budget alerts",
                 "parameters": [
                     {
-                        "description": "item to add",
-                        "name": "item",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/subscriptions.Subscription"
-                        }
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "only alerts of this budget",
+                        "name": "budgetId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "created",
+                        "description": "reached thresholds, newest first",
                         "schema": {
-                            "type": "integer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BudgetAlert"
+                            }
                         }
                     },
                     "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
                         "description": "error",
                         "schema": {
                             "type": "string"
@@ -62,42 +71,64 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "/subscription/delete": {
-            "delete": {
-                "produces": [
+        "/budget/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "text/plain"
+                ],
                 "tags": [
-                    "subscriptions"
+                    "budgets"
                 ],
-                "summary": "record deleting",
+                "summary": "budget creation",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "record id",
-                        "name": "rowId",
-                        "in": "query",
-                        "required": true
+                        "description": "monthly budget of a user, service or tag, thresholds are percents of the amount",
+                        "name": "budget",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Budget"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "data deleted",
+                        "description": "id of the created budget",
                         "schema": {
-                            "$ref": "#/definitions/subscriptions.Subscription"
+                            "type": "integer"
                         }
                     },
                     "400": {
-                        "description": "error",
+                        "description": "invalid fields or the target already has a budget",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
                         }
                     },
-                    "404": {
+                    "401": {
                         "description": "error",
                         "schema": {
                             "type": "string"
@@ -114,32 +145,60 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "/subscription/list": {
-            "get": {
+        "/budget/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "budgets"
                 ],
-                "summary": "list of records",
+                "summary": "budget deleting",
                 "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "page number",
-                        "name": "page",
-                        "in": "query"
+                        "description": "budget id",
+                        "name": "rowId",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "loaded successfully",
+                        "description": "deleted with its alerts, empty body"
+                    },
+                    "400": {
+                        "description": "invalid fields",
                         "schema": {
-                            "$ref": "#/definitions/subscriptions.SubscriptionListPage"
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "405": {
@@ -153,54 +212,71 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "/subscription/read": {
+        "/budget/list": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
-                ],
-                "summary": "record reading",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "record id",
-                        "name": "rowId",
-                        "in": "query",
-                        "required": true
-                    }
+                    "budgets"
                 ],
+                "summary": "budgets with the spend of the current month",
                 "responses": {
                     "200": {
-                        "description": "data found",
+                        "description": "month-to-date and projected spend of every budget",
                         "schema": {
-                            "$ref": "#/definitions/subscriptions.Subscription"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BudgetStatus"
+                            }
                         }
                     },
-                    "400": {
+                    "401": {
                         "description": "error",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
+                    "405": {
                         "description": "error",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "405": {
+                    "500": {
                         "description": "error",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
                         "description": "error",
                         "schema": {
                             "type": "string"
@@ -209,51 +285,86 @@ const docTemplate = `{
                 }
             }
         },
-        "/subscription/sum": {
-            "post": {
-                "consumes": [
-                    "application/x-www-form-urlencoded"
+        "/healthz": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
                 ],
+                "summary": "process is alive",
+                "responses": {
+                    "200": {
+                        "description": "alive",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance": {
+            "get": {
+                "description": "routed only when the service runs with API_KEYS, the toggle must not be open to anyone",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "health"
                 ],
-                "summary": "sum calculation",
+                "summary": "maintenance mode toggle",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "period from",
-                        "name": "filterFrom",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "period to",
-                        "name": "filterTo",
-                        "in": "formData",
-                        "required": true
+                        "type": "boolean",
+                        "description": "new maintenance state, required for PUT",
+                        "name": "enabled",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "current state",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "user id",
-                        "name": "userId",
-                        "in": "formData"
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "routed only when the service runs with API_KEYS, the toggle must not be open to anyone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "maintenance mode toggle",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "service name ",
-                        "name": "serviceName",
-                        "in": "formData"
+                        "type": "boolean",
+                        "description": "new maintenance state, required for PUT",
+                        "name": "enabled",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "sum is ready",
+                        "description": "current state",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/health.Report"
                         }
                     },
                     "400": {
@@ -267,50 +378,77 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "service is ready to accept traffic",
+                "responses": {
+                    "200": {
+                        "description": "ready",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
                     },
-                    "500": {
-                        "description": "error",
+                    "503": {
+                        "description": "not ready",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/health.Report"
                         }
                     }
                 }
             }
         },
-        "/subscription/update": {
-            "put": {
+        "/service/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "text/plain"
+                ],
                 "tags": [
-                    "subscriptions"
+                    "catalog"
                 ],
-                "summary": "record update",
+                "summary": "catalog entry creation",
                 "parameters": [
                     {
-                        "description": "item to update",
-                        "name": "item",
+                        "description": "entry to add, subscriptions matching its name or aliases are linked to it",
+                        "name": "service",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/subscriptions.Subscription"
+                            "$ref": "#/definitions/models.Service"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "updated",
+                        "description": "id of the created entry",
                         "schema": {
                             "type": "integer"
                         }
                     },
                     "400": {
-                        "description": "error",
+                        "description": "invalid fields, name or aliases of another entry",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
                         }
                     },
-                    "404": {
+                    "401": {
                         "description": "error",
                         "schema": {
                             "type": "string"
@@ -327,54 +465,3096 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "subscriptions.Subscription": {
-            "type": "object",
-            "properties": {
-                "finish_date": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "price": {
-                    "type": "integer"
-                },
-                "service_name": {
+        },
+        "/service/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "catalog entry deleting",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "entry id",
+                        "name": "rowId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "deleted, empty body, subscriptions keep their names"
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "catalog entries",
+                "responses": {
+                    "200": {
+                        "description": "all entries ordered by name",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Service"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/read": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "catalog entry reading",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "entry id",
+                        "name": "rowId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "data found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Service"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/resolve": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "catalog entry of a service name or alias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "service name or alias, case and repeated spaces are ignored",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "data found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Service"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "name is not in the catalog",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/update": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "catalog entry update",
+                "parameters": [
+                    {
+                        "description": "entry to update, its subscriptions are renamed to the new name",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Service"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "updated, empty body"
+                    },
+                    "400": {
+                        "description": "invalid fields, name or aliases of another entry",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/compare": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "spend of a period against another one",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "period from, YYYY-MM-DD or MM-YYYY for the first day of the month",
+                        "name": "filterFrom",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "period to, YYYY-MM-DD or MM-YYYY for the last day of the month",
+                        "name": "filterTo",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "base period from, given together with baseTo",
+                        "name": "baseFrom",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "base period to, given together with baseFrom",
+                        "name": "baseTo",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "previous",
+                            "year"
+                        ],
+                        "type": "string",
+                        "default": "previous",
+                        "description": "base period when baseFrom and baseTo are omitted: the previous period of the same length or the same period a year earlier",
+                        "name": "against",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "user id",
+                        "name": "userId",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "service name",
+                        "name": "serviceName",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "monthly",
+                            "prorated"
+                        ],
+                        "type": "string",
+                        "default": "monthly",
+                        "description": "monthly charges every touched month in full, prorated charges partial billing periods by days",
+                        "name": "mode",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "totals of both periods, the deltas and the subscriptions that started, ended, changed price or did not change",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comparison"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "record creation",
+                "parameters": [
+                    {
+                        "description": "item to add",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Subscription"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "id of the created record",
+                        "schema": {
+                            "type": "integer"
+                        },
+                        "headers": {
+                            "Warning": {
+                                "type": "string",
+                                "description": "one per overlapping subscription of the same user and service, when DUPLICATE_WARNINGS is on"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "record deleting",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "record id",
+                        "name": "rowId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "deleted, empty body"
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/discount": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "discounts"
+                ],
+                "summary": "discount creation",
+                "parameters": [
+                    {
+                        "description": "discount to add, months 0 means until deleted",
+                        "name": "discount",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Discount"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "id of the created discount",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields or overlapping discounts",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "subscription not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/discount/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "discounts"
+                ],
+                "summary": "discount deleting",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "discount id",
+                        "name": "rowId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "deleted, empty body"
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duplicates"
+                ],
+                "summary": "overlapping subscriptions of the same user and service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "user id, all users by default",
+                        "name": "userId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "groups of subscriptions connected by overlaps, service names are matched ignoring case, punctuation, company suffixes and typos",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DuplicateGroup"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/forecast": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "spend forecast by month",
+                "parameters": [
+                    {
+                        "maximum": 36,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 12,
+                        "description": "number of months",
+                        "name": "months",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "first month, YYYY-MM-DD or MM-YYYY, the current month by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "user id",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "service name",
+                        "name": "serviceName",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "monthly totals and the subscriptions whose charge changes, with the reasons",
+                        "schema": {
+                            "$ref": "#/definitions/models.Forecast"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "list of records",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "user id",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "service name",
+                        "name": "serviceName",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "loaded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.SubscriptionListPage"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duplicates"
+                ],
+                "summary": "merging duplicates into one subscription",
+                "parameters": [
+                    {
+                        "description": "the subscription that stays and the duplicates merged into it",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the merged subscription, the duplicates are deleted and kept in its merge history",
+                        "schema": {
+                            "$ref": "#/definitions/models.Subscription"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields, another user or service, overlapping discounts",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "subscription not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/merges": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duplicates"
+                ],
+                "summary": "merge history of a subscription",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "subscription id",
+                        "name": "rowId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "subscriptions merged into it, oldest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MergedRecord"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/pause": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "billing pause creation",
+                "parameters": [
+                    {
+                        "description": "pause to add, without finish_date it lasts until resumed",
+                        "name": "pause",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Pause"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "id of the created pause",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields, overlapping pauses or pauses outside the subscription",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "subscription not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/pause/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "billing pause deleting",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "pause id",
+                        "name": "rowId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "deleted, empty body"
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/price-change": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "price changes"
+                ],
+                "summary": "price change scheduling",
+                "parameters": [
+                    {
+                        "description": "new regular monthly price from effective_date",
+                        "name": "change",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PriceChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "id of the created price change",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields, date outside the subscription or taken by another change",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "subscription not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/price-change/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "price changes"
+                ],
+                "summary": "price change deleting",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "price change id",
+                        "name": "rowId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "deleted, empty body"
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/read": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "record reading",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "record id",
+                        "name": "rowId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "data found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Subscription"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/report": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "spend by category or tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "period from, YYYY-MM-DD or MM-YYYY for the first day of the month",
+                        "name": "filterFrom",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "period to, YYYY-MM-DD or MM-YYYY for the last day of the month",
+                        "name": "filterTo",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "category of the catalog entry, tag, or tag:KEY for the values of KEY:VALUE tags",
+                        "name": "groupBy",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tag filter, e.g. team:platform AND NOT (env:test OR env:dev)",
+                        "name": "tags",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "user id",
+                        "name": "userId",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "service name",
+                        "name": "serviceName",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "monthly",
+                            "prorated"
+                        ],
+                        "type": "string",
+                        "default": "monthly",
+                        "description": "monthly charges every touched month in full, prorated charges partial billing periods by days",
+                        "name": "mode",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "groups ordered by net spend, a subscription with several tags is counted in each of their groups",
+                        "schema": {
+                            "$ref": "#/definitions/models.SpendReport"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/resume": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "billing resumption",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "subscription id",
+                        "name": "rowId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "first billed day, YYYY-MM-DD",
+                        "name": "date",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "resumed, empty body"
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "subscription without an open pause",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/sum": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "sum calculation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "period from, YYYY-MM-DD or MM-YYYY for the first day of the month",
+                        "name": "filterFrom",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "period to, YYYY-MM-DD or MM-YYYY for the last day of the month",
+                        "name": "filterTo",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "user id",
+                        "name": "userId",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "service name",
+                        "name": "serviceName",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "monthly",
+                            "prorated"
+                        ],
+                        "type": "string",
+                        "default": "monthly",
+                        "description": "monthly charges every touched month in full, prorated charges partial billing periods by days",
+                        "name": "mode",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "sum for the period after discounts",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/sum/totals": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "sum calculation before and after discounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "period from, YYYY-MM-DD or MM-YYYY for the first day of the month",
+                        "name": "filterFrom",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "billing-date",
+                        "description": "period to, YYYY-MM-DD or MM-YYYY for the last day of the month",
+                        "name": "filterTo",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "user id",
+                        "name": "userId",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "service name",
+                        "name": "serviceName",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "monthly",
+                            "prorated"
+                        ],
+                        "type": "string",
+                        "default": "monthly",
+                        "description": "monthly charges every touched month in full, prorated charges partial billing periods by days",
+                        "name": "mode",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "gross, discount and net sums for the period",
+                        "schema": {
+                            "$ref": "#/definitions/models.SpendTotals"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/trials": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "subscriptions with ending trials",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "days ahead, TRIAL_WARNING_DAYS by default",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "trials ending from today to today + days, soonest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Subscription"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/update": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "record update",
+                "parameters": [
+                    {
+                        "description": "item to update",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Subscription"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "updated, empty body",
+                        "headers": {
+                            "Warning": {
+                                "type": "string",
+                                "description": "one per overlapping subscription of the same user and service, when DUPLICATE_WARNINGS is on"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "health.CheckResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/health.CheckResult"
+                    }
+                },
+                "maintenance": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Budget": {
+            "type": "object",
+            "required": [
+                "amount",
+                "scope",
+                "target"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 5000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "scope": {
+                    "enum": [
+                        "user",
+                        "service",
+                        "tag"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.BudgetScope"
+                        }
+                    ],
+                    "example": "user"
+                },
+                "target": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                },
+                "thresholds": {
+                    "description": "Thresholds are percents of Amount, 80 and 100 when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        80,
+                        100
+                    ]
+                }
+            }
+        },
+        "models.BudgetAlert": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 5000
+                },
+                "budget_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-12T10:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "month": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "example": "2025-09-01"
+                },
+                "projected": {
+                    "type": "integer",
+                    "example": 4100
+                },
+                "scope": {
+                    "enum": [
+                        "user",
+                        "service",
+                        "tag"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.BudgetScope"
+                        }
+                    ],
+                    "example": "user"
+                },
+                "spent": {
+                    "type": "integer",
+                    "example": 3200
+                },
+                "target": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                },
+                "threshold": {
+                    "type": "integer",
+                    "example": 80
+                }
+            }
+        },
+        "models.BudgetScope": {
+            "type": "string",
+            "enum": [
+                "user",
+                "service",
+                "tag"
+            ],
+            "x-enum-varnames": [
+                "BudgetUser",
+                "BudgetService",
+                "BudgetTag"
+            ]
+        },
+        "models.BudgetStatus": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "$ref": "#/definitions/models.Budget"
+                },
+                "month": {
+                    "description": "Month is the first day of the evaluated month",
+                    "type": "string",
+                    "format": "billing-date",
+                    "example": "2025-09-01"
+                },
+                "projected": {
+                    "description": "Projected is charged for the whole month like monthly sums",
+                    "type": "integer",
+                    "example": 4100
+                },
+                "reached": {
+                    "description": "Reached are the thresholds the projected spend has reached",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        80
+                    ]
+                },
+                "spent": {
+                    "description": "Spent is accrued from the first day of the month to today, prorated by days",
+                    "type": "integer",
+                    "example": 3200
+                }
+            }
+        },
+        "models.ChangeReason": {
+            "type": "string",
+            "enum": [
+                "started",
+                "finished",
+                "trial_ended",
+                "intro_ended",
+                "price_changed",
+                "discount_started",
+                "discount_ended",
+                "paused",
+                "resumed"
+            ],
+            "x-enum-varnames": [
+                "ChangeStarted",
+                "ChangeFinished",
+                "ChangeTrialEnded",
+                "ChangeIntroEnded",
+                "ChangePrice",
+                "ChangeDiscountStarted",
+                "ChangeDiscountEnded",
+                "ChangePaused",
+                "ChangeResumed"
+            ]
+        },
+        "models.Comparison": {
+            "type": "object",
+            "properties": {
+                "attribution": {
+                    "description": "Attribution has every kind in the order started, ended, price_changed, unchanged,\nthe deltas add up to the net delta",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComparisonGroup"
+                    }
+                },
+                "base": {
+                    "$ref": "#/definitions/models.Period"
+                },
+                "base_totals": {
+                    "$ref": "#/definitions/models.SpendTotals"
+                },
+                "current": {
+                    "$ref": "#/definitions/models.Period"
+                },
+                "current_totals": {
+                    "$ref": "#/definitions/models.SpendTotals"
+                },
+                "delta": {
+                    "description": "Delta is CurrentTotals minus BaseTotals",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SpendTotals"
+                        }
+                    ]
+                },
+                "percent": {
+                    "description": "Percent is the net delta relative to the base net spend, null when the base spend is zero",
+                    "type": "number",
+                    "example": 12.5
+                },
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SubscriptionDelta"
+                    }
+                }
+            }
+        },
+        "models.ComparisonGroup": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "current": {
+                    "type": "integer",
+                    "example": 800
+                },
+                "delta": {
+                    "type": "integer",
+                    "example": 800
+                },
+                "kind": {
+                    "enum": [
+                        "started",
+                        "ended",
+                        "price_changed",
+                        "unchanged"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ComparisonKind"
+                        }
+                    ],
+                    "example": "started"
+                },
+                "subscriptions": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.ComparisonKind": {
+            "type": "string",
+            "enum": [
+                "started",
+                "ended",
+                "price_changed",
+                "unchanged"
+            ],
+            "x-enum-varnames": [
+                "ComparisonStarted",
+                "ComparisonEnded",
+                "ComparisonPriceChanged",
+                "ComparisonUnchanged"
+            ]
+        },
+        "models.Discount": {
+            "type": "object",
+            "required": [
+                "amount",
+                "kind",
+                "start_month",
+                "subscription_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 20
+                },
+                "code": {
+                    "description": "Code is the coupon or promo code the discount came from, informational only",
+                    "type": "string",
+                    "example": "AUTUMN20"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DiscountKind"
+                        }
+                    ],
+                    "example": "percent"
+                },
+                "months": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "start_month": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "example": "2025-09-01"
+                },
+                "subscription_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "models.DiscountKind": {
+            "type": "string",
+            "enum": [
+                "percent",
+                "fixed"
+            ],
+            "x-enum-varnames": [
+                "DiscountPercent",
+                "DiscountFixed"
+            ]
+        },
+        "models.DuplicateGroup": {
+            "type": "object",
+            "properties": {
+                "overlaps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Overlap"
+                    }
+                },
+                "service_name": {
+                    "description": "ServiceName is the name of the earliest subscription",
+                    "type": "string",
+                    "example": "Yandex Plus"
+                },
+                "subscriptions": {
+                    "description": "Subscriptions are ordered by start date",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Subscription"
+                    }
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "models.DuplicateMatch": {
+            "type": "string",
+            "enum": [
+                "same_service",
+                "similar_name"
+            ],
+            "x-enum-varnames": [
+                "DuplicateSameService",
+                "DuplicateSimilarName"
+            ]
+        },
+        "models.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
                     "type": "string"
                 },
-                "start_date": {
+                "in": {
                     "type": "string"
                 },
-                "user_id": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "subscriptions.SubscriptionListPage": {
+        "models.Forecast": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ForecastChange"
+                    }
+                },
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ForecastMonth"
+                    }
+                }
+            }
+        },
+        "models.ForecastChange": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "before": {
+                    "description": "Before and After are the net charges of the previous and of this month",
+                    "type": "integer",
+                    "example": 0
+                },
+                "month": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "example": "2025-10-01"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ChangeReason"
+                    },
+                    "example": [
+                        "trial_ended"
+                    ]
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Yandex Plus"
+                },
+                "subscription_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "models.ForecastMonth": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer",
+                    "example": 240
+                },
+                "gross": {
+                    "type": "integer",
+                    "example": 2400
+                },
+                "month": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "example": "2025-10-01"
+                },
+                "net": {
+                    "type": "integer",
+                    "example": 2160
+                },
+                "subscriptions": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "models.MergeRequest": {
+            "type": "object",
+            "required": [
+                "ids",
+                "into"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        3
+                    ]
+                },
+                "into": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "models.MergedRecord": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "merged_at": {
+                    "type": "string",
+                    "example": "2025-10-19T12:00:00Z"
+                },
+                "merged_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "record": {
+                    "$ref": "#/definitions/models.Subscription"
+                },
+                "subscription_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.Overlap": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "example": "2025-09-01"
+                },
+                "match": {
+                    "enum": [
+                        "same_service",
+                        "similar_name"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DuplicateMatch"
+                        }
+                    ],
+                    "example": "same_service"
+                },
+                "subscription_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "to": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "x-nullable": true,
+                    "example": "2025-12-31"
+                }
+            }
+        },
+        "models.Pause": {
+            "type": "object",
+            "required": [
+                "start_date",
+                "subscription_id"
+            ],
+            "properties": {
+                "finish_date": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "x-nullable": true,
+                    "example": "2025-10-31"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_date": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "example": "2025-09-01"
+                },
+                "subscription_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "models.Period": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "example": "2025-09-01"
+                },
+                "to": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "example": "2025-09-30"
+                }
+            }
+        },
+        "models.Plan": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Standard"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 799
+                }
+            }
+        },
+        "models.PriceChange": {
+            "type": "object",
+            "required": [
+                "effective_date",
+                "subscription_id"
+            ],
+            "properties": {
+                "effective_date": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "example": "2026-01-01"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 500
+                },
+                "subscription_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "models.Service": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Netflix Inc",
+                        "netflix.com"
+                    ]
+                },
+                "category": {
+                    "type": "string",
+                    "example": "streaming"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Netflix"
+                },
+                "plans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Plan"
+                    }
+                },
+                "vendor_url": {
+                    "description": "VendorURL is the site of the vendor, empty when unknown",
+                    "type": "string",
+                    "example": "https://www.netflix.com"
+                }
+            }
+        },
+        "models.SpendGroup": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer",
+                    "example": 240
+                },
+                "gross": {
+                    "type": "integer",
+                    "example": 2400
+                },
+                "name": {
+                    "description": "Name is the category, the tag or the tag value, empty for subscriptions without them",
+                    "type": "string",
+                    "example": "streaming"
+                },
+                "net": {
+                    "type": "integer",
+                    "example": 2160
+                },
+                "subscriptions": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.SpendReport": {
+            "type": "object",
+            "properties": {
+                "group_by": {
+                    "type": "string",
+                    "example": "category"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SpendGroup"
+                    }
+                },
+                "total": {
+                    "$ref": "#/definitions/models.SpendTotals"
+                }
+            }
+        },
+        "models.SpendTotals": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer",
+                    "example": 240
+                },
+                "gross": {
+                    "type": "integer",
+                    "example": 2400
+                },
+                "net": {
+                    "type": "integer",
+                    "example": 2160
+                }
+            }
+        },
+        "models.Subscription": {
             "type": "object",
+            "required": [
+                "price",
+                "service_name",
+                "start_date",
+                "user_id"
+            ],
             "properties": {
-                "list": {
+                "billing_day": {
+                    "description": "BillingDay is the day of month the charge periods start on, the day of StartDate when not set",
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1,
+                    "example": 20
+                },
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Discount"
+                    }
+                },
+                "finish_date": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "x-nullable": true,
+                    "example": "2025-12-31"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "intro_months": {
+                    "description": "IntroMonths follow the trial and are charged IntroPrice instead of Price, IntroPrice is required with them",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "intro_price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 200
+                },
+                "pauses": {
+                    "description": "Pauses, Discounts and PriceChanges are managed by their own endpoints, they are ignored by create and update",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Pause"
+                    }
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "price_changes": {
+                    "description": "PriceChanges schedule new regular prices, Price is charged until the first of them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceChange"
+                    }
+                },
+                "service_id": {
+                    "description": "ServiceId is the catalog entry the service name resolved to, it is set by create and update",
+                    "type": "integer",
+                    "x-nullable": true,
+                    "example": 1
+                },
+                "service_name": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Yandex Plus"
+                },
+                "start_date": {
+                    "type": "string",
+                    "format": "billing-date",
+                    "example": "2025-07-20"
+                },
+                "tags": {
+                    "description": "Tags are free labels like \"team:platform\", they are stored lowercased and sorted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "cost-center:42",
+                        "team:platform"
+                    ]
+                },
+                "trial_days": {
+                    "description": "TrialDays is the length of the trial from StartDate, TrialPrice is the monthly price during it, zero for free trials",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 14
+                },
+                "trial_price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "user_id": {
+                    "type": "string",
+                    "format": "uuid",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "models.SubscriptionDelta": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "current": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "delta": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "kind": {
+                    "enum": [
+                        "started",
+                        "ended",
+                        "price_changed",
+                        "unchanged"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ComparisonKind"
+                        }
+                    ],
+                    "example": "price_changed"
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Yandex Plus"
+                },
+                "subscription_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "models.SubscriptionListPage": {
+            "type": "object",
+            "properties": {
+                "List": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/subscriptions.Subscription"
+                        "$ref": "#/definitions/models.Subscription"
                     }
                 },
-                "page": {
+                "Page": {
                     "type": "integer"
                 },
-                "perPage": {
+                "PerPage": {
                     "type": "integer"
                 },
-                "total": {
+                "Total": {
                     "type": "integer"
                 }
             }
+        },
+        "models.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid request data"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "required when the service runs with API_KEYS, \"Authorization: Bearer \u003ckey\u003e\" is accepted as well",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
         }
     }
 }`
@@ -382,7 +3562,7 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "localhost:8080",
+	Host:             "",
 	BasePath:         "/",
 	Schemes:          []string{"http"},
 	Title:            "Subscriptions service",
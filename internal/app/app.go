@@ -0,0 +1,223 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	"github.com/zakharova-e/subscriptions-info/internal/auth"
+	"github.com/zakharova-e/subscriptions-info/internal/config"
+	"github.com/zakharova-e/subscriptions-info/internal/connections"
+	"github.com/zakharova-e/subscriptions-info/internal/grpcserver"
+	"github.com/zakharova-e/subscriptions-info/internal/health"
+	"github.com/zakharova-e/subscriptions-info/internal/metrics"
+	"github.com/zakharova-e/subscriptions-info/internal/notifications"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+	"github.com/zakharova-e/subscriptions-info/internal/tracing"
+	"github.com/zakharova-e/subscriptions-info/internal/web"
+)
+
+// Worker is a background job bound to the application lifetime, it must return once ctx is done
+type Worker func(ctx context.Context)
+
+type namedWorker struct {
+	name    string
+	run     Worker
+	running atomic.Bool
+}
+
+// App owns every long-living resource of the service: database pool, http and grpc servers and background workers
+type App struct {
+	config     config.App
+	server     *http.Server
+	grpcServer *grpc.Server
+	health     *health.Checker
+	workers    []*namedWorker
+	wg         sync.WaitGroup
+
+	shutdownTracing func(context.Context) error
+}
+
+// New connects to the database, applies migrations and prepares the http and grpc servers
+func New(ctx context.Context, cfg config.App) (*App, error) {
+	if err := connections.Connect(ctx, cfg.DB); err != nil {
+		return nil, err
+	}
+	if cfg.AutoMigrate {
+		if err := connections.Migrate(cfg.DB); err != nil {
+			_ = connections.Close()
+			return nil, err
+		}
+		slog.Info("migrations applied")
+	}
+	expectedVersion, err := connections.LatestMigrationVersion()
+	if err != nil {
+		_ = connections.Close()
+		return nil, err
+	}
+	if err := registerMetrics(); err != nil {
+		_ = connections.Close()
+		return nil, err
+	}
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		_ = connections.Close()
+		return nil, err
+	}
+	a := &App{
+		config:          cfg,
+		health:          health.NewChecker(),
+		shutdownTracing: shutdownTracing,
+	}
+	a.health.AddReadinessCheck("database", func(ctx context.Context) error {
+		return connections.PGDatabase.PingContext(ctx)
+	})
+	if connections.PGReplica != nil {
+		a.health.AddReadinessCheck("replica", func(ctx context.Context) error {
+			return connections.PGReplica.PingContext(ctx)
+		})
+	}
+	a.health.AddReadinessCheck("migrations", func(ctx context.Context) error {
+		return connections.CheckMigrationVersion(ctx, expectedVersion)
+	})
+	a.health.AddReadinessCheck("workers", a.checkWorkers)
+	models.Configure(cfg.Billing)
+	keys := auth.NewKeys(cfg.APIKeys)
+	a.server = web.NewServer(cfg.ListenAddr, a.health, keys)
+	a.grpcServer = grpcserver.New(keys)
+	if cfg.Budgets.CheckInterval > 0 {
+		a.AddWorker("budgets", subscriptions.BudgetWorker(cfg.Budgets.CheckInterval, notifications.New(cfg.Notifications)))
+	}
+	return a, nil
+}
+
+func registerMetrics() error {
+	errs := []error{
+		metrics.RegisterDB(connections.PGDatabase, "postgres"),
+		metrics.Register(subscriptions.NewCollector()),
+	}
+	if connections.PGReplica != nil {
+		errs = append(errs, metrics.RegisterDB(connections.PGReplica, "postgres_replica"))
+	}
+	return errors.Join(errs...)
+}
+
+// AddWorker registers a background job, it is started by Run
+func (a *App) AddWorker(name string, run Worker) {
+	a.workers = append(a.workers, &namedWorker{name: name, run: run})
+}
+
+func (a *App) checkWorkers(ctx context.Context) error {
+	var stopped []error
+	for _, w := range a.workers {
+		if !w.running.Load() {
+			stopped = append(stopped, fmt.Errorf("worker %s is not running", w.name))
+		}
+	}
+	return errors.Join(stopped...)
+}
+
+// Run serves requests until SIGINT/SIGTERM is received or the server fails,
+// then drains in-flight requests, stops workers and closes the database
+func (a *App) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	for _, w := range a.workers {
+		a.wg.Add(1)
+		w.running.Store(true)
+		go func(w *namedWorker) {
+			defer a.wg.Done()
+			defer w.running.Store(false)
+			slog.Info("worker started", "worker", w.name)
+			w.run(workersCtx)
+			slog.Info("worker stopped", "worker", w.name)
+		}(w)
+	}
+
+	listener, err := net.Listen("tcp", a.config.GRPCListenAddr)
+	if err != nil {
+		return errors.Join(err, a.shutdown(stopWorkers))
+	}
+
+	serverErr := make(chan error, 2)
+	go func() {
+		slog.Info("server started", "addr", a.config.ListenAddr)
+		if err := a.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+	go func() {
+		slog.Info("grpc server started", "addr", a.config.GRPCListenAddr)
+		if err := a.grpcServer.Serve(listener); err != nil {
+			serverErr <- err
+		}
+	}()
+
+	var errRun error
+	select {
+	case <-ctx.Done():
+		slog.Info("shutdown signal received")
+	case errRun = <-serverErr:
+	}
+	return errors.Join(errRun, a.shutdown(stopWorkers))
+}
+
+func (a *App) shutdown(stopWorkers context.CancelFunc) error {
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.config.ShutdownTimeout)
+	defer cancel()
+
+	// readiness must fail while in-flight requests are drained
+	a.health.SetMaintenance(true)
+	errServer := a.server.Shutdown(shutdownCtx)
+	if errServer != nil {
+		slog.Error("server shutdown", "error", errServer)
+	}
+	a.stopGRPC(shutdownCtx)
+
+	stopWorkers()
+	workersDone := make(chan struct{})
+	go func() {
+		a.wg.Wait()
+		close(workersDone)
+	}()
+	var errWorkers error
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		errWorkers = errors.New("background workers did not stop in time")
+	}
+
+	errTracing := a.shutdownTracing(shutdownCtx)
+	errDB := connections.Close()
+	slog.Info("server stopped")
+	return errors.Join(errServer, errWorkers, errTracing, errDB)
+}
+
+// stopGRPC waits for open rpcs and streams, cutting them off once ctx is done
+func (a *App) stopGRPC(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		a.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		slog.Error("grpc server did not stop in time, closing connections")
+		a.grpcServer.Stop()
+	}
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// Keys validates api keys, an empty set disables authentication
+type Keys struct {
+	keys []string
+}
+
+func NewKeys(keys []string) *Keys {
+	return &Keys{keys: keys}
+}
+
+func (k *Keys) Enabled() bool {
+	return len(k.keys) > 0
+}
+
+// Valid compares key with every configured key in constant time
+func (k *Keys) Valid(key string) bool {
+	if !k.Enabled() {
+		return true
+	}
+	valid := false
+	for _, candidate := range k.keys {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(key)) == 1 {
+			valid = true
+		}
+	}
+	return valid && key != ""
+}
+
+// FromHeader extracts the key from "Authorization: Bearer <key>" or X-API-Key
+func FromHeader(header http.Header) string {
+	if bearer, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(bearer)
+	}
+	return header.Get(APIKeyHeader)
+}
+
+// Middleware rejects requests without a valid api key, paths in public are always allowed,
+// a public path ending with "/" allows the whole subtree
+func (k *Keys) Middleware(next http.Handler, public ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !k.Enabled() || r.Method == http.MethodOptions || isPublic(r.URL.Path, public) || k.Valid(FromHeader(r.Header)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Bearer realm="subscriptions"`)
+		http.Error(w, "missing or invalid api key", http.StatusUnauthorized)
+	})
+}
+
+func isPublic(path string, public []string) bool {
+	for _, p := range public {
+		if path == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(path, p)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/auth"
+)
+
+func TestKeys_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		key  string
+		want bool
+	}{
+		{"test without keys configured", nil, "", true},
+		{"test with matching key", []string{"first", "second"}, "second", true},
+		{"test with wrong key", []string{"first", "second"}, "third", false},
+		{"test with empty key", []string{"first"}, "", false},
+		{"test with key prefix", []string{"first"}, "fir", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := auth.NewKeys(tt.keys).Valid(tt.key); got != tt.want {
+				t.Errorf("Keys.Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   string
+	}{
+		{"test without headers", http.Header{}, ""},
+		{"test with bearer token", http.Header{"Authorization": {"Bearer secret "}}, "secret"},
+		{"test with api key header", http.Header{"X-Api-Key": {"secret"}}, "secret"},
+		{"test with bearer token and api key header", http.Header{"Authorization": {"Bearer first"}, "X-Api-Key": {"second"}}, "first"},
+		{"test with basic auth", http.Header{"Authorization": {"Basic c2VjcmV0"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := auth.FromHeader(tt.header); got != tt.want {
+				t.Errorf("FromHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeys_Middleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	tests := []struct {
+		name   string
+		keys   []string
+		method string
+		path   string
+		header http.Header
+		want   int
+	}{
+		{"test without keys configured", nil, http.MethodGet, "/subscription/list", http.Header{}, http.StatusNoContent},
+		{"test without key", []string{"secret"}, http.MethodGet, "/subscription/list", http.Header{}, http.StatusUnauthorized},
+		{"test with wrong key", []string{"secret"}, http.MethodGet, "/subscription/list", http.Header{"X-Api-Key": {"wrong"}}, http.StatusUnauthorized},
+		{"test with api key header", []string{"secret"}, http.MethodGet, "/subscription/list", http.Header{"X-Api-Key": {"secret"}}, http.StatusNoContent},
+		{"test with bearer token", []string{"secret"}, http.MethodDelete, "/subscription/delete", http.Header{"Authorization": {"Bearer secret"}}, http.StatusNoContent},
+		{"test with public path", []string{"secret"}, http.MethodGet, "/healthz", http.Header{}, http.StatusNoContent},
+		{"test with public path prefix", []string{"secret"}, http.MethodGet, "/healthz/extra", http.Header{}, http.StatusUnauthorized},
+		{"test with public subtree", []string{"secret"}, http.MethodGet, "/dashboard/app.js", http.Header{}, http.StatusNoContent},
+		{"test with public subtree root", []string{"secret"}, http.MethodGet, "/dashboard/", http.Header{}, http.StatusNoContent},
+		{"test with public subtree prefix", []string{"secret"}, http.MethodGet, "/dashboards", http.Header{}, http.StatusUnauthorized},
+		{"test with cors preflight", []string{"secret"}, http.MethodOptions, "/subscription/list", http.Header{}, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, tt.path, nil)
+			request.Header = tt.header
+			response := httptest.NewRecorder()
+			auth.NewKeys(tt.keys).Middleware(next, "/healthz", "/dashboard/").ServeHTTP(response, request)
+			if response.Code != tt.want {
+				t.Errorf("status = %d, want %d", response.Code, tt.want)
+			}
+			if tt.want == http.StatusUnauthorized && response.Header().Get("WWW-Authenticate") == "" {
+				t.Errorf("401 without WWW-Authenticate header")
+			}
+		})
+	}
+}
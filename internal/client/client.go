@@ -0,0 +1,500 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+// APIError is returned for every non-2xx response of the service
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (err *APIError) Error() string {
+	return fmt.Sprintf("server responded %d: %s", err.StatusCode, err.Message)
+}
+
+// Client calls the subscriptions http API
+type Client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+func New(baseURL string, apiKey string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *Client) Create(ctx context.Context, item models.Subscription) (int32, error) {
+	body, err := json.Marshal(&item)
+	if err != nil {
+		return 0, err
+	}
+	data, err := c.do(ctx, http.MethodPost, "/subscription/create", nil, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected create response %q", data)
+	}
+	return int32(id), nil
+}
+
+func (c *Client) Read(ctx context.Context, id int32) (*models.Subscription, error) {
+	data, err := c.do(ctx, http.MethodGet, "/subscription/read", url.Values{"rowId": {strconv.Itoa(int(id))}}, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var item models.Subscription
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (c *Client) Update(ctx context.Context, item models.Subscription) error {
+	body, err := json.Marshal(&item)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, http.MethodPut, "/subscription/update", nil, "application/json", bytes.NewReader(body))
+	return err
+}
+
+func (c *Client) Delete(ctx context.Context, id int32) error {
+	_, err := c.do(ctx, http.MethodDelete, "/subscription/delete", url.Values{"rowId": {strconv.Itoa(int(id))}}, "", nil)
+	return err
+}
+
+// Filter narrows list and sum requests, empty fields are not sent
+type Filter struct {
+	UserId      string
+	ServiceName string
+}
+
+func (f Filter) values() url.Values {
+	values := url.Values{}
+	if f.UserId != "" {
+		values.Set("userId", f.UserId)
+	}
+	if f.ServiceName != "" {
+		values.Set("serviceName", f.ServiceName)
+	}
+	return values
+}
+
+func (c *Client) List(ctx context.Context, page int, filter Filter) (*models.SubscriptionListPage, error) {
+	query := filter.values()
+	query.Set("page", strconv.Itoa(page))
+	data, err := c.do(ctx, http.MethodGet, "/subscription/list", query, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var list models.SubscriptionListPage
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListAll walks every page of the list
+func (c *Client) ListAll(ctx context.Context, filter Filter) ([]models.Subscription, error) {
+	var all []models.Subscription
+	for page := 1; ; page++ {
+		list, err := c.List(ctx, page, filter)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, list.List...)
+		if len(list.List) == 0 || page*list.PerPage >= list.Total {
+			return all, nil
+		}
+	}
+}
+
+// Sum returns the spend for the period, from and to use the "YYYY-MM-DD" or "MM-YYYY" formats of the API
+func (c *Client) Sum(ctx context.Context, from string, to string, filter Filter, mode models.SumMode) (int, error) {
+	form := filter.values()
+	form.Set("filterFrom", from)
+	form.Set("filterTo", to)
+	form.Set("mode", string(mode))
+	data, err := c.do(ctx, http.MethodPost, "/subscription/sum", nil, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, err
+	}
+	sum, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected sum response %q", data)
+	}
+	return sum, nil
+}
+
+// SumTotals returns the spend of the period before and after discounts
+func (c *Client) SumTotals(ctx context.Context, from string, to string, filter Filter, mode models.SumMode) (models.SpendTotals, error) {
+	form := filter.values()
+	form.Set("filterFrom", from)
+	form.Set("filterTo", to)
+	form.Set("mode", string(mode))
+	var totals models.SpendTotals
+	data, err := c.do(ctx, http.MethodPost, "/subscription/sum/totals", nil, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return totals, err
+	}
+	err = json.Unmarshal(data, &totals)
+	return totals, err
+}
+
+// Report returns the spend of the period grouped by "category", "tag" or "tag:KEY",
+// tags is a filter expression like "team:platform AND NOT env:test", empty selects all subscriptions
+func (c *Client) Report(ctx context.Context, from string, to string, filter Filter, mode models.SumMode, groupBy string, tags string) (*models.SpendReport, error) {
+	form := filter.values()
+	form.Set("filterFrom", from)
+	form.Set("filterTo", to)
+	form.Set("mode", string(mode))
+	form.Set("groupBy", groupBy)
+	if tags != "" {
+		form.Set("tags", tags)
+	}
+	data, err := c.do(ctx, http.MethodPost, "/subscription/report", nil, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	var report models.SpendReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Compare returns the spend of the period against baseFrom..baseTo, or against the period picked by against
+// when they are empty
+func (c *Client) Compare(ctx context.Context, from string, to string, baseFrom string, baseTo string, against models.CompareAgainst,
+	filter Filter, mode models.SumMode) (*models.Comparison, error) {
+	form := filter.values()
+	form.Set("filterFrom", from)
+	form.Set("filterTo", to)
+	form.Set("mode", string(mode))
+	if baseFrom != "" || baseTo != "" {
+		form.Set("baseFrom", baseFrom)
+		form.Set("baseTo", baseTo)
+	} else {
+		form.Set("against", string(against))
+	}
+	data, err := c.do(ctx, http.MethodPost, "/subscription/compare", nil, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	var comparison models.Comparison
+	if err := json.Unmarshal(data, &comparison); err != nil {
+		return nil, err
+	}
+	return &comparison, nil
+}
+
+// Forecast projects the spend of months calendar months from the month of from, an empty from is the current month
+func (c *Client) Forecast(ctx context.Context, from string, months int, filter Filter) (*models.Forecast, error) {
+	query := filter.values()
+	query.Set("months", strconv.Itoa(months))
+	if from != "" {
+		query.Set("from", from)
+	}
+	data, err := c.do(ctx, http.MethodGet, "/subscription/forecast", query, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var forecast models.Forecast
+	if err := json.Unmarshal(data, &forecast); err != nil {
+		return nil, err
+	}
+	return &forecast, nil
+}
+
+// TrialsEnding returns subscriptions whose trial ends within days from today, negative days use the server default
+func (c *Client) TrialsEnding(ctx context.Context, days int) ([]models.Subscription, error) {
+	query := url.Values{}
+	if days >= 0 {
+		query.Set("days", strconv.Itoa(days))
+	}
+	data, err := c.do(ctx, http.MethodGet, "/subscription/trials", query, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var items []models.Subscription
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Pause stops billing of a subscription and returns the pause id
+func (c *Client) Pause(ctx context.Context, pause models.Pause) (int32, error) {
+	body, err := json.Marshal(&pause)
+	if err != nil {
+		return 0, err
+	}
+	data, err := c.do(ctx, http.MethodPost, "/subscription/pause", nil, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected pause response %q", data)
+	}
+	return int32(id), nil
+}
+
+// Resume finishes the open pause of the subscription, date is the first billed day
+func (c *Client) Resume(ctx context.Context, subscriptionId int32, date string) error {
+	form := url.Values{"rowId": {strconv.Itoa(int(subscriptionId))}, "date": {date}}
+	_, err := c.do(ctx, http.MethodPost, "/subscription/resume", nil, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	return err
+}
+
+func (c *Client) DeletePause(ctx context.Context, id int32) error {
+	_, err := c.do(ctx, http.MethodDelete, "/subscription/pause/delete", url.Values{"rowId": {strconv.Itoa(int(id))}}, "", nil)
+	return err
+}
+
+// AddDiscount attaches a discount to a subscription and returns the discount id
+func (c *Client) AddDiscount(ctx context.Context, discount models.Discount) (int32, error) {
+	body, err := json.Marshal(&discount)
+	if err != nil {
+		return 0, err
+	}
+	data, err := c.do(ctx, http.MethodPost, "/subscription/discount", nil, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected discount response %q", data)
+	}
+	return int32(id), nil
+}
+
+func (c *Client) DeleteDiscount(ctx context.Context, id int32) error {
+	_, err := c.do(ctx, http.MethodDelete, "/subscription/discount/delete", url.Values{"rowId": {strconv.Itoa(int(id))}}, "", nil)
+	return err
+}
+
+// AddPriceChange schedules a new regular price of a subscription and returns the change id
+func (c *Client) AddPriceChange(ctx context.Context, change models.PriceChange) (int32, error) {
+	body, err := json.Marshal(&change)
+	if err != nil {
+		return 0, err
+	}
+	data, err := c.do(ctx, http.MethodPost, "/subscription/price-change", nil, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected price change response %q", data)
+	}
+	return int32(id), nil
+}
+
+func (c *Client) DeletePriceChange(ctx context.Context, id int32) error {
+	_, err := c.do(ctx, http.MethodDelete, "/subscription/price-change/delete", url.Values{"rowId": {strconv.Itoa(int(id))}}, "", nil)
+	return err
+}
+
+// Duplicates returns the groups of overlapping subscriptions of the same service, of every user when userId is empty
+func (c *Client) Duplicates(ctx context.Context, userId string) ([]models.DuplicateGroup, error) {
+	data, err := c.do(ctx, http.MethodGet, "/subscription/duplicates", Filter{UserId: userId}.values(), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var groups []models.DuplicateGroup
+	err = json.Unmarshal(data, &groups)
+	return groups, err
+}
+
+// Merge merges the ids into the subscription into and returns it as merged
+func (c *Client) Merge(ctx context.Context, into int32, ids []int32) (*models.Subscription, error) {
+	body, err := json.Marshal(models.MergeRequest{Into: into, Ids: ids})
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.do(ctx, http.MethodPost, "/subscription/merge", nil, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	var item models.Subscription
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Merges returns the snapshots of the subscriptions merged into the subscription, oldest first
+func (c *Client) Merges(ctx context.Context, id int32) ([]models.MergedRecord, error) {
+	data, err := c.do(ctx, http.MethodGet, "/subscription/merges", url.Values{"rowId": {strconv.Itoa(int(id))}}, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var records []models.MergedRecord
+	err = json.Unmarshal(data, &records)
+	return records, err
+}
+
+// Services returns the service catalog ordered by name
+func (c *Client) Services(ctx context.Context) ([]models.Service, error) {
+	data, err := c.do(ctx, http.MethodGet, "/service/list", nil, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var services []models.Service
+	err = json.Unmarshal(data, &services)
+	return services, err
+}
+
+func (c *Client) Service(ctx context.Context, id int32) (*models.Service, error) {
+	return c.service(ctx, "/service/read", url.Values{"rowId": {strconv.Itoa(int(id))}})
+}
+
+// ResolveService returns the catalog entry of a service name or alias
+func (c *Client) ResolveService(ctx context.Context, name string) (*models.Service, error) {
+	return c.service(ctx, "/service/resolve", url.Values{"name": {name}})
+}
+
+func (c *Client) service(ctx context.Context, path string, query url.Values) (*models.Service, error) {
+	data, err := c.do(ctx, http.MethodGet, path, query, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var service models.Service
+	if err := json.Unmarshal(data, &service); err != nil {
+		return nil, err
+	}
+	return &service, nil
+}
+
+// CreateService adds a catalog entry and returns its id
+func (c *Client) CreateService(ctx context.Context, service models.Service) (int32, error) {
+	body, err := json.Marshal(service)
+	if err != nil {
+		return 0, err
+	}
+	data, err := c.do(ctx, http.MethodPost, "/service/create", nil, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected create response %q", data)
+	}
+	return int32(id), nil
+}
+
+func (c *Client) UpdateService(ctx context.Context, service models.Service) error {
+	body, err := json.Marshal(service)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, http.MethodPut, "/service/update", nil, "application/json", bytes.NewReader(body))
+	return err
+}
+
+func (c *Client) DeleteService(ctx context.Context, id int32) error {
+	_, err := c.do(ctx, http.MethodDelete, "/service/delete", url.Values{"rowId": {strconv.Itoa(int(id))}}, "", nil)
+	return err
+}
+
+// Budgets returns every budget with the spend of the current month
+func (c *Client) Budgets(ctx context.Context) ([]models.BudgetStatus, error) {
+	data, err := c.do(ctx, http.MethodGet, "/budget/list", nil, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []models.BudgetStatus
+	if err := json.Unmarshal(data, &statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// CreateBudget adds a monthly budget and returns its id
+func (c *Client) CreateBudget(ctx context.Context, budget models.Budget) (int32, error) {
+	body, err := json.Marshal(budget)
+	if err != nil {
+		return 0, err
+	}
+	data, err := c.do(ctx, http.MethodPost, "/budget/create", nil, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected create response %q", data)
+	}
+	return int32(id), nil
+}
+
+func (c *Client) DeleteBudget(ctx context.Context, id int32) error {
+	_, err := c.do(ctx, http.MethodDelete, "/budget/delete", url.Values{"rowId": {strconv.Itoa(int(id))}}, "", nil)
+	return err
+}
+
+// BudgetAlerts returns the reached thresholds of the budget, of all budgets when id is zero, newest first
+func (c *Client) BudgetAlerts(ctx context.Context, id int32) ([]models.BudgetAlert, error) {
+	query := url.Values{}
+	if id > 0 {
+		query.Set("budgetId", strconv.Itoa(int(id)))
+	}
+	data, err := c.do(ctx, http.MethodGet, "/budget/alerts", query, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var alerts []models.BudgetAlert
+	if err := json.Unmarshal(data, &alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, contentType string, body io.Reader) ([]byte, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target = target + "?" + query.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	if c.apiKey != "" {
+		request.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	response, err := c.http.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, &APIError{StatusCode: response.StatusCode, Message: strings.TrimSpace(string(data))}
+	}
+	return data, nil
+}
@@ -1,3 +1,210 @@
 package config
 
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
 const DefaultPageSize = 50
+
+type App struct {
+	ListenAddr      string
+	GRPCListenAddr  string
+	ShutdownTimeout time.Duration
+	// AutoMigrate applies pending migrations on server start
+	AutoMigrate bool
+	DB          DB
+	Log         Log
+	Tracing     Tracing
+	Billing     Billing
+	Budgets     Budgets
+	// Notifications deliver budget alerts
+	Notifications Notifications
+	// APIKeys protect the API when set, requests must send one of them
+	APIKeys []string
+}
+
+type DB struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+	Timeouts QueryTimeouts
+	Pool     Pool
+	Retry    Retry
+	// ReplicaDSN is an optional read-only replica used for read, list and sum queries
+	ReplicaDSN string
+}
+
+type Pool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// Retry configures the exponential backoff of startup connection and idempotent reads
+type Retry struct {
+	ConnectAttempts int
+	ReadAttempts    int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+}
+
+// QueryTimeouts limit how long a single repository operation may run, zero disables the limit
+type QueryTimeouts struct {
+	Read  time.Duration
+	Write time.Duration
+	List  time.Duration
+	Sum   time.Duration
+}
+
+type Billing struct {
+	// TrialWarningDays is how many days ahead trials are reported as ending
+	TrialWarningDays int
+	// DuplicateWarnings makes create and update report overlapping subscriptions of the same user and service
+	DuplicateWarnings bool
+}
+
+type Budgets struct {
+	// CheckInterval is how often budgets are evaluated against the spend of the month, zero disables alerts
+	CheckInterval time.Duration
+}
+
+type Notifications struct {
+	// WebhookURL receives notifications as json posts, they are only logged when it is empty
+	WebhookURL string
+	Timeout    time.Duration
+}
+
+type Log struct {
+	Level        string
+	Body         bool
+	BodyLimit    int
+	RedactFields []string
+}
+
+type Tracing struct {
+	Exporter    string
+	SampleRatio float64
+}
+
+// Load reads application settings from the environment, falling back to defaults
+func Load() App {
+	return App{
+		ListenAddr:      getEnv("LISTEN_ADDR", ":8080"),
+		GRPCListenAddr:  getEnv("GRPC_LISTEN_ADDR", ":9090"),
+		ShutdownTimeout: getEnvDuration("SHUTDOWN_TIMEOUT", 15*time.Second),
+		AutoMigrate:     getEnvBool("AUTO_MIGRATE", true),
+		DB: DB{
+			Host:     os.Getenv("DB_HOST"),
+			Port:     getEnv("DB_PORT", "5432"),
+			User:     os.Getenv("DB_USER"),
+			Password: os.Getenv("DB_PASSWORD"),
+			Name:     os.Getenv("DB_NAME"),
+			Timeouts: QueryTimeouts{
+				Read:  getEnvDuration("DB_TIMEOUT_READ", 2*time.Second),
+				Write: getEnvDuration("DB_TIMEOUT_WRITE", 5*time.Second),
+				List:  getEnvDuration("DB_TIMEOUT_LIST", 5*time.Second),
+				Sum:   getEnvDuration("DB_TIMEOUT_SUM", 10*time.Second),
+			},
+			Pool: Pool{
+				MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 20),
+				MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
+				ConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+				ConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+			},
+			Retry: Retry{
+				ConnectAttempts: getEnvInt("DB_CONNECT_ATTEMPTS", 10),
+				ReadAttempts:    getEnvInt("DB_READ_ATTEMPTS", 3),
+				BaseDelay:       getEnvDuration("DB_RETRY_BASE_DELAY", 200*time.Millisecond),
+				MaxDelay:        getEnvDuration("DB_RETRY_MAX_DELAY", 10*time.Second),
+			},
+			ReplicaDSN: os.Getenv("DB_REPLICA_DSN"),
+		},
+		Log: Log{
+			Level:        getEnv("LOG_LEVEL", "info"),
+			Body:         getEnvBool("LOG_BODY", false),
+			BodyLimit:    getEnvInt("LOG_BODY_LIMIT", 1024),
+			RedactFields: getEnvList("LOG_REDACT_FIELDS", []string{"password", "token", "api_key", "secret", "authorization"}),
+		},
+		APIKeys: getEnvList("API_KEYS", nil),
+		Billing: Billing{
+			TrialWarningDays:  getEnvInt("TRIAL_WARNING_DAYS", 7),
+			DuplicateWarnings: getEnvBool("DUPLICATE_WARNINGS", true),
+		},
+		Budgets: Budgets{
+			CheckInterval: getEnvDuration("BUDGET_CHECK_INTERVAL", time.Hour),
+		},
+		Notifications: Notifications{
+			WebhookURL: os.Getenv("NOTIFY_WEBHOOK_URL"),
+			Timeout:    getEnvDuration("NOTIFY_TIMEOUT", 10*time.Second),
+		},
+		Tracing: Tracing{
+			Exporter:    getEnv("TRACING_EXPORTER", "none"),
+			SampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+	}
+}
+
+func (db DB) ConnString() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", db.User, db.Password, db.Host, db.Port, db.Name)
+}
+
+func getEnv(name string, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(name string, fallback int) int {
+	value, errParse := strconv.Atoi(os.Getenv(name))
+	if errParse != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvFloat(name string, fallback float64) float64 {
+	value, errParse := strconv.ParseFloat(os.Getenv(name), 64)
+	if errParse != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvBool(name string, fallback bool) bool {
+	value, errParse := strconv.ParseBool(os.Getenv(name))
+	if errParse != nil {
+		return fallback
+	}
+	return value
+}
+
+// getEnvList splits a comma separated variable, empty items are skipped
+func getEnvList(name string, fallback []string) []string {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func getEnvDuration(name string, fallback time.Duration) time.Duration {
+	value, errParse := time.ParseDuration(os.Getenv(name))
+	if errParse != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,95 @@
+package connections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/zakharova-e/subscriptions-info/internal/config"
+	"github.com/zakharova-e/subscriptions-info/internal/migrations"
+)
+
+// NewMigrator reads migrations embedded into the binary, the caller must Close it
+func NewMigrator(cfg config.DB) (*migrate.Migrate, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, err
+	}
+	return migrate.NewWithSourceInstance("iofs", src, cfg.ConnString())
+}
+
+// Migrate applies all pending migrations
+func Migrate(cfg config.DB) error {
+	m, err := NewMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// MigrationVersions lists versions of all embedded migrations in ascending order
+func MigrationVersions() ([]uint, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	return listVersions(src)
+}
+
+func listVersions(src source.Driver) ([]uint, error) {
+	version, err := src.First()
+	if err != nil {
+		return nil, err
+	}
+	versions := []uint{version}
+	for {
+		next, errNext := src.Next(version)
+		if errors.Is(errNext, fs.ErrNotExist) {
+			return versions, nil
+		}
+		if errNext != nil {
+			return nil, errNext
+		}
+		versions = append(versions, next)
+		version = next
+	}
+}
+
+// LatestMigrationVersion returns the newest migration version shipped with the service
+func LatestMigrationVersion() (uint, error) {
+	versions, err := MigrationVersions()
+	if err != nil {
+		return 0, err
+	}
+	return versions[len(versions)-1], nil
+}
+
+// CheckMigrationVersion fails if the database schema is dirty or differs from the expected version
+func CheckMigrationVersion(ctx context.Context, expected uint) error {
+	var (
+		version uint
+		dirty   bool
+	)
+	row := PGDatabase.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1")
+	if err := row.Scan(&version, &dirty); err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", version)
+	}
+	if version != expected {
+		return fmt.Errorf("schema version %d, expected %d", version, expected)
+	}
+	return nil
+}
@@ -1,42 +1,115 @@
 package connections
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
+	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/zakharova-e/subscriptions-info/internal/config"
 )
 
 var PGDatabase *sql.DB
 
-func init() {
-	connString := fmt.Sprintf("postgres://%s:%s@%s:5432/%s?sslmode=disable",
-		os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"), os.Getenv("DB_HOST"), os.Getenv("DB_NAME"))
-	var err error
-	PGDatabase, err = sql.Open("pgx", connString)
+// PGReplica is nil unless a read replica is configured, use Reader to pick the pool for reads
+var PGReplica *sql.DB
+
+var (
+	timeouts    config.QueryTimeouts
+	retryConfig config.Retry
+)
+
+type Operation int
+
+const (
+	OperationRead Operation = iota
+	OperationWrite
+	OperationList
+	OperationSum
+)
+
+// Connect opens the primary pool and the optional replica pool, the database is pinged with
+// exponential backoff so the service survives postgres starting slower than the service
+func Connect(ctx context.Context, cfg config.DB) error {
+	timeouts = cfg.Timeouts
+	retryConfig = cfg.Retry
+	db, err := open(ctx, cfg.ConnString(), cfg.Pool)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	errPing := PGDatabase.Ping()
-	if errPing != nil {
-		log.Fatal(errPing)
+	PGDatabase = db
+	if cfg.ReplicaDSN != "" {
+		replica, errReplica := open(ctx, cfg.ReplicaDSN, cfg.Pool)
+		if errReplica != nil {
+			_ = db.Close()
+			return fmt.Errorf("replica: %w", errReplica)
+		}
+		PGReplica = replica
 	}
-	m, err := migrate.New(
-		"file://internal/migrations",
-		connString,
-	)
+	return nil
+}
+
+func open(ctx context.Context, dsn string, pool config.Pool) (*sql.DB, error) {
+	db, err := sql.Open("pgx", dsn)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		log.Fatal(err)
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+	errPing := retry(ctx, max(retryConfig.ConnectAttempts, 1), func(attempt int) error {
+		errPing := db.PingContext(ctx)
+		if errPing != nil {
+			slog.WarnContext(ctx, "database is not reachable", "attempt", attempt, "error", errPing)
+		}
+		return errPing
+	}, func(error) bool { return true })
+	if errPing != nil {
+		_ = db.Close()
+		return nil, errPing
 	}
+	return db, nil
+}
+
+// Reader returns the pool for read-only queries: the replica when it is configured, the primary otherwise
+func Reader() *sql.DB {
+	if PGReplica != nil {
+		return PGReplica
+	}
+	return PGDatabase
+}
 
-	log.Println("Migrations applied!")
+// WithTimeout derives a context limited by the configured timeout of the operation
+func WithTimeout(ctx context.Context, op Operation) (context.Context, context.CancelFunc) {
+	var timeout time.Duration
+	switch op {
+	case OperationRead:
+		timeout = timeouts.Read
+	case OperationWrite:
+		timeout = timeouts.Write
+	case OperationList:
+		timeout = timeouts.List
+	case OperationSum:
+		timeout = timeouts.Sum
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
 
+func Close() error {
+	var errReplica, errPrimary error
+	if PGReplica != nil {
+		errReplica = PGReplica.Close()
+	}
+	if PGDatabase != nil {
+		errPrimary = PGDatabase.Close()
+	}
+	return errors.Join(errPrimary, errReplica)
 }
@@ -0,0 +1,78 @@
+package connections
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// RetryRead runs an idempotent read and repeats it while it fails with a transient error.
+// When a replica is configured the last attempt goes to the primary
+func RetryRead(ctx context.Context, read func(db *sql.DB) error) error {
+	attempts := max(retryConfig.ReadAttempts, 1)
+	return retry(ctx, attempts, func(attempt int) error {
+		db := Reader()
+		if attempt == attempts && attempts > 1 {
+			db = PGDatabase
+		}
+		return read(db)
+	}, IsTransient)
+}
+
+// IsTransient reports errors worth retrying: broken connections, server restarts and serialization conflicts
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) || pgconn.SafeToRetry(err) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case strings.HasPrefix(pgErr.Code, "08"): // connection exception
+			return true
+		case pgErr.Code == "40001", pgErr.Code == "40P01": // serialization failure, deadlock
+			return true
+		case pgErr.Code == "57P01", pgErr.Code == "57P02", pgErr.Code == "57P03": // shutdown, cannot connect now
+			return true
+		}
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func retry(ctx context.Context, attempts int, run func(attempt int) error, retryable func(error) bool) error {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = run(attempt); err == nil || !retryable(err) || attempt == attempts {
+			return err
+		}
+		timer := time.NewTimer(backoff(attempt, retryConfig.BaseDelay, retryConfig.MaxDelay))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+	return err
+}
+
+// backoff doubles the delay on every attempt up to maxDelay, with up to 20% of random jitter
+func backoff(attempt int, baseDelay time.Duration, maxDelay time.Duration) time.Duration {
+	delay := baseDelay << (attempt - 1)
+	if delay <= 0 || delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay + time.Duration(rand.Int64N(int64(delay)/5+1))
+}
@@ -0,0 +1,42 @@
+package connections_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/zakharova-e/subscriptions-info/internal/connections"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"test with nil error", nil, false},
+		{"test with no rows error", sql.ErrNoRows, false},
+		{"test with canceled context", context.Canceled, false},
+		{"test with deadline exceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), false},
+		{"test with bad connection", driver.ErrBadConn, true},
+		{"test with unexpected eof", fmt.Errorf("read: %w", io.ErrUnexpectedEOF), true},
+		{"test with connection exception", &pgconn.PgError{Code: "08006"}, true},
+		{"test with serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"test with admin shutdown", &pgconn.PgError{Code: "57P01"}, true},
+		{"test with unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"test with custom error", errors.New("custom err"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := connections.IsTransient(tt.err); got != tt.want {
+				t.Errorf("IsTransient() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package graphqlapi
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+var schema = graphql.MustParseSchema(schemaSDL, &resolver{})
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Handler executes POSTed GraphQL queries, resolver errors are reported in the "errors" field with a code extension
+func Handler(response http.ResponseWriter, httpRequest *http.Request) {
+	if httpRequest.Method != http.MethodPost {
+		subscriptions.ResponseWithError(response, httpRequest, &models.MethodNotAllowedError{RequiredMethod: http.MethodPost})
+		return
+	}
+	var params request
+	if err := json.NewDecoder(httpRequest.Body).Decode(&params); err != nil {
+		subscriptions.ResponseWithError(response, httpRequest, &models.InvalidParameterError{ParamName: "graphql request body"})
+		return
+	}
+	ctx := withLoaders(httpRequest.Context())
+	result := schema.Exec(ctx, params.Query, params.OperationName, params.Variables)
+	data, err := json.Marshal(result)
+	if err != nil {
+		subscriptions.ResponseWithError(response, httpRequest, &models.JsonError{Err: err})
+		return
+	}
+	response.Header().Set("Content-Type", "application/json")
+	subscriptions.WriteResponse(response, httpRequest, data)
+}
+
+// codedError exposes the error class to clients as extensions.code
+type codedError struct {
+	err  error
+	code string
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+func (e *codedError) Unwrap() error { return e.err }
+func (e *codedError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+// apiError classifies domain errors the same way ResponseWithError maps them to http statuses
+func apiError(err error) error {
+	var (
+		valErr      *models.ValidationError
+		jsonErr     *models.JsonError
+		paramErr    *models.InvalidParameterError
+		notFoundErr *models.ResourceNotFoundError
+		canceledErr *models.QueryCanceledError
+	)
+	switch {
+	case errors.As(err, &canceledErr) && errors.Is(err, context.DeadlineExceeded):
+		return &codedError{err, "TIMEOUT"}
+	case errors.As(err, &canceledErr):
+		return &codedError{err, "UNAVAILABLE"}
+	case errors.As(err, &valErr), errors.As(err, &jsonErr), errors.As(err, &paramErr):
+		return &codedError{err, "BAD_USER_INPUT"}
+	case errors.As(err, &notFoundErr), errors.Is(err, sql.ErrNoRows):
+		return &codedError{err, "NOT_FOUND"}
+	default:
+		return &codedError{err, "INTERNAL"}
+	}
+}
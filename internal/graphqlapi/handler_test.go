@@ -0,0 +1,72 @@
+package graphqlapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/graphqlapi"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"test with get method", http.MethodGet, ``, http.StatusMethodNotAllowed, ""},
+		{"test with invalid body", http.MethodPost, `query`, http.StatusBadRequest, ""},
+		{"test with field resolved without database", http.MethodPost,
+			`{"query":"{ user(id: \"60601fee-2bf1-4721-ae6f-7636e79a0cba\") { id } service(name: \"Netflix\") { name } }"}`,
+			http.StatusOK, `{"data":{"user":{"id":"60601fee-2bf1-4721-ae6f-7636e79a0cba"},"service":{"name":"Netflix"}}}`},
+		{"test with user id that is not a uuid", http.MethodPost,
+			`{"query":"{ user(id: \"u1\") { id } }"}`,
+			http.StatusOK, `"code":"BAD_USER_INPUT"`},
+		{"test with invalid period", http.MethodPost,
+			`{"query":"{ spend(from: \"13-2025\", to: \"01-2026\") }"}`,
+			http.StatusOK, `"code":"BAD_USER_INPUT"`},
+		{"test with subscriptions of invalid user id", http.MethodPost,
+			`{"query":"{ subscriptions(userId: \"u1\") { total } }"}`,
+			http.StatusOK, `"message":"invalid parameter: userId"`},
+		{"test with spend of invalid user id", http.MethodPost,
+			`{"query":"{ spend(from: \"01-2025\", to: \"12-2025\", userId: \"u1\") }"}`,
+			http.StatusOK, `"message":"invalid parameter: userId"`},
+		{"test with spend totals of invalid user id", http.MethodPost,
+			`{"query":"{ spendTotals(from: \"01-2025\", to: \"12-2025\", userId: \"u1\") { net } }"}`,
+			http.StatusOK, `"message":"invalid parameter: userId"`},
+		{"test with spend report of invalid user id", http.MethodPost,
+			`{"query":"{ spendReport(from: \"01-2025\", to: \"12-2025\", groupBy: \"category\", userId: \"u1\") { groupBy } }"}`,
+			http.StatusOK, `"message":"invalid parameter: userId"`},
+		{"test with service totals of invalid user id", http.MethodPost,
+			`{"query":"{ serviceTotals(from: \"01-2025\", to: \"12-2025\", userId: \"u1\") { serviceName } }"}`,
+			http.StatusOK, `"message":"invalid parameter: userId"`},
+		{"test with forecast of invalid user id", http.MethodPost,
+			`{"query":"{ forecast(userId: \"u1\") { months { net } } }"}`,
+			http.StatusOK, `"message":"invalid parameter: userId"`},
+		{"test with compare of invalid user id", http.MethodPost,
+			`{"query":"{ compare(from: \"01-2025\", to: \"12-2025\", userId: \"u1\") { percent } }"}`,
+			http.StatusOK, `"message":"invalid parameter: userId"`},
+		{"test with duplicates of invalid user id", http.MethodPost,
+			`{"query":"{ duplicates(userId: \"u1\") { userId } }"}`,
+			http.StatusOK, `"message":"invalid parameter: userId"`},
+		{"test with unknown field", http.MethodPost,
+			`{"query":"{ unknown }"}`,
+			http.StatusOK, `Cannot query field \"unknown\"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, "/graphql", strings.NewReader(tt.body))
+			response := httptest.NewRecorder()
+			graphqlapi.Handler(response, request)
+			if response.Code != tt.wantStatus {
+				t.Fatalf("Handler() status = %d, want %d", response.Code, tt.wantStatus)
+			}
+			if !strings.Contains(response.Body.String(), tt.wantBody) {
+				t.Errorf("Handler() body = %s, want it to contain %s", response.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
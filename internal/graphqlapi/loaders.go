@@ -0,0 +1,50 @@
+package graphqlapi
+
+import (
+	"context"
+
+	"github.com/graph-gophers/dataloader/v7"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+type loadersKey struct{}
+
+// loaders batch nested lookups of one request: every user or service
+// requested while resolving a level of the query is fetched by a single statement
+type loaders struct {
+	userSummaries     *dataloader.Loader[string, models.Summary]
+	serviceSummaries  *dataloader.Loader[string, models.Summary]
+	userSubscriptions *dataloader.Loader[string, []models.Subscription]
+	catalog           *dataloader.Loader[string, models.Service]
+}
+
+func newLoaders() *loaders {
+	return &loaders{
+		userSummaries:     dataloader.NewBatchedLoader(batch(subscriptions.SubscriptionSummariesByUser)),
+		serviceSummaries:  dataloader.NewBatchedLoader(batch(subscriptions.SubscriptionSummariesByService)),
+		userSubscriptions: dataloader.NewBatchedLoader(batch(subscriptions.SubscriptionListByUsers)),
+		catalog:           dataloader.NewBatchedLoader(batch(subscriptions.ServicesByNames)),
+	}
+}
+
+func withLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders())
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// batch adapts a repository lookup by keys, keys missing from its result get the zero value
+func batch[V any](fetch func(ctx context.Context, keys []string) (map[string]V, error)) dataloader.BatchFunc[string, V] {
+	return func(ctx context.Context, keys []string) []*dataloader.Result[V] {
+		values, err := fetch(ctx, keys)
+		results := make([]*dataloader.Result[V], len(keys))
+		for i, key := range keys {
+			results[i] = &dataloader.Result[V]{Data: values[key], Error: err}
+		}
+		return results
+	}
+}
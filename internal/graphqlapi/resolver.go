@@ -0,0 +1,561 @@
+package graphqlapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+// resolver returns separate Query and Mutation roots, the library would otherwise
+// take the Subscription query field for the subscription operation root
+type resolver struct{}
+
+func (r *resolver) Query() *queryResolver       { return &queryResolver{} }
+func (r *resolver) Mutation() *mutationResolver { return &mutationResolver{} }
+
+type queryResolver struct{}
+
+type mutationResolver struct{}
+
+type serviceInput struct {
+	Name      string
+	Aliases   []string
+	Category  string
+	VendorUrl string
+	Plans     []struct {
+		Name  string
+		Price int32
+	}
+}
+
+type subscriptionInput struct {
+	ServiceName string
+	Price       int32
+	UserId      string
+	StartDate   string
+	FinishDate  *string
+	BillingDay  *int32
+	TrialDays   int32
+	TrialPrice  int32
+	IntroMonths int32
+	IntroPrice  int32
+	Tags        []string
+}
+
+func (r *queryResolver) Subscription(ctx context.Context, args struct{ Id int32 }) (*subscriptionResolver, error) {
+	item, err := subscriptions.SubscriptionRead(ctx, args.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return &subscriptionResolver{*item}, nil
+}
+
+func (r *queryResolver) Subscriptions(ctx context.Context, args struct {
+	Page        int32
+	UserId      *string
+	ServiceName *string
+}) (*pageResolver, error) {
+	if args.Page < 1 {
+		return nil, apiError(&models.InvalidParameterError{ParamName: "page"})
+	}
+	if err := checkUserId(args.UserId); err != nil {
+		return nil, apiError(err)
+	}
+	list, err := subscriptions.SubscriptionList(ctx, int(args.Page), models.ListFilter{UserId: args.UserId, ServiceName: args.ServiceName})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return &pageResolver{list}, nil
+}
+
+func (r *queryResolver) Spend(ctx context.Context, args struct {
+	From        string
+	To          string
+	UserId      *string
+	ServiceName *string
+	Mode        string
+}) (int32, error) {
+	filterFrom, filterTo, err := models.ParsePeriod(args.From, args.To)
+	if err != nil {
+		return 0, apiError(err)
+	}
+	if err := checkUserId(args.UserId); err != nil {
+		return 0, apiError(err)
+	}
+	mode, err := sumMode(args.Mode)
+	if err != nil {
+		return 0, apiError(err)
+	}
+	sum, err := subscriptions.SubscriptionSum(ctx, filterFrom, filterTo, args.UserId, args.ServiceName, mode)
+	if err != nil {
+		return 0, apiError(err)
+	}
+	return int32(sum.Net), nil
+}
+
+func (r *queryResolver) SpendTotals(ctx context.Context, args struct {
+	From        string
+	To          string
+	UserId      *string
+	ServiceName *string
+	Mode        string
+}) (*spendTotalsResolver, error) {
+	filterFrom, filterTo, err := models.ParsePeriod(args.From, args.To)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	if err := checkUserId(args.UserId); err != nil {
+		return nil, apiError(err)
+	}
+	mode, err := sumMode(args.Mode)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	sum, err := subscriptions.SubscriptionSum(ctx, filterFrom, filterTo, args.UserId, args.ServiceName, mode)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return &spendTotalsResolver{sum}, nil
+}
+
+func (r *queryResolver) Forecast(ctx context.Context, args struct {
+	Months      int32
+	From        *string
+	UserId      *string
+	ServiceName *string
+}) (*forecastResolver, error) {
+	from := models.Today()
+	if args.From != nil {
+		var err error
+		if from, err = models.ParseStartDate(*args.From); err != nil {
+			return nil, apiError(&models.InvalidParameterError{ParamName: "from"})
+		}
+	}
+	if err := checkUserId(args.UserId); err != nil {
+		return nil, apiError(err)
+	}
+	forecast, err := subscriptions.SubscriptionForecast(ctx, from, int(args.Months), args.UserId, args.ServiceName)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return &forecastResolver{*forecast}, nil
+}
+
+func (r *queryResolver) Compare(ctx context.Context, args struct {
+	From        string
+	To          string
+	BaseFrom    *string
+	BaseTo      *string
+	Against     string
+	UserId      *string
+	ServiceName *string
+	Mode        string
+}) (*comparisonResolver, error) {
+	filterFrom, filterTo, err := models.ParsePeriod(args.From, args.To)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	var base models.Period
+	if args.BaseFrom != nil || args.BaseTo != nil {
+		if args.BaseFrom == nil || args.BaseTo == nil {
+			return nil, apiError(&models.InvalidParameterError{ParamName: "baseFrom and baseTo"})
+		}
+		if base.From, base.To, err = models.ParsePeriod(*args.BaseFrom, *args.BaseTo); err != nil {
+			return nil, apiError(err)
+		}
+	} else {
+		against, err := models.ParseCompareAgainst(strings.ToLower(args.Against))
+		if err != nil {
+			return nil, apiError(&models.InvalidParameterError{ParamName: "against"})
+		}
+		base.From, base.To = models.BasePeriod(filterFrom, filterTo, against)
+	}
+	if err := checkUserId(args.UserId); err != nil {
+		return nil, apiError(err)
+	}
+	mode, err := sumMode(args.Mode)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	comparison, err := subscriptions.SubscriptionCompare(ctx, base, models.Period{From: filterFrom, To: filterTo},
+		args.UserId, args.ServiceName, mode)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return &comparisonResolver{*comparison}, nil
+}
+
+func (r *queryResolver) SpendReport(ctx context.Context, args struct {
+	From        string
+	To          string
+	GroupBy     string
+	Tags        *string
+	UserId      *string
+	ServiceName *string
+	Mode        string
+}) (*spendReportResolver, error) {
+	filterFrom, filterTo, err := models.ParsePeriod(args.From, args.To)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	groupBy, err := models.ParseReportGroup(args.GroupBy)
+	if err != nil {
+		return nil, apiError(&models.InvalidParameterError{ParamName: "groupBy"})
+	}
+	var filter models.TagFilter
+	if args.Tags != nil {
+		if filter, err = models.ParseTagFilter(*args.Tags); err != nil {
+			return nil, apiError(&models.InvalidParameterError{ParamName: "tags"})
+		}
+	}
+	if err := checkUserId(args.UserId); err != nil {
+		return nil, apiError(err)
+	}
+	mode, err := sumMode(args.Mode)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	report, err := subscriptions.SubscriptionSpendReport(ctx, filterFrom, filterTo, args.UserId, args.ServiceName, mode, groupBy, filter)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return &spendReportResolver{*report}, nil
+}
+
+func (r *queryResolver) ServiceTotals(ctx context.Context, args struct {
+	From   string
+	To     string
+	UserId *string
+	Mode   string
+}) ([]*serviceTotalResolver, error) {
+	filterFrom, filterTo, err := models.ParsePeriod(args.From, args.To)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	if err := checkUserId(args.UserId); err != nil {
+		return nil, apiError(err)
+	}
+	mode, err := sumMode(args.Mode)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	totals, err := subscriptions.SubscriptionSumByService(ctx, filterFrom, filterTo, args.UserId, mode)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	result := make([]*serviceTotalResolver, 0, len(totals))
+	for serviceName, total := range totals {
+		result = append(result, &serviceTotalResolver{serviceName, total})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].serviceName < result[j].serviceName })
+	return result, nil
+}
+
+func (r *queryResolver) TrialsEnding(ctx context.Context, args struct{ Days *int32 }) ([]*subscriptionResolver, error) {
+	days := models.TrialWarningDays()
+	if args.Days != nil {
+		days = int(*args.Days)
+	}
+	if days < 0 {
+		return nil, apiError(&models.InvalidParameterError{ParamName: "days"})
+	}
+	items, err := subscriptions.SubscriptionTrialsEnding(ctx, days)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	result := make([]*subscriptionResolver, 0, len(items))
+	for _, item := range items {
+		result = append(result, &subscriptionResolver{item})
+	}
+	return result, nil
+}
+
+func (r *queryResolver) Duplicates(ctx context.Context, args struct{ UserId *string }) ([]*duplicateGroupResolver, error) {
+	if err := checkUserId(args.UserId); err != nil {
+		return nil, apiError(err)
+	}
+	groups, err := subscriptions.SubscriptionDuplicates(ctx, args.UserId)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	result := make([]*duplicateGroupResolver, len(groups))
+	for i := range groups {
+		result[i] = &duplicateGroupResolver{groups[i]}
+	}
+	return result, nil
+}
+
+func (r *queryResolver) Merges(ctx context.Context, args struct{ SubscriptionId int32 }) ([]*mergedRecordResolver, error) {
+	records, err := subscriptions.SubscriptionMerges(ctx, args.SubscriptionId)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	result := make([]*mergedRecordResolver, len(records))
+	for i := range records {
+		result[i] = &mergedRecordResolver{records[i]}
+	}
+	return result, nil
+}
+
+func (r *queryResolver) User(args struct{ Id string }) (*userResolver, error) {
+	if uuid.Validate(args.Id) != nil {
+		return nil, apiError(&models.InvalidParameterError{ParamName: "id"})
+	}
+	return &userResolver{args.Id}, nil
+}
+
+func (r *queryResolver) Service(args struct{ Name string }) *serviceResolver {
+	return &serviceResolver{name: args.Name}
+}
+
+func (r *queryResolver) Services(ctx context.Context) ([]*serviceResolver, error) {
+	services, err := subscriptions.ServiceList(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	result := make([]*serviceResolver, len(services))
+	for i := range services {
+		result[i] = &serviceResolver{name: services[i].Name, entry: &services[i]}
+	}
+	return result, nil
+}
+
+func (r *mutationResolver) CreateSubscription(ctx context.Context, args struct{ Input subscriptionInput }) (*subscriptionResolver, error) {
+	item, err := fromInput(0, args.Input)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	id, err := subscriptions.SubscriptionCreate(ctx, item)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return readSubscription(ctx, *id)
+}
+
+func (r *mutationResolver) UpdateSubscription(ctx context.Context, args struct {
+	Id    int32
+	Input subscriptionInput
+}) (*subscriptionResolver, error) {
+	item, err := fromInput(args.Id, args.Input)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	if err := subscriptions.SubscriptionUpdate(ctx, item); err != nil {
+		return nil, apiError(err)
+	}
+	return readSubscription(ctx, args.Id)
+}
+
+func (r *mutationResolver) DeleteSubscription(ctx context.Context, args struct{ Id int32 }) (bool, error) {
+	if err := subscriptions.SubscriptionDelete(ctx, args.Id); err != nil {
+		return false, apiError(err)
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) PauseSubscription(ctx context.Context, args struct {
+	SubscriptionId int32
+	StartDate      string
+	FinishDate     *string
+}) (*pauseResolver, error) {
+	pause := models.Pause{SubscriptionId: args.SubscriptionId}
+	var err error
+	if pause.StartDate, err = models.ParseStartDate(args.StartDate); err != nil {
+		return nil, apiError(&models.InvalidParameterError{ParamName: "startDate"})
+	}
+	if args.FinishDate != nil {
+		if pause.FinishDate, err = models.ParseFinishDate(*args.FinishDate); err != nil {
+			return nil, apiError(&models.InvalidParameterError{ParamName: "finishDate"})
+		}
+	}
+	id, err := subscriptions.PauseCreate(ctx, pause)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	pause.Id = *id
+	return &pauseResolver{pause}, nil
+}
+
+func (r *mutationResolver) ResumeSubscription(ctx context.Context, args struct {
+	SubscriptionId int32
+	Date           string
+}) (*subscriptionResolver, error) {
+	resumeDate, err := models.ParseStartDate(args.Date)
+	if err != nil {
+		return nil, apiError(&models.InvalidParameterError{ParamName: "date"})
+	}
+	if err := subscriptions.PauseResume(ctx, args.SubscriptionId, resumeDate); err != nil {
+		return nil, apiError(err)
+	}
+	return readSubscription(ctx, args.SubscriptionId)
+}
+
+func (r *mutationResolver) DeletePause(ctx context.Context, args struct{ Id int32 }) (bool, error) {
+	if err := subscriptions.PauseDelete(ctx, args.Id); err != nil {
+		return false, apiError(err)
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) AddDiscount(ctx context.Context, args struct {
+	SubscriptionId int32
+	Kind           string
+	Amount         int32
+	StartMonth     string
+	Months         int32
+	Code           string
+}) (*discountResolver, error) {
+	discount := models.Discount{SubscriptionId: args.SubscriptionId, Kind: models.DiscountKind(strings.ToLower(args.Kind)),
+		Amount: int(args.Amount), Months: int(args.Months), Code: args.Code}
+	startMonth, err := models.ParseStartDate(args.StartMonth)
+	if err != nil {
+		return nil, apiError(&models.InvalidParameterError{ParamName: "startMonth"})
+	}
+	discount.StartMonth = models.MonthOf(startMonth)
+	id, err := subscriptions.DiscountCreate(ctx, discount)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	discount.Id = *id
+	return &discountResolver{discount}, nil
+}
+
+func (r *mutationResolver) DeleteDiscount(ctx context.Context, args struct{ Id int32 }) (bool, error) {
+	if err := subscriptions.DiscountDelete(ctx, args.Id); err != nil {
+		return false, apiError(err)
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) AddPriceChange(ctx context.Context, args struct {
+	SubscriptionId int32
+	EffectiveDate  string
+	Price          int32
+}) (*priceChangeResolver, error) {
+	change := models.PriceChange{SubscriptionId: args.SubscriptionId, Price: int(args.Price)}
+	var err error
+	if change.EffectiveDate, err = models.ParseStartDate(args.EffectiveDate); err != nil {
+		return nil, apiError(&models.InvalidParameterError{ParamName: "effectiveDate"})
+	}
+	id, err := subscriptions.PriceChangeCreate(ctx, change)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	change.Id = *id
+	return &priceChangeResolver{change}, nil
+}
+
+func (r *mutationResolver) DeletePriceChange(ctx context.Context, args struct{ Id int32 }) (bool, error) {
+	if err := subscriptions.PriceChangeDelete(ctx, args.Id); err != nil {
+		return false, apiError(err)
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) MergeSubscriptions(ctx context.Context, args struct {
+	Into int32
+	Ids  []int32
+}) (*subscriptionResolver, error) {
+	item, err := subscriptions.SubscriptionMerge(ctx, models.MergeRequest{Into: args.Into, Ids: args.Ids})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return &subscriptionResolver{*item}, nil
+}
+
+func (r *mutationResolver) CreateService(ctx context.Context, args struct{ Input serviceInput }) (*serviceResolver, error) {
+	id, err := subscriptions.ServiceCreate(ctx, serviceFromInput(0, args.Input))
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return readService(ctx, *id)
+}
+
+func (r *mutationResolver) UpdateService(ctx context.Context, args struct {
+	Id    int32
+	Input serviceInput
+}) (*serviceResolver, error) {
+	if err := subscriptions.ServiceUpdate(ctx, serviceFromInput(args.Id, args.Input)); err != nil {
+		return nil, apiError(err)
+	}
+	return readService(ctx, args.Id)
+}
+
+func (r *mutationResolver) DeleteService(ctx context.Context, args struct{ Id int32 }) (bool, error) {
+	if err := subscriptions.ServiceDelete(ctx, args.Id); err != nil {
+		return false, apiError(err)
+	}
+	return true, nil
+}
+
+// readService returns the stored entry, with the names normalized by the repository
+func readService(ctx context.Context, id int32) (*serviceResolver, error) {
+	service, err := subscriptions.ServiceRead(ctx, id)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return &serviceResolver{name: service.Name, entry: service}, nil
+}
+
+func serviceFromInput(id int32, input serviceInput) models.Service {
+	service := models.Service{Id: id, Name: input.Name, Aliases: input.Aliases, Category: input.Category, VendorURL: input.VendorUrl}
+	for _, plan := range input.Plans {
+		service.Plans = append(service.Plans, models.Plan{Name: plan.Name, Price: int(plan.Price)})
+	}
+	return service
+}
+
+// readSubscription returns the stored state after a mutation, with defaults and pauses filled in by the repository
+func readSubscription(ctx context.Context, id int32) (*subscriptionResolver, error) {
+	item, err := subscriptions.SubscriptionRead(ctx, id)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return &subscriptionResolver{*item}, nil
+}
+
+func fromInput(id int32, input subscriptionInput) (models.Subscription, error) {
+	item := models.Subscription{Id: id, ServiceName: input.ServiceName, Price: int(input.Price), UserId: input.UserId,
+		TrialDays: int(input.TrialDays), TrialPrice: int(input.TrialPrice), IntroMonths: int(input.IntroMonths), IntroPrice: int(input.IntroPrice),
+		Tags: input.Tags}
+	var err error
+	if item.StartDate, err = models.ParseStartDate(input.StartDate); err != nil {
+		return item, &models.InvalidParameterError{ParamName: "startDate"}
+	}
+	if input.FinishDate != nil {
+		if item.FinishDate, err = models.ParseFinishDate(*input.FinishDate); err != nil {
+			return item, &models.InvalidParameterError{ParamName: "finishDate"}
+		}
+	}
+	if input.BillingDay != nil {
+		item.BillingDay = int(*input.BillingDay)
+	}
+	return item, nil
+}
+
+// sumMode converts the SumMode enum into the api name of the mode
+func sumMode(value string) (models.SumMode, error) {
+	mode, err := models.ParseSumMode(strings.ToLower(value))
+	if err != nil {
+		return "", &models.InvalidParameterError{ParamName: "mode"}
+	}
+	return mode, nil
+}
+
+// checkUserId rejects user ids that are not uuids like the http handlers do, nil means no filter
+func checkUserId(userId *string) error {
+	if userId != nil && uuid.Validate(*userId) != nil {
+		return &models.InvalidParameterError{ParamName: "userId"}
+	}
+	return nil
+}
@@ -0,0 +1,454 @@
+package graphqlapi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+type subscriptionResolver struct {
+	item models.Subscription
+}
+
+func (s *subscriptionResolver) Id() int32           { return s.item.Id }
+func (s *subscriptionResolver) ServiceName() string { return s.item.ServiceName }
+func (s *subscriptionResolver) Price() int32        { return int32(s.item.Price) }
+func (s *subscriptionResolver) UserId() string      { return s.item.UserId }
+func (s *subscriptionResolver) StartDate() string {
+	return models.FormatDate(s.item.StartDate)
+}
+
+func (s *subscriptionResolver) FinishDate() *string {
+	if !s.item.FinishDate.Valid {
+		return nil
+	}
+	finishDate := models.FormatDate(s.item.FinishDate.Time)
+	return &finishDate
+}
+
+func (s *subscriptionResolver) BillingDay() int32  { return int32(s.item.BillingDay) }
+func (s *subscriptionResolver) TrialDays() int32   { return int32(s.item.TrialDays) }
+func (s *subscriptionResolver) TrialPrice() int32  { return int32(s.item.TrialPrice) }
+func (s *subscriptionResolver) IntroMonths() int32 { return int32(s.item.IntroMonths) }
+func (s *subscriptionResolver) IntroPrice() int32  { return int32(s.item.IntroPrice) }
+
+func (s *subscriptionResolver) TrialEnd() *string {
+	trialEnd, ok := s.item.TrialEnd()
+	if !ok {
+		return nil
+	}
+	formatted := models.FormatDate(trialEnd)
+	return &formatted
+}
+
+func (s *subscriptionResolver) Pauses() []*pauseResolver {
+	pauses := make([]*pauseResolver, 0, len(s.item.Pauses))
+	for _, pause := range s.item.Pauses {
+		pauses = append(pauses, &pauseResolver{pause})
+	}
+	return pauses
+}
+
+func (s *subscriptionResolver) ServiceId() *int32 { return s.item.ServiceId }
+
+func (s *subscriptionResolver) Tags() []string {
+	if s.item.Tags == nil {
+		return []string{}
+	}
+	return s.item.Tags
+}
+
+func (s *subscriptionResolver) Discounts() []*discountResolver {
+	discounts := make([]*discountResolver, 0, len(s.item.Discounts))
+	for _, discount := range s.item.Discounts {
+		discounts = append(discounts, &discountResolver{discount})
+	}
+	return discounts
+}
+
+func (s *subscriptionResolver) User() *userResolver {
+	return &userResolver{s.item.UserId}
+}
+
+func (s *subscriptionResolver) Service() *serviceResolver {
+	return &serviceResolver{name: s.item.ServiceName}
+}
+
+type pauseResolver struct {
+	pause models.Pause
+}
+
+func (p *pauseResolver) Id() int32             { return p.pause.Id }
+func (p *pauseResolver) SubscriptionId() int32 { return p.pause.SubscriptionId }
+func (p *pauseResolver) StartDate() string {
+	return models.FormatDate(p.pause.StartDate)
+}
+
+func (p *pauseResolver) FinishDate() *string {
+	if !p.pause.FinishDate.Valid {
+		return nil
+	}
+	finishDate := models.FormatDate(p.pause.FinishDate.Time)
+	return &finishDate
+}
+
+func (s *subscriptionResolver) PriceChanges() []*priceChangeResolver {
+	changes := make([]*priceChangeResolver, 0, len(s.item.PriceChanges))
+	for _, change := range s.item.PriceChanges {
+		changes = append(changes, &priceChangeResolver{change})
+	}
+	return changes
+}
+
+type priceChangeResolver struct {
+	change models.PriceChange
+}
+
+func (p *priceChangeResolver) Id() int32             { return p.change.Id }
+func (p *priceChangeResolver) SubscriptionId() int32 { return p.change.SubscriptionId }
+func (p *priceChangeResolver) EffectiveDate() string {
+	return models.FormatDate(p.change.EffectiveDate)
+}
+func (p *priceChangeResolver) Price() int32 { return int32(p.change.Price) }
+
+type discountResolver struct {
+	discount models.Discount
+}
+
+func (d *discountResolver) Id() int32             { return d.discount.Id }
+func (d *discountResolver) SubscriptionId() int32 { return d.discount.SubscriptionId }
+func (d *discountResolver) Kind() string          { return strings.ToUpper(string(d.discount.Kind)) }
+func (d *discountResolver) Amount() int32         { return int32(d.discount.Amount) }
+func (d *discountResolver) StartMonth() string {
+	return models.FormatDate(d.discount.StartMonth)
+}
+func (d *discountResolver) Months() int32 { return int32(d.discount.Months) }
+func (d *discountResolver) Code() string  { return d.discount.Code }
+
+type pageResolver struct {
+	list *models.SubscriptionListPage
+}
+
+func (p *pageResolver) Items() []*subscriptionResolver { return wrap(p.list.List) }
+func (p *pageResolver) Page() int32                    { return int32(p.list.Page) }
+func (p *pageResolver) PerPage() int32                 { return int32(p.list.PerPage) }
+func (p *pageResolver) Total() int32                   { return int32(p.list.Total) }
+
+type serviceTotalResolver struct {
+	serviceName string
+	totals      models.SpendTotals
+}
+
+func (s *serviceTotalResolver) ServiceName() string { return s.serviceName }
+func (s *serviceTotalResolver) Total() int32        { return int32(s.totals.Net) }
+func (s *serviceTotalResolver) Gross() int32        { return int32(s.totals.Gross) }
+func (s *serviceTotalResolver) Discount() int32     { return int32(s.totals.Discount) }
+
+type spendTotalsResolver struct {
+	totals models.SpendTotals
+}
+
+func (s *spendTotalsResolver) Gross() int32    { return int32(s.totals.Gross) }
+func (s *spendTotalsResolver) Discount() int32 { return int32(s.totals.Discount) }
+func (s *spendTotalsResolver) Net() int32      { return int32(s.totals.Net) }
+
+type spendReportResolver struct {
+	report models.SpendReport
+}
+
+func (s *spendReportResolver) GroupBy() string { return string(s.report.GroupBy) }
+func (s *spendReportResolver) Total() *spendTotalsResolver {
+	return &spendTotalsResolver{s.report.Total}
+}
+
+func (s *spendReportResolver) Groups() []*spendGroupResolver {
+	groups := make([]*spendGroupResolver, len(s.report.Groups))
+	for i := range s.report.Groups {
+		groups[i] = &spendGroupResolver{s.report.Groups[i]}
+	}
+	return groups
+}
+
+type spendGroupResolver struct {
+	group models.SpendGroup
+}
+
+func (s *spendGroupResolver) Name() string         { return s.group.Name }
+func (s *spendGroupResolver) Subscriptions() int32 { return int32(s.group.Subscriptions) }
+func (s *spendGroupResolver) Gross() int32         { return int32(s.group.Gross) }
+func (s *spendGroupResolver) Discount() int32      { return int32(s.group.Discount) }
+func (s *spendGroupResolver) Net() int32           { return int32(s.group.Net) }
+
+type forecastResolver struct {
+	forecast models.Forecast
+}
+
+func (f *forecastResolver) Months() []*forecastMonthResolver {
+	months := make([]*forecastMonthResolver, len(f.forecast.Months))
+	for i := range f.forecast.Months {
+		months[i] = &forecastMonthResolver{f.forecast.Months[i]}
+	}
+	return months
+}
+
+func (f *forecastResolver) Changes() []*forecastChangeResolver {
+	changes := make([]*forecastChangeResolver, len(f.forecast.Changes))
+	for i := range f.forecast.Changes {
+		changes[i] = &forecastChangeResolver{f.forecast.Changes[i]}
+	}
+	return changes
+}
+
+type forecastMonthResolver struct {
+	month models.ForecastMonth
+}
+
+func (f *forecastMonthResolver) Month() string        { return models.FormatDate(f.month.Month) }
+func (f *forecastMonthResolver) Subscriptions() int32 { return int32(f.month.Subscriptions) }
+func (f *forecastMonthResolver) Gross() int32         { return int32(f.month.Gross) }
+func (f *forecastMonthResolver) Discount() int32      { return int32(f.month.Discount) }
+func (f *forecastMonthResolver) Net() int32           { return int32(f.month.Net) }
+
+type forecastChangeResolver struct {
+	change models.ForecastChange
+}
+
+func (f *forecastChangeResolver) Month() string         { return models.FormatDate(f.change.Month) }
+func (f *forecastChangeResolver) SubscriptionId() int32 { return f.change.SubscriptionId }
+func (f *forecastChangeResolver) ServiceName() string   { return f.change.ServiceName }
+func (f *forecastChangeResolver) UserId() string        { return f.change.UserId }
+func (f *forecastChangeResolver) Before() int32         { return int32(f.change.Before) }
+func (f *forecastChangeResolver) After() int32          { return int32(f.change.After) }
+func (f *forecastChangeResolver) Reasons() []string {
+	reasons := make([]string, len(f.change.Reasons))
+	for i, reason := range f.change.Reasons {
+		reasons[i] = string(reason)
+	}
+	return reasons
+}
+
+type comparisonResolver struct {
+	comparison models.Comparison
+}
+
+func (c *comparisonResolver) BaseFrom() string {
+	return models.FormatDate(c.comparison.Base.From)
+}
+func (c *comparisonResolver) BaseTo() string { return models.FormatDate(c.comparison.Base.To) }
+func (c *comparisonResolver) From() string {
+	return models.FormatDate(c.comparison.Current.From)
+}
+func (c *comparisonResolver) To() string { return models.FormatDate(c.comparison.Current.To) }
+func (c *comparisonResolver) BaseTotals() *spendTotalsResolver {
+	return &spendTotalsResolver{c.comparison.BaseTotals}
+}
+func (c *comparisonResolver) CurrentTotals() *spendTotalsResolver {
+	return &spendTotalsResolver{c.comparison.CurrentTotals}
+}
+func (c *comparisonResolver) Delta() *spendTotalsResolver {
+	return &spendTotalsResolver{c.comparison.Delta}
+}
+func (c *comparisonResolver) Percent() *float64 { return c.comparison.Percent }
+
+func (c *comparisonResolver) Attribution() []*comparisonGroupResolver {
+	groups := make([]*comparisonGroupResolver, len(c.comparison.Attribution))
+	for i := range c.comparison.Attribution {
+		groups[i] = &comparisonGroupResolver{c.comparison.Attribution[i]}
+	}
+	return groups
+}
+
+func (c *comparisonResolver) Subscriptions() []*subscriptionDeltaResolver {
+	deltas := make([]*subscriptionDeltaResolver, len(c.comparison.Subscriptions))
+	for i := range c.comparison.Subscriptions {
+		deltas[i] = &subscriptionDeltaResolver{c.comparison.Subscriptions[i]}
+	}
+	return deltas
+}
+
+type comparisonGroupResolver struct {
+	group models.ComparisonGroup
+}
+
+func (c *comparisonGroupResolver) Kind() string         { return string(c.group.Kind) }
+func (c *comparisonGroupResolver) Subscriptions() int32 { return int32(c.group.Subscriptions) }
+func (c *comparisonGroupResolver) Base() int32          { return int32(c.group.Base) }
+func (c *comparisonGroupResolver) Current() int32       { return int32(c.group.Current) }
+func (c *comparisonGroupResolver) Delta() int32         { return int32(c.group.Delta) }
+
+type subscriptionDeltaResolver struct {
+	delta models.SubscriptionDelta
+}
+
+func (s *subscriptionDeltaResolver) SubscriptionId() int32 { return s.delta.SubscriptionId }
+func (s *subscriptionDeltaResolver) ServiceName() string   { return s.delta.ServiceName }
+func (s *subscriptionDeltaResolver) UserId() string        { return s.delta.UserId }
+func (s *subscriptionDeltaResolver) Kind() string          { return string(s.delta.Kind) }
+func (s *subscriptionDeltaResolver) Base() int32           { return int32(s.delta.Base) }
+func (s *subscriptionDeltaResolver) Current() int32        { return int32(s.delta.Current) }
+func (s *subscriptionDeltaResolver) Delta() int32          { return int32(s.delta.Delta) }
+
+// userResolver fields are loaded lazily through the request loaders
+type userResolver struct {
+	id string
+}
+
+func (u *userResolver) Id() string { return u.id }
+
+func (u *userResolver) ActiveSubscriptions(ctx context.Context) (int32, error) {
+	summary, err := loadersFrom(ctx).userSummaries.Load(ctx, u.id)()
+	if err != nil {
+		return 0, apiError(err)
+	}
+	return int32(summary.Active), nil
+}
+
+func (u *userResolver) MonthlySpend(ctx context.Context) (int32, error) {
+	summary, err := loadersFrom(ctx).userSummaries.Load(ctx, u.id)()
+	if err != nil {
+		return 0, apiError(err)
+	}
+	return int32(summary.MonthlySpend), nil
+}
+
+func (u *userResolver) Subscriptions(ctx context.Context) ([]*subscriptionResolver, error) {
+	list, err := loadersFrom(ctx).userSubscriptions.Load(ctx, u.id)()
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return wrap(list), nil
+}
+
+// serviceResolver loads the catalog entry by name unless it is already known
+type serviceResolver struct {
+	name  string
+	entry *models.Service
+}
+
+func (s *serviceResolver) Name() string { return s.name }
+
+func (s *serviceResolver) catalogEntry(ctx context.Context) (models.Service, error) {
+	if s.entry != nil {
+		return *s.entry, nil
+	}
+	entry, err := loadersFrom(ctx).catalog.Load(ctx, s.name)()
+	if err != nil {
+		return entry, apiError(err)
+	}
+	s.entry = &entry
+	return entry, nil
+}
+
+func (s *serviceResolver) Id(ctx context.Context) (*int32, error) {
+	entry, err := s.catalogEntry(ctx)
+	if err != nil || entry.Id == 0 {
+		return nil, err
+	}
+	return &entry.Id, nil
+}
+
+func (s *serviceResolver) Aliases(ctx context.Context) ([]string, error) {
+	entry, err := s.catalogEntry(ctx)
+	if entry.Aliases == nil {
+		entry.Aliases = []string{}
+	}
+	return entry.Aliases, err
+}
+
+func (s *serviceResolver) Category(ctx context.Context) (string, error) {
+	entry, err := s.catalogEntry(ctx)
+	return entry.Category, err
+}
+
+func (s *serviceResolver) VendorUrl(ctx context.Context) (string, error) {
+	entry, err := s.catalogEntry(ctx)
+	return entry.VendorURL, err
+}
+
+func (s *serviceResolver) Plans(ctx context.Context) ([]*planResolver, error) {
+	entry, err := s.catalogEntry(ctx)
+	plans := make([]*planResolver, 0, len(entry.Plans))
+	for _, plan := range entry.Plans {
+		plans = append(plans, &planResolver{plan})
+	}
+	return plans, err
+}
+
+func (s *serviceResolver) ActiveSubscriptions(ctx context.Context) (int32, error) {
+	summary, err := loadersFrom(ctx).serviceSummaries.Load(ctx, s.name)()
+	if err != nil {
+		return 0, apiError(err)
+	}
+	return int32(summary.Active), nil
+}
+
+func (s *serviceResolver) MonthlySpend(ctx context.Context) (int32, error) {
+	summary, err := loadersFrom(ctx).serviceSummaries.Load(ctx, s.name)()
+	if err != nil {
+		return 0, apiError(err)
+	}
+	return int32(summary.MonthlySpend), nil
+}
+
+type planResolver struct {
+	plan models.Plan
+}
+
+func (p *planResolver) Name() string { return p.plan.Name }
+func (p *planResolver) Price() int32 { return int32(p.plan.Price) }
+
+type duplicateGroupResolver struct {
+	group models.DuplicateGroup
+}
+
+func (d *duplicateGroupResolver) UserId() string      { return d.group.UserId }
+func (d *duplicateGroupResolver) ServiceName() string { return d.group.ServiceName }
+func (d *duplicateGroupResolver) Subscriptions() []*subscriptionResolver {
+	return wrap(d.group.Subscriptions)
+}
+
+func (d *duplicateGroupResolver) Overlaps() []*overlapResolver {
+	overlaps := make([]*overlapResolver, len(d.group.Overlaps))
+	for i := range d.group.Overlaps {
+		overlaps[i] = &overlapResolver{d.group.Overlaps[i]}
+	}
+	return overlaps
+}
+
+type overlapResolver struct {
+	overlap models.Overlap
+}
+
+func (o *overlapResolver) SubscriptionIds() []int32 { return o.overlap.SubscriptionIds[:] }
+func (o *overlapResolver) Match() string            { return string(o.overlap.Match) }
+func (o *overlapResolver) From() string             { return models.FormatDate(o.overlap.From) }
+
+func (o *overlapResolver) To() *string {
+	if !o.overlap.To.Valid {
+		return nil
+	}
+	to := models.FormatDate(o.overlap.To.Time)
+	return &to
+}
+
+type mergedRecordResolver struct {
+	record models.MergedRecord
+}
+
+func (m *mergedRecordResolver) Id() int32             { return m.record.Id }
+func (m *mergedRecordResolver) SubscriptionId() int32 { return m.record.SubscriptionId }
+func (m *mergedRecordResolver) MergedId() int32       { return m.record.MergedId }
+func (m *mergedRecordResolver) Record() *subscriptionResolver {
+	return &subscriptionResolver{m.record.Record}
+}
+func (m *mergedRecordResolver) MergedAt() string { return m.record.MergedAt.Format(time.RFC3339) }
+
+func wrap(list []models.Subscription) []*subscriptionResolver {
+	result := make([]*subscriptionResolver, len(list))
+	for i := range list {
+		result[i] = &subscriptionResolver{list[i]}
+	}
+	return result
+}
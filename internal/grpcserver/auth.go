@@ -0,0 +1,60 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/zakharova-e/subscriptions-info/internal/auth"
+)
+
+const (
+	// reflectionService stays public so that grpcurl can list services without a key
+	reflectionService = "/grpc.reflection."
+	// healthService stays public like the http health endpoints
+	healthService = "/grpc.health.v1.Health/"
+)
+
+// keyFromMetadata accepts the same "authorization: Bearer <key>" and "x-api-key" as the http api
+func keyFromMetadata(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if bearer, ok := strings.CutPrefix(value, "Bearer "); ok {
+			return strings.TrimSpace(bearer)
+		}
+	}
+	if values := md.Get(strings.ToLower(auth.APIKeyHeader)); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func authorize(ctx context.Context, keys *auth.Keys, method string) error {
+	if !keys.Enabled() || strings.HasPrefix(method, reflectionService) || strings.HasPrefix(method, healthService) ||
+		keys.Valid(keyFromMetadata(ctx)) {
+		return nil
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid api key")
+}
+
+func unaryAuth(keys *auth.Keys) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authorize(ctx, keys, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, request)
+	}
+}
+
+func streamAuth(keys *auth.Keys) grpc.StreamServerInterceptor {
+	return func(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(stream.Context(), keys, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(server, stream)
+	}
+}
@@ -0,0 +1,107 @@
+package grpcserver_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	subscriptionsv1 "github.com/zakharova-e/subscriptions-info/api/subscriptions/v1"
+	"github.com/zakharova-e/subscriptions-info/internal/auth"
+	"github.com/zakharova-e/subscriptions-info/internal/grpcserver"
+)
+
+// dial serves grpcserver.New over an in-memory listener
+func dial(t *testing.T, keys *auth.Keys) *grpc.ClientConn {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpcserver.New(keys)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestAuth(t *testing.T) {
+	conn := dial(t, auth.NewKeys([]string{"secret"}))
+	subscriptionsClient := subscriptionsv1.NewSubscriptionServiceClient(conn)
+	// a read of id 0 is rejected before the database, so passing the interceptor shows up as InvalidArgument
+	read := func(ctx context.Context) error {
+		_, err := subscriptionsClient.Read(ctx, &subscriptionsv1.ReadRequest{Id: 0})
+		return err
+	}
+	list := func(ctx context.Context) error {
+		stream, err := subscriptionsClient.List(ctx, &subscriptionsv1.ListRequest{})
+		if err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	}
+	health := func(ctx context.Context) error {
+		response, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		if err == nil && response.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("health status = %v, want SERVING", response.GetStatus())
+		}
+		return err
+	}
+	reflection := func(ctx context.Context) error {
+		stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+		if err != nil {
+			return err
+		}
+		request := &reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}}
+		if err := stream.Send(request); err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	}
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context) error
+		metadata []string
+		want     codes.Code
+	}{
+		{"test unary without key", read, nil, codes.Unauthenticated},
+		{"test unary with wrong bearer key", read, []string{"authorization", "Bearer wrong"}, codes.Unauthenticated},
+		{"test unary with empty bearer key", read, []string{"authorization", "Bearer "}, codes.Unauthenticated},
+		{"test unary with wrong x-api-key", read, []string{"x-api-key", "wrong"}, codes.Unauthenticated},
+		{"test unary with bearer key", read, []string{"authorization", "Bearer secret"}, codes.InvalidArgument},
+		{"test unary with x-api-key", read, []string{"x-api-key", "secret"}, codes.InvalidArgument},
+		{"test stream without key", list, nil, codes.Unauthenticated},
+		{"test stream with wrong key", list, []string{"x-api-key", "wrong"}, codes.Unauthenticated},
+		{"test health without key", health, nil, codes.OK},
+		{"test reflection without key", reflection, nil, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(t.Context(), tt.metadata...)
+			if got := status.Code(tt.call(ctx)); got != tt.want {
+				t.Errorf("code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuth_Disabled(t *testing.T) {
+	client := subscriptionsv1.NewSubscriptionServiceClient(dial(t, auth.NewKeys(nil)))
+	_, err := client.Read(t.Context(), &subscriptionsv1.ReadRequest{Id: 0})
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("Read() without keys configured code = %v, want %v", got, codes.InvalidArgument)
+	}
+}
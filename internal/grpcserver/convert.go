@@ -0,0 +1,209 @@
+package grpcserver
+
+import (
+	subscriptionsv1 "github.com/zakharova-e/subscriptions-info/api/subscriptions/v1"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func toProto(item models.Subscription) *subscriptionsv1.Subscription {
+	message := &subscriptionsv1.Subscription{
+		Id:          item.Id,
+		ServiceName: item.ServiceName,
+		Price:       int64(item.Price),
+		UserId:      item.UserId,
+		StartDate:   models.FormatDate(item.StartDate),
+		BillingDay:  int32(item.BillingDay),
+		TrialDays:   int32(item.TrialDays),
+		TrialPrice:  int64(item.TrialPrice),
+		IntroMonths: int32(item.IntroMonths),
+		IntroPrice:  int64(item.IntroPrice),
+		Tags:        item.Tags,
+	}
+	if item.FinishDate.Valid {
+		message.FinishDate = models.FormatDate(item.FinishDate.Time)
+	}
+	if item.ServiceId != nil {
+		message.ServiceId = *item.ServiceId
+	}
+	for _, pause := range item.Pauses {
+		message.Pauses = append(message.Pauses, pauseToProto(pause))
+	}
+	for _, discount := range item.Discounts {
+		message.Discounts = append(message.Discounts, discountToProto(discount))
+	}
+	for _, change := range item.PriceChanges {
+		message.PriceChanges = append(message.PriceChanges, priceChangeToProto(change))
+	}
+	return message
+}
+
+func pauseToProto(pause models.Pause) *subscriptionsv1.Pause {
+	message := &subscriptionsv1.Pause{
+		Id:             pause.Id,
+		SubscriptionId: pause.SubscriptionId,
+		StartDate:      models.FormatDate(pause.StartDate),
+	}
+	if pause.FinishDate.Valid {
+		message.FinishDate = models.FormatDate(pause.FinishDate.Time)
+	}
+	return message
+}
+
+func pauseFromProto(message *subscriptionsv1.Pause) (models.Pause, error) {
+	if message == nil {
+		return models.Pause{}, &models.InvalidParameterError{ParamName: "pause"}
+	}
+	pause := models.Pause{Id: message.GetId(), SubscriptionId: message.GetSubscriptionId()}
+	var err error
+	if pause.StartDate, err = models.ParseStartDate(message.GetStartDate()); err != nil {
+		return pause, &models.InvalidParameterError{ParamName: "start_date"}
+	}
+	if pause.FinishDate, err = models.ParseFinishDate(message.GetFinishDate()); err != nil {
+		return pause, &models.InvalidParameterError{ParamName: "finish_date"}
+	}
+	return pause, nil
+}
+
+func discountToProto(discount models.Discount) *subscriptionsv1.Discount {
+	return &subscriptionsv1.Discount{
+		Id:             discount.Id,
+		SubscriptionId: discount.SubscriptionId,
+		Kind:           string(discount.Kind),
+		Amount:         int64(discount.Amount),
+		StartMonth:     models.FormatDate(discount.StartMonth),
+		Months:         int32(discount.Months),
+		Code:           discount.Code,
+	}
+}
+
+func discountFromProto(message *subscriptionsv1.Discount) (models.Discount, error) {
+	if message == nil {
+		return models.Discount{}, &models.InvalidParameterError{ParamName: "discount"}
+	}
+	discount := models.Discount{
+		Id:             message.GetId(),
+		SubscriptionId: message.GetSubscriptionId(),
+		Kind:           models.DiscountKind(message.GetKind()),
+		Amount:         int(message.GetAmount()),
+		Months:         int(message.GetMonths()),
+		Code:           message.GetCode(),
+	}
+	startMonth, err := models.ParseStartDate(message.GetStartMonth())
+	if err != nil {
+		return discount, &models.InvalidParameterError{ParamName: "start_month"}
+	}
+	discount.StartMonth = models.MonthOf(startMonth)
+	return discount, nil
+}
+
+func priceChangeToProto(change models.PriceChange) *subscriptionsv1.PriceChange {
+	return &subscriptionsv1.PriceChange{
+		Id:             change.Id,
+		SubscriptionId: change.SubscriptionId,
+		EffectiveDate:  models.FormatDate(change.EffectiveDate),
+		Price:          int64(change.Price),
+	}
+}
+
+func priceChangeFromProto(message *subscriptionsv1.PriceChange) (models.PriceChange, error) {
+	if message == nil {
+		return models.PriceChange{}, &models.InvalidParameterError{ParamName: "price_change"}
+	}
+	change := models.PriceChange{Id: message.GetId(), SubscriptionId: message.GetSubscriptionId(), Price: int(message.GetPrice())}
+	var err error
+	if change.EffectiveDate, err = models.ParseStartDate(message.GetEffectiveDate()); err != nil {
+		return change, &models.InvalidParameterError{ParamName: "effective_date"}
+	}
+	return change, nil
+}
+
+func forecastToProto(forecast models.Forecast) *subscriptionsv1.ForecastResponse {
+	message := &subscriptionsv1.ForecastResponse{}
+	for _, month := range forecast.Months {
+		message.Months = append(message.Months, &subscriptionsv1.ForecastMonth{Month: models.FormatDate(month.Month),
+			Subscriptions: int32(month.Subscriptions), Gross: int64(month.Gross), Discount: int64(month.Discount), Net: int64(month.Net)})
+	}
+	for _, change := range forecast.Changes {
+		reasons := make([]string, len(change.Reasons))
+		for i, reason := range change.Reasons {
+			reasons[i] = string(reason)
+		}
+		message.Changes = append(message.Changes, &subscriptionsv1.ForecastChange{Month: models.FormatDate(change.Month),
+			SubscriptionId: change.SubscriptionId, ServiceName: change.ServiceName, UserId: change.UserId, Reasons: reasons,
+			Before: int64(change.Before), After: int64(change.After)})
+	}
+	return message
+}
+
+func totalsToProto(totals models.SpendTotals) *subscriptionsv1.ComparisonTotals {
+	return &subscriptionsv1.ComparisonTotals{Gross: int64(totals.Gross), Discount: int64(totals.Discount), Net: int64(totals.Net)}
+}
+
+func comparisonToProto(comparison models.Comparison) *subscriptionsv1.CompareResponse {
+	message := &subscriptionsv1.CompareResponse{
+		BaseFrom:      models.FormatDate(comparison.Base.From),
+		BaseTo:        models.FormatDate(comparison.Base.To),
+		FilterFrom:    models.FormatDate(comparison.Current.From),
+		FilterTo:      models.FormatDate(comparison.Current.To),
+		BaseTotals:    totalsToProto(comparison.BaseTotals),
+		CurrentTotals: totalsToProto(comparison.CurrentTotals),
+		Delta:         totalsToProto(comparison.Delta),
+		Percent:       comparison.Percent,
+	}
+	for _, group := range comparison.Attribution {
+		message.Attribution = append(message.Attribution, &subscriptionsv1.ComparisonGroup{Kind: string(group.Kind),
+			Subscriptions: int32(group.Subscriptions), Base: int64(group.Base), Current: int64(group.Current), Delta: int64(group.Delta)})
+	}
+	for _, delta := range comparison.Subscriptions {
+		message.Subscriptions = append(message.Subscriptions, &subscriptionsv1.SubscriptionDelta{SubscriptionId: delta.SubscriptionId,
+			ServiceName: delta.ServiceName, UserId: delta.UserId, Kind: string(delta.Kind),
+			Base: int64(delta.Base), Current: int64(delta.Current), Delta: int64(delta.Delta)})
+	}
+	return message
+}
+
+func duplicatesToProto(groups []models.DuplicateGroup) *subscriptionsv1.DuplicatesResponse {
+	message := &subscriptionsv1.DuplicatesResponse{}
+	for _, group := range groups {
+		result := &subscriptionsv1.DuplicateGroup{UserId: group.UserId, ServiceName: group.ServiceName}
+		for _, item := range group.Subscriptions {
+			result.Subscriptions = append(result.Subscriptions, toProto(item))
+		}
+		for _, overlap := range group.Overlaps {
+			converted := &subscriptionsv1.Overlap{SubscriptionIds: overlap.SubscriptionIds[:], Match: string(overlap.Match),
+				From: models.FormatDate(overlap.From)}
+			if overlap.To.Valid {
+				converted.To = models.FormatDate(overlap.To.Time)
+			}
+			result.Overlaps = append(result.Overlaps, converted)
+		}
+		message.Groups = append(message.Groups, result)
+	}
+	return message
+}
+
+func fromProto(message *subscriptionsv1.Subscription) (models.Subscription, error) {
+	if message == nil {
+		return models.Subscription{}, &models.InvalidParameterError{ParamName: "subscription"}
+	}
+	item := models.Subscription{
+		Id:          message.GetId(),
+		ServiceName: message.GetServiceName(),
+		Price:       int(message.GetPrice()),
+		UserId:      message.GetUserId(),
+		BillingDay:  int(message.GetBillingDay()),
+		TrialDays:   int(message.GetTrialDays()),
+		TrialPrice:  int(message.GetTrialPrice()),
+		IntroMonths: int(message.GetIntroMonths()),
+		IntroPrice:  int(message.GetIntroPrice()),
+		Tags:        message.GetTags(),
+	}
+	var err error
+	if item.StartDate, err = models.ParseStartDate(message.GetStartDate()); err != nil {
+		return item, &models.InvalidParameterError{ParamName: "start_date"}
+	}
+	if item.FinishDate, err = models.ParseFinishDate(message.GetFinishDate()); err != nil {
+		return item, &models.InvalidParameterError{ParamName: "finish_date"}
+	}
+	return item, nil
+}
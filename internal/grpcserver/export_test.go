@@ -0,0 +1,7 @@
+package grpcserver
+
+// unexported helpers used by the tests of the grpcserver_test package
+var (
+	StatusError = statusError
+	SendPages   = sendPages
+)
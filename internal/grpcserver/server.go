@@ -0,0 +1,414 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	subscriptionsv1 "github.com/zakharova-e/subscriptions-info/api/subscriptions/v1"
+	"github.com/zakharova-e/subscriptions-info/internal/auth"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+// Server implements SubscriptionService on top of the subscriptions repository
+type Server struct {
+	subscriptionsv1.UnimplementedSubscriptionServiceServer
+}
+
+// New creates the grpc server with reflection, health checks, tracing and the same api keys as the http api
+func New(keys *auth.Keys) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnaryInterceptor(unaryAuth(keys)),
+		grpc.StreamInterceptor(streamAuth(keys)),
+	)
+	subscriptionsv1.RegisterSubscriptionServiceServer(server, &Server{})
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+	return server
+}
+
+func (s *Server) Create(ctx context.Context, request *subscriptionsv1.CreateRequest) (*subscriptionsv1.CreateResponse, error) {
+	item, err := fromProto(request.GetSubscription())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := subscriptions.SubscriptionCreate(ctx, item)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	item.Id = *id
+	return &subscriptionsv1.CreateResponse{Id: *id, DuplicateIds: duplicateIds(ctx, item)}, nil
+}
+
+// duplicateIds returns the overlapping subscriptions of the written item when the server checks for duplicates,
+// the write has succeeded already, so a failed check is only logged
+func duplicateIds(ctx context.Context, item models.Subscription) []int32 {
+	if !models.DuplicateWarnings() {
+		return nil
+	}
+	overlaps, err := subscriptions.SubscriptionDuplicatesOf(ctx, item)
+	if err != nil {
+		slog.WarnContext(ctx, "duplicate check failed", "id", item.Id, "error", err)
+		return nil
+	}
+	ids := make([]int32, len(overlaps))
+	for i, overlap := range overlaps {
+		ids[i] = overlap.SubscriptionIds[1]
+	}
+	return ids
+}
+
+func (s *Server) Read(ctx context.Context, request *subscriptionsv1.ReadRequest) (*subscriptionsv1.ReadResponse, error) {
+	item, err := subscriptions.SubscriptionRead(ctx, request.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.ReadResponse{Subscription: toProto(*item)}, nil
+}
+
+func (s *Server) Update(ctx context.Context, request *subscriptionsv1.UpdateRequest) (*subscriptionsv1.UpdateResponse, error) {
+	item, err := fromProto(request.GetSubscription())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if err := subscriptions.SubscriptionUpdate(ctx, item); err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.UpdateResponse{DuplicateIds: duplicateIds(ctx, item)}, nil
+}
+
+func (s *Server) Delete(ctx context.Context, request *subscriptionsv1.DeleteRequest) (*subscriptionsv1.DeleteResponse, error) {
+	if err := subscriptions.SubscriptionDelete(ctx, request.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.DeleteResponse{}, nil
+}
+
+// List sends the pages of the repository list one subscription at a time
+func (s *Server) List(request *subscriptionsv1.ListRequest, stream grpc.ServerStreamingServer[subscriptionsv1.ListResponse]) error {
+	userId, err := userIdFilter(request.GetUserId())
+	if err != nil {
+		return err
+	}
+	filter := models.ListFilter{UserId: userId}
+	if serviceName := request.GetServiceName(); serviceName != "" {
+		filter.ServiceName = &serviceName
+	}
+	return sendPages(func(page int) (*models.SubscriptionListPage, error) {
+		return subscriptions.SubscriptionList(stream.Context(), page, filter)
+	}, func(item models.Subscription) error {
+		return stream.Send(&subscriptionsv1.ListResponse{Subscription: toProto(item)})
+	})
+}
+
+// sendPages fetches pages from the first one until the total is reached or a page is empty
+func sendPages(fetch func(page int) (*models.SubscriptionListPage, error), send func(item models.Subscription) error) error {
+	for page := 1; ; page++ {
+		list, err := fetch(page)
+		if err != nil {
+			return statusError(err)
+		}
+		for _, item := range list.List {
+			if err := send(item); err != nil {
+				return err
+			}
+		}
+		if len(list.List) == 0 || page*list.PerPage >= list.Total {
+			return nil
+		}
+	}
+}
+
+func (s *Server) Sum(ctx context.Context, request *subscriptionsv1.SumRequest) (*subscriptionsv1.SumResponse, error) {
+	filterFrom, filterTo, err := models.ParsePeriod(request.GetFilterFrom(), request.GetFilterTo())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	userId, err := userIdFilter(request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	var serviceName *string
+	if value := request.GetServiceName(); value != "" {
+		serviceName = &value
+	}
+	mode, err := models.ParseSumMode(request.GetMode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "mode "+err.Error())
+	}
+	sum, err := subscriptions.SubscriptionSum(ctx, filterFrom, filterTo, userId, serviceName, mode)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.SumResponse{Sum: int64(sum.Net), Gross: int64(sum.Gross), Discount: int64(sum.Discount)}, nil
+}
+
+func (s *Server) Report(ctx context.Context, request *subscriptionsv1.ReportRequest) (*subscriptionsv1.ReportResponse, error) {
+	filterFrom, filterTo, err := models.ParsePeriod(request.GetFilterFrom(), request.GetFilterTo())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	userId, err := userIdFilter(request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	var serviceName *string
+	if value := request.GetServiceName(); value != "" {
+		serviceName = &value
+	}
+	mode, err := models.ParseSumMode(request.GetMode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "mode "+err.Error())
+	}
+	groupBy, err := models.ParseReportGroup(request.GetGroupBy())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "group_by "+err.Error())
+	}
+	filter, err := models.ParseTagFilter(request.GetTags())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "tags "+err.Error())
+	}
+	report, err := subscriptions.SubscriptionSpendReport(ctx, filterFrom, filterTo, userId, serviceName, mode, groupBy, filter)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	result := &subscriptionsv1.ReportResponse{GroupBy: string(report.GroupBy),
+		Gross: int64(report.Total.Gross), Discount: int64(report.Total.Discount), Net: int64(report.Total.Net)}
+	for _, group := range report.Groups {
+		result.Groups = append(result.Groups, &subscriptionsv1.ReportGroup{Name: group.Name, Subscriptions: int32(group.Subscriptions),
+			Gross: int64(group.Gross), Discount: int64(group.Discount), Net: int64(group.Net)})
+	}
+	return result, nil
+}
+
+func (s *Server) Forecast(ctx context.Context, request *subscriptionsv1.ForecastRequest) (*subscriptionsv1.ForecastResponse, error) {
+	from := models.Today()
+	if value := request.GetFrom(); value != "" {
+		var err error
+		if from, err = models.ParseStartDate(value); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "from "+err.Error())
+		}
+	}
+	months := int(request.GetMonths())
+	if months == 0 {
+		months = 12
+	}
+	userId, err := userIdFilter(request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	var serviceName *string
+	if value := request.GetServiceName(); value != "" {
+		serviceName = &value
+	}
+	forecast, err := subscriptions.SubscriptionForecast(ctx, from, months, userId, serviceName)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return forecastToProto(*forecast), nil
+}
+
+func (s *Server) Compare(ctx context.Context, request *subscriptionsv1.CompareRequest) (*subscriptionsv1.CompareResponse, error) {
+	filterFrom, filterTo, err := models.ParsePeriod(request.GetFilterFrom(), request.GetFilterTo())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	current := models.Period{From: filterFrom, To: filterTo}
+	var base models.Period
+	switch {
+	case request.GetBaseFrom() != "" || request.GetBaseTo() != "":
+		if base.From, base.To, err = models.ParsePeriod(request.GetBaseFrom(), request.GetBaseTo()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "base_from and base_to must be set together and be dates")
+		}
+	default:
+		against, err := models.ParseCompareAgainst(request.GetAgainst())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "against "+err.Error())
+		}
+		base.From, base.To = models.BasePeriod(filterFrom, filterTo, against)
+	}
+	userId, err := userIdFilter(request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	var serviceName *string
+	if value := request.GetServiceName(); value != "" {
+		serviceName = &value
+	}
+	mode, err := models.ParseSumMode(request.GetMode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "mode "+err.Error())
+	}
+	comparison, err := subscriptions.SubscriptionCompare(ctx, base, current, userId, serviceName, mode)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return comparisonToProto(*comparison), nil
+}
+
+func (s *Server) TrialsEnding(ctx context.Context, request *subscriptionsv1.TrialsEndingRequest) (*subscriptionsv1.TrialsEndingResponse, error) {
+	days := models.TrialWarningDays()
+	if request.Days != nil {
+		days = int(request.GetDays())
+	}
+	if days < 0 {
+		return nil, status.Error(codes.InvalidArgument, "days must not be negative")
+	}
+	items, err := subscriptions.SubscriptionTrialsEnding(ctx, days)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	result := &subscriptionsv1.TrialsEndingResponse{}
+	for _, item := range items {
+		result.Subscriptions = append(result.Subscriptions, toProto(item))
+	}
+	return result, nil
+}
+
+func (s *Server) Pause(ctx context.Context, request *subscriptionsv1.PauseRequest) (*subscriptionsv1.PauseResponse, error) {
+	pause, err := pauseFromProto(request.GetPause())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := subscriptions.PauseCreate(ctx, pause)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.PauseResponse{Id: *id}, nil
+}
+
+func (s *Server) Resume(ctx context.Context, request *subscriptionsv1.ResumeRequest) (*subscriptionsv1.ResumeResponse, error) {
+	resumeDate, err := models.ParseStartDate(request.GetDate())
+	if err != nil {
+		return nil, statusError(&models.InvalidParameterError{ParamName: "date"})
+	}
+	if err := subscriptions.PauseResume(ctx, request.GetSubscriptionId(), resumeDate); err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.ResumeResponse{}, nil
+}
+
+func (s *Server) DeletePause(ctx context.Context, request *subscriptionsv1.DeletePauseRequest) (*subscriptionsv1.DeletePauseResponse, error) {
+	if err := subscriptions.PauseDelete(ctx, request.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.DeletePauseResponse{}, nil
+}
+
+func (s *Server) AddDiscount(ctx context.Context, request *subscriptionsv1.AddDiscountRequest) (*subscriptionsv1.AddDiscountResponse, error) {
+	discount, err := discountFromProto(request.GetDiscount())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := subscriptions.DiscountCreate(ctx, discount)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.AddDiscountResponse{Id: *id}, nil
+}
+
+func (s *Server) DeleteDiscount(ctx context.Context, request *subscriptionsv1.DeleteDiscountRequest) (*subscriptionsv1.DeleteDiscountResponse, error) {
+	if err := subscriptions.DiscountDelete(ctx, request.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.DeleteDiscountResponse{}, nil
+}
+
+func (s *Server) AddPriceChange(ctx context.Context, request *subscriptionsv1.AddPriceChangeRequest) (*subscriptionsv1.AddPriceChangeResponse, error) {
+	change, err := priceChangeFromProto(request.GetPriceChange())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := subscriptions.PriceChangeCreate(ctx, change)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.AddPriceChangeResponse{Id: *id}, nil
+}
+
+func (s *Server) DeletePriceChange(ctx context.Context, request *subscriptionsv1.DeletePriceChangeRequest) (*subscriptionsv1.DeletePriceChangeResponse, error) {
+	if err := subscriptions.PriceChangeDelete(ctx, request.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.DeletePriceChangeResponse{}, nil
+}
+
+func (s *Server) Duplicates(ctx context.Context, request *subscriptionsv1.DuplicatesRequest) (*subscriptionsv1.DuplicatesResponse, error) {
+	userId, err := userIdFilter(request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	groups, err := subscriptions.SubscriptionDuplicates(ctx, userId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return duplicatesToProto(groups), nil
+}
+
+func (s *Server) Merge(ctx context.Context, request *subscriptionsv1.MergeRequest) (*subscriptionsv1.MergeResponse, error) {
+	merged, err := subscriptions.SubscriptionMerge(ctx, models.MergeRequest{Into: request.GetInto(), Ids: request.GetIds()})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &subscriptionsv1.MergeResponse{Subscription: toProto(*merged)}, nil
+}
+
+func (s *Server) Merges(ctx context.Context, request *subscriptionsv1.MergesRequest) (*subscriptionsv1.MergesResponse, error) {
+	records, err := subscriptions.SubscriptionMerges(ctx, request.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	result := &subscriptionsv1.MergesResponse{}
+	for _, record := range records {
+		result.Records = append(result.Records, &subscriptionsv1.MergedRecord{Id: record.Id, SubscriptionId: record.SubscriptionId,
+			MergedId: record.MergedId, Record: toProto(record.Record), MergedAt: record.MergedAt.UTC().Format(time.RFC3339)})
+	}
+	return result, nil
+}
+
+// statusError maps domain errors to grpc codes the same way ResponseWithError maps them to http statuses
+// userIdFilter checks the optional user_id of a request like the http handlers do, empty means no filter
+func userIdFilter(value string) (*string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if err := uuid.Validate(value); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "user_id must be uuid")
+	}
+	return &value, nil
+}
+
+func statusError(err error) error {
+	var (
+		valErr      *models.ValidationError
+		jsonErr     *models.JsonError
+		paramErr    *models.InvalidParameterError
+		notFoundErr *models.ResourceNotFoundError
+		canceledErr *models.QueryCanceledError
+	)
+	switch {
+	case errors.As(err, &canceledErr) && errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.As(err, &canceledErr):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.As(err, &valErr), errors.As(err, &jsonErr), errors.As(err, &paramErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.As(err, &notFoundErr), errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,161 @@
+package grpcserver_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	subscriptionsv1 "github.com/zakharova-e/subscriptions-info/api/subscriptions/v1"
+	"github.com/zakharova-e/subscriptions-info/internal/auth"
+	"github.com/zakharova-e/subscriptions-info/internal/grpcserver"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"test with validation error", &models.ValidationError{Errors: []error{errors.New("price must be positive")}}, codes.InvalidArgument},
+		{"test with json error", &models.JsonError{Json: "{", Err: errors.New("unexpected end of input")}, codes.InvalidArgument},
+		{"test with invalid parameter", &models.InvalidParameterError{ParamName: "recordId"}, codes.InvalidArgument},
+		{"test with not found error", &models.ResourceNotFoundError{Err: errors.New("subscription 1")}, codes.NotFound},
+		{"test with wrapped not found error", fmt.Errorf("read: %w", &models.ResourceNotFoundError{}), codes.NotFound},
+		{"test with no rows", &models.DatabaseError{Query: "subscription_read", Err: sql.ErrNoRows}, codes.NotFound},
+		{"test with timed out query", &models.QueryCanceledError{Operation: "subscription_list", Err: context.DeadlineExceeded}, codes.DeadlineExceeded},
+		{"test with canceled query", &models.QueryCanceledError{Operation: "subscription_list", Err: context.Canceled}, codes.Unavailable},
+		{"test with database error", &models.DatabaseError{Query: "subscription_list", Err: errors.New("connection refused")}, codes.Internal},
+		{"test with unknown error", errors.New("unexpected"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := grpcserver.StatusError(tt.err)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("StatusError() code = %v, want %v", got, tt.want)
+			}
+			if got := status.Convert(err).Message(); got != tt.err.Error() {
+				t.Errorf("StatusError() message = %q, want %q", got, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestSendPages(t *testing.T) {
+	item := func(id int32) models.Subscription { return models.Subscription{Id: id} }
+	failed := errors.New("stream closed")
+	tests := []struct {
+		name      string
+		pages     []models.SubscriptionListPage
+		fetchErr  error
+		sendErr   error
+		wantIds   []int32
+		wantPages int
+		wantCode  codes.Code
+	}{
+		{"test with empty list", []models.SubscriptionListPage{{PerPage: 2}}, nil, nil, nil, 1, codes.OK},
+		{"test with single page", []models.SubscriptionListPage{{List: []models.Subscription{item(1)}, PerPage: 2, Total: 1}},
+			nil, nil, []int32{1}, 1, codes.OK},
+		{"test with full last page", []models.SubscriptionListPage{
+			{List: []models.Subscription{item(1), item(2)}, PerPage: 2, Total: 4},
+			{List: []models.Subscription{item(3), item(4)}, PerPage: 2, Total: 4},
+		}, nil, nil, []int32{1, 2, 3, 4}, 2, codes.OK},
+		// rows deleted while paging must not make the stream wait for pages that never come
+		{"test with list shrinking while paging", []models.SubscriptionListPage{
+			{List: []models.Subscription{item(1), item(2)}, PerPage: 2, Total: 5},
+			{PerPage: 2, Total: 2},
+		}, nil, nil, []int32{1, 2}, 2, codes.OK},
+		{"test with failing fetch", nil, &models.InvalidParameterError{ParamName: "page"}, nil, nil, 1, codes.InvalidArgument},
+		{"test with failing send", []models.SubscriptionListPage{{List: []models.Subscription{item(1), item(2)}, PerPage: 2, Total: 2}},
+			nil, failed, nil, 1, codes.Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []int32
+			fetched := 0
+			err := grpcserver.SendPages(func(page int) (*models.SubscriptionListPage, error) {
+				fetched++
+				if page != fetched {
+					t.Fatalf("fetched page %d, want %d", page, fetched)
+				}
+				if tt.fetchErr != nil {
+					return nil, tt.fetchErr
+				}
+				list := tt.pages[page-1]
+				list.Page = page
+				return &list, nil
+			}, func(item models.Subscription) error {
+				if tt.sendErr != nil {
+					return tt.sendErr
+				}
+				ids = append(ids, item.Id)
+				return nil
+			})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("SendPages() error = %v, want code %v", err, tt.wantCode)
+			}
+			if tt.sendErr != nil && !errors.Is(err, tt.sendErr) {
+				t.Errorf("SendPages() error = %v, want the send error", err)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIds) || fetched != tt.wantPages {
+				t.Errorf("SendPages() sent %v in %d pages, want %v in %d pages", ids, fetched, tt.wantIds, tt.wantPages)
+			}
+		})
+	}
+}
+
+// TestUserIdValidation sends a user_id that is not a uuid, it is rejected before the database is queried
+func TestUserIdValidation(t *testing.T) {
+	client := subscriptionsv1.NewSubscriptionServiceClient(dial(t, auth.NewKeys(nil)))
+	const userId = "42"
+	tests := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"test list", func(ctx context.Context) error {
+			stream, err := client.List(ctx, &subscriptionsv1.ListRequest{UserId: userId})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		}},
+		{"test sum", func(ctx context.Context) error {
+			_, err := client.Sum(ctx, &subscriptionsv1.SumRequest{FilterFrom: "01-2025", FilterTo: "12-2025", UserId: userId})
+			return err
+		}},
+		{"test report", func(ctx context.Context) error {
+			_, err := client.Report(ctx, &subscriptionsv1.ReportRequest{FilterFrom: "01-2025", FilterTo: "12-2025", GroupBy: "category", UserId: userId})
+			return err
+		}},
+		{"test forecast", func(ctx context.Context) error {
+			_, err := client.Forecast(ctx, &subscriptionsv1.ForecastRequest{UserId: userId})
+			return err
+		}},
+		{"test compare", func(ctx context.Context) error {
+			_, err := client.Compare(ctx, &subscriptionsv1.CompareRequest{FilterFrom: "01-2025", FilterTo: "12-2025", Against: "year", UserId: userId})
+			return err
+		}},
+		{"test duplicates", func(ctx context.Context) error {
+			_, err := client.Duplicates(ctx, &subscriptionsv1.DuplicatesRequest{UserId: userId})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(t.Context())
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Fatalf("code = %v, want %v", got, codes.InvalidArgument)
+			}
+			if got := status.Convert(err).Message(); got != "user_id must be uuid" {
+				t.Errorf("message = %q, want %q", got, "user_id must be uuid")
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const checkTimeout = 3 * time.Second
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check reports a dependency problem by returning an error
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+type CheckResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status      string        `json:"status"`
+	Maintenance bool          `json:"maintenance"`
+	Checks      []CheckResult `json:"checks"`
+}
+
+// Checker keeps readiness checks and the maintenance flag of the service
+type Checker struct {
+	mu          sync.RWMutex
+	checks      []namedCheck
+	maintenance atomic.Bool
+}
+
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+func (c *Checker) AddReadinessCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+func (c *Checker) SetMaintenance(enabled bool) {
+	c.maintenance.Store(enabled)
+}
+
+func (c *Checker) Maintenance() bool {
+	return c.maintenance.Load()
+}
+
+// Readiness runs every check concurrently, the service is ready when all checks pass and maintenance is off
+func (c *Checker) Readiness(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := append([]namedCheck(nil), c.checks...)
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, nc := range checks {
+		wg.Add(1)
+		go func(i int, nc namedCheck) {
+			defer wg.Done()
+			results[i] = CheckResult{Name: nc.name, Status: StatusUp}
+			if err := nc.check(ctx); err != nil {
+				results[i].Status = StatusDown
+				results[i].Error = err.Error()
+			}
+		}(i, nc)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Maintenance: c.Maintenance(), Checks: results}
+	if report.Maintenance {
+		report.Status = StatusDown
+	}
+	for _, r := range results {
+		if r.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
@@ -0,0 +1,78 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+// LivenessHandler godoc
+//
+//	@Summary	process is alive
+//	@Tags		health
+//	@Produce	json
+//	@Success	200	{object}	Report	"alive"
+//	@Router		/healthz [get]
+func (c *Checker) LivenessHandler(response http.ResponseWriter, request *http.Request) {
+	writeReport(response, Report{Status: StatusUp, Maintenance: c.Maintenance(), Checks: []CheckResult{}})
+}
+
+// ReadinessHandler godoc
+//
+//	@Summary	service is ready to accept traffic
+//	@Tags		health
+//	@Produce	json
+//	@Success	200	{object}	Report	"ready"
+//	@Failure	503	{object}	Report	"not ready"
+//	@Router		/readyz [get]
+func (c *Checker) ReadinessHandler(response http.ResponseWriter, request *http.Request) {
+	writeReport(response, c.Readiness(request.Context()))
+}
+
+// MaintenanceHandler godoc
+//
+//	@Summary		maintenance mode toggle
+//	@Description	routed only when the service runs with API_KEYS, the toggle must not be open to anyone
+//	@Tags			health
+//	@Produce		json
+//	@Param			enabled	query		boolean	false	"new maintenance state, required for PUT"
+//	@Success		200		{object}	Report	"current state"
+//	@Failure		400		{string}	string	"error"
+//	@Failure		405		{string}	string	"error"
+//	@Router			/maintenance [get]
+//	@Router			/maintenance [put]
+func (c *Checker) MaintenanceHandler(response http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		enabled, errParam := strconv.ParseBool(request.URL.Query().Get("enabled"))
+		if errParam != nil {
+			err := &models.InvalidParameterError{ParamName: "enabled"}
+			http.Error(response, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c.SetMaintenance(enabled)
+	default:
+		err := &models.MethodNotAllowedError{RequiredMethod: "GET or PUT"}
+		http.Error(response, err.Error(), http.StatusMethodNotAllowed)
+		return
+	}
+	writeReport(response, Report{Status: StatusUp, Maintenance: c.Maintenance(), Checks: []CheckResult{}})
+}
+
+func writeReport(response http.ResponseWriter, report Report) {
+	body, err := json.Marshal(report)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response.Header().Set("Content-Type", "application/json")
+	if report.Status != StatusUp {
+		response.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		response.WriteHeader(http.StatusOK)
+	}
+	_, _ = response.Write(body)
+}
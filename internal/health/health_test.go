@@ -0,0 +1,119 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/health"
+)
+
+func passing(ctx context.Context) error {
+	return nil
+}
+
+func failing(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func TestChecker_Readiness(t *testing.T) {
+	tests := []struct {
+		name        string
+		checks      map[string]health.Check
+		order       []string
+		maintenance bool
+		want        health.Report
+	}{
+		{"test without checks", nil, nil, false,
+			health.Report{Status: health.StatusUp, Checks: []health.CheckResult{}}},
+		{"test with passing checks", map[string]health.Check{"postgres": passing, "replica": passing}, []string{"postgres", "replica"}, false,
+			health.Report{Status: health.StatusUp, Checks: []health.CheckResult{
+				{Name: "postgres", Status: health.StatusUp},
+				{Name: "replica", Status: health.StatusUp},
+			}}},
+		{"test with failing check", map[string]health.Check{"postgres": passing, "replica": failing}, []string{"postgres", "replica"}, false,
+			health.Report{Status: health.StatusDown, Checks: []health.CheckResult{
+				{Name: "postgres", Status: health.StatusUp},
+				{Name: "replica", Status: health.StatusDown, Error: "connection refused"},
+			}}},
+		{"test with maintenance", map[string]health.Check{"postgres": passing}, []string{"postgres"}, true,
+			health.Report{Status: health.StatusDown, Maintenance: true, Checks: []health.CheckResult{
+				{Name: "postgres", Status: health.StatusUp},
+			}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.NewChecker()
+			for _, name := range tt.order {
+				checker.AddReadinessCheck(name, tt.checks[name])
+			}
+			checker.SetMaintenance(tt.maintenance)
+			if got := checker.Readiness(context.Background()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Readiness() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecker_Handlers(t *testing.T) {
+	tests := []struct {
+		name            string
+		maintenance     bool
+		check           health.Check
+		method          string
+		target          string
+		wantCode        int
+		wantStatus      string
+		wantMaintenance bool
+	}{
+		{"test liveness", false, passing, http.MethodGet, "/healthz", http.StatusOK, health.StatusUp, false},
+		{"test liveness ignores failing checks", false, failing, http.MethodGet, "/healthz", http.StatusOK, health.StatusUp, false},
+		{"test liveness in maintenance", true, passing, http.MethodGet, "/healthz", http.StatusOK, health.StatusUp, true},
+		{"test readiness", false, passing, http.MethodGet, "/readyz", http.StatusOK, health.StatusUp, false},
+		{"test readiness with failing check", false, failing, http.MethodGet, "/readyz", http.StatusServiceUnavailable, health.StatusDown, false},
+		{"test readiness in maintenance", true, passing, http.MethodGet, "/readyz", http.StatusServiceUnavailable, health.StatusDown, true},
+		{"test maintenance state", true, passing, http.MethodGet, "/maintenance", http.StatusOK, health.StatusUp, true},
+		{"test maintenance on", false, passing, http.MethodPut, "/maintenance?enabled=true", http.StatusOK, health.StatusUp, true},
+		{"test maintenance off", true, passing, http.MethodPut, "/maintenance?enabled=false", http.StatusOK, health.StatusUp, false},
+		{"test maintenance without state", false, passing, http.MethodPut, "/maintenance", http.StatusBadRequest, "", false},
+		{"test maintenance with invalid state", true, passing, http.MethodPut, "/maintenance?enabled=maybe", http.StatusBadRequest, "", true},
+		{"test maintenance with wrong method", false, passing, http.MethodPost, "/maintenance?enabled=true", http.StatusMethodNotAllowed, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.NewChecker()
+			checker.AddReadinessCheck("postgres", tt.check)
+			checker.SetMaintenance(tt.maintenance)
+			mux := http.NewServeMux()
+			mux.HandleFunc("/healthz", checker.LivenessHandler)
+			mux.HandleFunc("/readyz", checker.ReadinessHandler)
+			mux.HandleFunc("/maintenance", checker.MaintenanceHandler)
+
+			response := httptest.NewRecorder()
+			mux.ServeHTTP(response, httptest.NewRequest(tt.method, tt.target, nil))
+			if response.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d, body %q", response.Code, tt.wantCode, response.Body.String())
+			}
+			if got := checker.Maintenance(); got != tt.wantMaintenance {
+				t.Errorf("Maintenance() = %v, want %v", got, tt.wantMaintenance)
+			}
+			if tt.wantStatus == "" {
+				return
+			}
+			if got := response.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", got)
+			}
+			var report health.Report
+			if err := json.Unmarshal(response.Body.Bytes(), &report); err != nil {
+				t.Fatalf("report is not valid json: %v", err)
+			}
+			if report.Status != tt.wantStatus || report.Maintenance != tt.wantMaintenance {
+				t.Errorf("report = %+v, want status %q and maintenance %v", report, tt.wantStatus, tt.wantMaintenance)
+			}
+		})
+	}
+}
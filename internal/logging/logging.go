@@ -0,0 +1,140 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/zakharova-e/subscriptions-info/internal/config"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	redacted        = "[REDACTED]"
+)
+
+type requestIDKey struct{}
+
+var settings = config.Log{BodyLimit: 1024}
+
+// Setup installs the JSON logger as the slog default and keeps body logging settings
+func Setup(cfg config.Log) error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+	settings = cfg
+	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	})
+	slog.SetDefault(slog.New(contextHandler{handler}))
+	return nil
+}
+
+// contextHandler adds the request id stored in the context to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// RequestIDMiddleware reuses the incoming X-Request-ID or generates a new one,
+// echoes it in the response and stores it in the request context
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), requestID)))
+	})
+}
+
+// Body returns the attribute describing a request/response body, it is empty unless body logging is enabled.
+// Sensitive JSON fields are redacted and the result is truncated to the configured limit
+func Body(data []byte) slog.Attr {
+	if !settings.Body || len(data) == 0 {
+		return slog.Attr{}
+	}
+	text := string(data)
+	var parsed any
+	if err := json.Unmarshal(data, &parsed); err == nil {
+		if redactedBody, errJson := json.Marshal(redactValue(parsed)); errJson == nil {
+			text = string(redactedBody)
+		}
+	}
+	if settings.BodyLimit > 0 && len(text) > settings.BodyLimit {
+		// cut at a rune boundary, half of a multi-byte character would be invalid utf-8 in the log
+		limit := settings.BodyLimit
+		for limit > 0 && !utf8.RuneStart(text[limit]) {
+			limit--
+		}
+		text = text[:limit] + "...(truncated)"
+	}
+	return slog.String("body", text)
+}
+
+func isSensitive(key string) bool {
+	for _, field := range settings.RedactFields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	if isSensitive(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+	return attr
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if isSensitive(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,51 @@
+package logging_test
+
+import (
+	"log/slog"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/zakharova-e/subscriptions-info/internal/config"
+	"github.com/zakharova-e/subscriptions-info/internal/logging"
+)
+
+func TestBody(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Log
+		data string
+		want string
+	}{
+		{"test with body logging disabled", config.Log{Level: "info"}, `{"id":1}`, ""},
+		{"test with empty body", config.Log{Level: "info", Body: true}, ``, ""},
+		{"test with plain text body", config.Log{Level: "info", Body: true}, `42`, "42"},
+		{"test with redacted field", config.Log{Level: "info", Body: true, RedactFields: []string{"token"}},
+			`{"id":1,"Token":"abc"}`, `{"Token":"[REDACTED]","id":1}`},
+		{"test with nested redacted field", config.Log{Level: "info", Body: true, RedactFields: []string{"token"}},
+			`{"List":[{"token":"abc"}]}`, `{"List":[{"token":"[REDACTED]"}]}`},
+		{"test with truncated body", config.Log{Level: "info", Body: true, BodyLimit: 5}, `"abcdefgh"`, `"abcd...(truncated)`},
+		{"test with limit inside a character", config.Log{Level: "info", Body: true, BodyLimit: 4}, `"ааа"`, `"а...(truncated)`},
+		{"test with limit at a character boundary", config.Log{Level: "info", Body: true, BodyLimit: 5}, `"ааа"`, `"аа...(truncated)`},
+		{"test with limit inside the first character", config.Log{Level: "info", Body: true, BodyLimit: 1}, `ёж`, `...(truncated)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := logging.Setup(tt.cfg); err != nil {
+				t.Fatalf("Setup() error = %v", err)
+			}
+			got := logging.Body([]byte(tt.data))
+			if tt.want == "" {
+				if !got.Equal(slog.Attr{}) {
+					t.Errorf("Body() = %v, want empty attribute", got)
+				}
+				return
+			}
+			if !utf8.ValidString(got.Value.String()) {
+				t.Errorf("Body() = %q is not valid utf-8", got.Value.String())
+			}
+			if got.Value.String() != tt.want {
+				t.Errorf("Body() = %v, want %v", got.Value.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+begin;
+
+alter table subscription add column if not exists billing_day smallint;
+
+-- existing subscriptions are billed on the day they started
+update subscription set billing_day = extract(day from start_date) where billing_day is null;
+
+alter table subscription alter column billing_day set not null;
+alter table subscription add constraint subscription_billing_day_check check (billing_day between 1 and 31);
+
+commit;
@@ -0,0 +1,5 @@
+alter table subscription
+    drop column if exists trial_days,
+    drop column if exists trial_price,
+    drop column if exists intro_months,
+    drop column if exists intro_price;
@@ -0,0 +1,9 @@
+begin;
+
+alter table subscription
+    add column if not exists trial_days integer not null default 0 check (trial_days >= 0),
+    add column if not exists trial_price integer not null default 0 check (trial_price >= 0),
+    add column if not exists intro_months integer not null default 0 check (intro_months >= 0),
+    add column if not exists intro_price integer not null default 0 check (intro_price >= 0);
+
+commit;
@@ -0,0 +1 @@
+drop table if exists subscription_pause;
@@ -0,0 +1,13 @@
+begin;
+
+create table if not exists subscription_pause(
+    id integer generated always as identity primary key,
+    subscription_id integer not null references subscription(id) on delete cascade,
+    start_date date not null,
+    finish_date date,
+    check (finish_date is null or finish_date >= start_date)
+);
+
+create index if not exists idx_subscription_pause_subscription on subscription_pause(subscription_id, start_date);
+
+commit;
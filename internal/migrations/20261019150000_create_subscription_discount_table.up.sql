@@ -0,0 +1,15 @@
+begin;
+
+create table if not exists subscription_discount(
+    id integer generated always as identity primary key,
+    subscription_id integer not null references subscription(id) on delete cascade,
+    kind text not null check (kind in ('percent', 'fixed')),
+    amount integer not null check (amount > 0 and (kind <> 'percent' or amount <= 100)),
+    start_month date not null check (extract(day from start_month) = 1),
+    months integer not null default 0 check (months >= 0),
+    code text not null default ''
+);
+
+create index if not exists idx_subscription_discount_subscription on subscription_discount(subscription_id, start_month);
+
+commit;
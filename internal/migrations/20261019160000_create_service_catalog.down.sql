@@ -0,0 +1,3 @@
+alter table subscription drop column if exists service_id;
+drop table if exists service_alias;
+drop table if exists service;
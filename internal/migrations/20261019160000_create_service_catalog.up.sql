@@ -0,0 +1,25 @@
+begin;
+
+create table if not exists service(
+    id integer generated always as identity primary key,
+    name varchar(1000) not null unique,
+    aliases jsonb not null default '[]',
+    category text not null default '',
+    vendor_url text not null default '',
+    plans jsonb not null default '[]'
+);
+
+-- match keys of the names and aliases, see serviceKey
+create table if not exists service_alias(
+    key text primary key,
+    service_id integer not null references service(id) on delete cascade
+);
+
+create index if not exists idx_service_alias_service on service_alias(service_id);
+
+alter table subscription
+    add column if not exists service_id integer references service(id) on delete set null;
+
+create index if not exists idx_subscription_service on subscription(service_id);
+
+commit;
@@ -0,0 +1 @@
+alter table subscription drop column if exists tags;
@@ -0,0 +1,8 @@
+begin;
+
+alter table subscription
+    add column if not exists tags jsonb not null default '[]';
+
+create index if not exists idx_subscription_tags on subscription using gin (tags);
+
+commit;
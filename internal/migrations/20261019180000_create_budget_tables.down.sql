@@ -0,0 +1,2 @@
+drop table if exists budget_alert;
+drop table if exists budget;
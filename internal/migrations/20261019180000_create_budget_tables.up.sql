@@ -0,0 +1,28 @@
+begin;
+
+create table if not exists budget(
+    id integer generated always as identity primary key,
+    scope text not null check (scope in ('user', 'service', 'tag')),
+    target text not null,
+    amount integer not null check (amount > 0),
+    thresholds jsonb not null default '[80, 100]',
+    unique (scope, target)
+);
+
+-- one alert per budget, month and threshold, notified_at is null until the notification is delivered
+create table if not exists budget_alert(
+    id integer generated always as identity primary key,
+    budget_id integer not null references budget(id) on delete cascade,
+    month date not null check (extract(day from month) = 1),
+    threshold integer not null,
+    amount integer not null,
+    spent integer not null,
+    projected integer not null,
+    created_at timestamptz not null default now(),
+    notified_at timestamptz,
+    unique (budget_id, month, threshold)
+);
+
+create index if not exists idx_budget_alert_pending on budget_alert(id) where notified_at is null;
+
+commit;
@@ -0,0 +1,11 @@
+begin;
+
+create table if not exists subscription_price_change(
+    id integer generated always as identity primary key,
+    subscription_id integer not null references subscription(id) on delete cascade,
+    effective_date date not null,
+    price integer not null check (price >= 0),
+    unique (subscription_id, effective_date)
+);
+
+commit;
@@ -0,0 +1,14 @@
+begin;
+
+-- subscriptions deleted by a merge, record is the json of the row with its pauses, discounts and price changes
+create table if not exists subscription_merge(
+    id integer generated always as identity primary key,
+    subscription_id integer not null references subscription(id) on delete cascade,
+    merged_id integer not null,
+    record jsonb not null,
+    merged_at timestamptz not null default now()
+);
+
+create index if not exists idx_subscription_merge_subscription on subscription_merge(subscription_id);
+
+commit;
@@ -0,0 +1,73 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/zakharova-e/subscriptions-info/internal/config"
+)
+
+// Notification is a message about an event, Data carries the event itself for machine consumers
+type Notification struct {
+	Kind    string    `json:"kind"`
+	Subject string    `json:"subject"`
+	Message string    `json:"message"`
+	Data    any       `json:"data,omitempty"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// Notifier delivers notifications, an error means the notification should be sent again later
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// New returns the webhook notifier when a webhook url is configured, otherwise notifications are only logged
+func New(cfg config.Notifications) Notifier {
+	if cfg.WebhookURL == "" {
+		return Log{}
+	}
+	return &Webhook{URL: cfg.WebhookURL, Client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+// Log writes notifications to the application log
+type Log struct{}
+
+func (Log) Notify(ctx context.Context, notification Notification) error {
+	slog.WarnContext(ctx, notification.Message, "notification", notification.Kind, "subject", notification.Subject)
+	return nil
+}
+
+// Webhook posts notifications as json, any status other than 2xx is an error
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+func (w *Webhook) Notify(ctx context.Context, notification Notification) error {
+	if notification.SentAt.IsZero() {
+		notification.SentAt = time.Now().UTC()
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := w.Client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zakharova-e/subscriptions-info/internal/config"
+	"github.com/zakharova-e/subscriptions-info/internal/notifications"
+)
+
+func TestWebhook_Notify(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"test delivered", http.StatusNoContent, false},
+		{"test rejected", http.StatusBadGateway, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got notifications.Notification
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("decode body: %v", err)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			notifier := notifications.New(config.Notifications{WebhookURL: server.URL, Timeout: time.Second})
+			err := notifier.Notify(context.Background(), notifications.Notification{Kind: "budget_alert", Subject: "tag team:platform", Message: "80% reached"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Kind != "budget_alert" || got.Subject != "tag team:platform" || got.SentAt.IsZero() {
+				t.Errorf("webhook received %+v", got)
+			}
+		})
+	}
+}
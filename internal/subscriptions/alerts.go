@@ -0,0 +1,77 @@
+package subscriptions
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zakharova-e/subscriptions-info/internal/notifications"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+// BudgetWorker evaluates the budgets every interval and delivers the new alerts through the notifier,
+// undelivered alerts are sent again on the next run
+func BudgetWorker(interval time.Duration, notifier notifications.Notifier) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			checkBudgets(ctx, postgresBudgetAlerts{}, notifier)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// budgetAlertStore creates the budget alerts and keeps which of them were delivered
+type budgetAlertStore interface {
+	Evaluate(ctx context.Context, today time.Time) (int, error)
+	Pending(ctx context.Context) ([]models.BudgetAlert, error)
+	Notified(ctx context.Context, recordId int32) error
+}
+
+// postgresBudgetAlerts is the budgetAlertStore of the database
+type postgresBudgetAlerts struct{}
+
+func (postgresBudgetAlerts) Evaluate(ctx context.Context, today time.Time) (int, error) {
+	return BudgetEvaluate(ctx, today)
+}
+
+func (postgresBudgetAlerts) Pending(ctx context.Context) ([]models.BudgetAlert, error) {
+	return pendingBudgetAlerts(ctx)
+}
+
+func (postgresBudgetAlerts) Notified(ctx context.Context, recordId int32) error {
+	return budgetAlertNotified(ctx, recordId)
+}
+
+// checkBudgets creates the new alerts and delivers the pending ones in order,
+// the first failed delivery stops the run so that the rest keep their order on the next one
+func checkBudgets(ctx context.Context, store budgetAlertStore, notifier notifications.Notifier) {
+	created, err := store.Evaluate(ctx, models.Today())
+	if err != nil {
+		slog.ErrorContext(ctx, "budget evaluation failed", "error", err)
+	} else if created > 0 {
+		slog.InfoContext(ctx, "budget thresholds reached", "alerts", created)
+	}
+	alerts, err := store.Pending(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "pending budget alerts", "error", err)
+		return
+	}
+	for _, alert := range alerts {
+		notification := notifications.Notification{Kind: "budget_alert", Subject: string(alert.Scope) + " " + alert.Target,
+			Message: alert.Message(), Data: &alert}
+		if err := notifier.Notify(ctx, notification); err != nil {
+			slog.ErrorContext(ctx, "budget alert notification failed", "alert_id", alert.Id, "error", err)
+			return
+		}
+		if err := store.Notified(ctx, alert.Id); err != nil {
+			slog.ErrorContext(ctx, "budget alert notification", "alert_id", alert.Id, "error", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package subscriptions_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zakharova-e/subscriptions-info/internal/notifications"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+// fakeStore keeps the alerts in memory, evaluation adds the alerts of created
+type fakeStore struct {
+	alerts      []models.BudgetAlert
+	created     []models.BudgetAlert
+	notified    []int32
+	evaluateErr error
+	pendingErr  error
+	notifiedErr error
+}
+
+func (s *fakeStore) Evaluate(ctx context.Context, today time.Time) (int, error) {
+	if s.evaluateErr != nil {
+		return 0, s.evaluateErr
+	}
+	s.alerts = append(s.alerts, s.created...)
+	created := len(s.created)
+	s.created = nil
+	return created, nil
+}
+
+func (s *fakeStore) Pending(ctx context.Context) ([]models.BudgetAlert, error) {
+	if s.pendingErr != nil {
+		return nil, s.pendingErr
+	}
+	pending := []models.BudgetAlert{}
+	for _, alert := range s.alerts {
+		if !s.isNotified(alert.Id) {
+			pending = append(pending, alert)
+		}
+	}
+	return pending, nil
+}
+
+func (s *fakeStore) Notified(ctx context.Context, recordId int32) error {
+	if s.notifiedErr != nil {
+		return s.notifiedErr
+	}
+	s.notified = append(s.notified, recordId)
+	return nil
+}
+
+func (s *fakeStore) isNotified(recordId int32) bool {
+	for _, id := range s.notified {
+		if id == recordId {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeNotifier records the delivered alerts and fails the deliveries listed in failures
+type fakeNotifier struct {
+	failures  map[int32]int
+	delivered []int32
+	attempts  []int32
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, notification notifications.Notification) error {
+	alert := notification.Data.(*models.BudgetAlert)
+	n.attempts = append(n.attempts, alert.Id)
+	if n.failures[alert.Id] > 0 {
+		n.failures[alert.Id]--
+		return errors.New("webhook responded with status 502")
+	}
+	n.delivered = append(n.delivered, alert.Id)
+	return nil
+}
+
+func alerts(ids ...int32) []models.BudgetAlert {
+	result := make([]models.BudgetAlert, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, models.BudgetAlert{Id: id, BudgetId: 1, Scope: models.BudgetUser,
+			Target: "60601fee-2bf1-4721-ae6f-7636e79a0cba", Threshold: 80, Amount: 5000, Spent: 4100})
+	}
+	return result
+}
+
+func TestCheckBudgets(t *testing.T) {
+	tests := []struct {
+		name          string
+		store         *fakeStore
+		failures      map[int32]int
+		runs          int
+		wantAttempts  []int32
+		wantDelivered []int32
+		wantNotified  []int32
+	}{
+		{"test without alerts", &fakeStore{}, nil, 1, nil, nil, nil},
+		{"test with created alerts", &fakeStore{created: alerts(1, 2)}, nil, 1, []int32{1, 2}, []int32{1, 2}, []int32{1, 2}},
+		{"test with pending alerts", &fakeStore{alerts: alerts(1), created: alerts(2)}, nil, 1, []int32{1, 2}, []int32{1, 2}, []int32{1, 2}},
+		{"test with failed evaluation", &fakeStore{alerts: alerts(1), evaluateErr: errors.New("timeout")}, nil, 1,
+			[]int32{1}, []int32{1}, []int32{1}},
+		{"test with failed pending alerts", &fakeStore{alerts: alerts(1), pendingErr: errors.New("timeout")}, nil, 1, nil, nil, nil},
+		{"test with failed notification", &fakeStore{created: alerts(1, 2, 3)}, map[int32]int{2: 1}, 1,
+			[]int32{1, 2}, []int32{1}, []int32{1}},
+		{"test with retry after failed notification", &fakeStore{created: alerts(1, 2, 3)}, map[int32]int{2: 1}, 2,
+			[]int32{1, 2, 2, 3}, []int32{1, 2, 3}, []int32{1, 2, 3}},
+		{"test with notification failing twice", &fakeStore{created: alerts(1, 2)}, map[int32]int{1: 2}, 2,
+			[]int32{1, 1}, nil, nil},
+		{"test with failed mark as notified", &fakeStore{created: alerts(1, 2), notifiedErr: errors.New("timeout")}, nil, 1,
+			[]int32{1}, []int32{1}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &fakeNotifier{failures: tt.failures}
+			for range tt.runs {
+				subscriptions.CheckBudgets(context.Background(), tt.store, notifier)
+			}
+			if !reflect.DeepEqual(notifier.attempts, tt.wantAttempts) {
+				t.Errorf("attempts = %v, want %v", notifier.attempts, tt.wantAttempts)
+			}
+			if !reflect.DeepEqual(notifier.delivered, tt.wantDelivered) {
+				t.Errorf("delivered = %v, want %v", notifier.delivered, tt.wantDelivered)
+			}
+			if !reflect.DeepEqual(tt.store.notified, tt.wantNotified) {
+				t.Errorf("notified = %v, want %v", tt.store.notified, tt.wantNotified)
+			}
+		})
+	}
+}
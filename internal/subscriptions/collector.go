@@ -0,0 +1,45 @@
+package subscriptions
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const collectTimeout = 5 * time.Second
+
+// Collector exports domain figures, they are queried from the database on every scrape
+type Collector struct {
+	active *prometheus.Desc
+	spend  *prometheus.Desc
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		active: prometheus.NewDesc("subscriptions_active",
+			"Number of subscriptions active today.", nil, nil),
+		spend: prometheus.NewDesc("subscriptions_monthly_spend",
+			"Current monthly spend of active subscriptions by service.", []string{"service_name"}, nil),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.active
+	ch <- c.spend
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+	active, spend, err := SubscriptionActiveStats(ctx)
+	if err != nil {
+		slog.Error("metrics collection failed", "error", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.active, prometheus.GaugeValue, float64(active))
+	for serviceName, price := range spend {
+		ch <- prometheus.MustNewConstMetric(c.spend, prometheus.GaugeValue, float64(price), serviceName)
+	}
+}
@@ -0,0 +1,4 @@
+package subscriptions
+
+// unexported helpers used by the tests of the subscriptions_test package
+var CheckBudgets = checkBudgets
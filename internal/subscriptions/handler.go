@@ -1,17 +1,22 @@
 package subscriptions
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/zakharova-e/subscriptions-info/internal/logging"
 	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+	"github.com/zakharova-e/subscriptions-info/internal/tracing"
 )
 
 // SubscriptionCreateHandler godoc
@@ -20,47 +25,69 @@ import (
 //	@Tags		subscriptions
 //	@Accept		json
 //	@Produce	plain
-//	@Param		item	body		Subscription	true	"item to add"
-//	@Success	200		{integer}	string			"created"
+//	@Security	ApiKeyAuth
+//	@Param		item	body		models.Subscription	true	"item to add"
+//	@Success	200		{integer}	integer			"id of the created record"
+//	@Header		200		{string}	Warning			"one per overlapping subscription of the same user and service, when DUPLICATE_WARNINGS is on"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string			"error"
 //	@Failure	405		{string}	string			"error"
-//	@Failure	400		{string}	string			"error"
 //	@Failure	500		{string}	string			"error"
+//	@Failure	503		{string}	string			"error"
+//	@Failure	504		{string}	string			"error"
 //	@Router		/subscription/create [post]
 func SubscriptionCreateHandler(response http.ResponseWriter, request *http.Request) {
 	if request.Method != http.MethodPost {
 		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "POST"})
 		return
 	}
-	body, errBody := io.ReadAll(request.Body)
+	sbscr, errBody := readSubscription(request)
 	if errBody != nil {
 		ResponseWithError(response, request, errBody)
 		return
 	}
-	var sbscr Subscription
-	errJson := json.Unmarshal(body, &sbscr)
-	if errJson != nil {
-		ResponseWithError(response, request, errJson)
-		return
-	}
-	num, errAdd := SubscriptionCreate(sbscr)
+	num, errAdd := SubscriptionCreate(request.Context(), sbscr)
 	if errAdd != nil {
 		ResponseWithError(response, request, errAdd)
 		return
 	}
+	sbscr.Id = *num
+	warnDuplicates(response, request, sbscr)
 	WriteResponse(response, request, []byte(strconv.Itoa(int(*num))))
 }
 
-// SubscriptionReadHandlergodoc
+// warnDuplicates adds a Warning header for every overlapping subscription of the same user and service,
+// the write has succeeded already, so a failed check is only logged
+func warnDuplicates(response http.ResponseWriter, request *http.Request, item models.Subscription) {
+	if !models.DuplicateWarnings() {
+		return
+	}
+	overlaps, err := SubscriptionDuplicatesOf(request.Context(), item)
+	if err != nil {
+		slog.WarnContext(request.Context(), "duplicate check failed", "id", item.Id, "error", err)
+		return
+	}
+	for _, overlap := range overlaps {
+		response.Header().Add("Warning", fmt.Sprintf(`299 - "overlaps subscription %d of the same service from %s"`,
+			overlap.SubscriptionIds[1], models.FormatDate(overlap.From)))
+	}
+}
+
+// SubscriptionReadHandler godoc
 //
 //	@Summary	record reading
 //	@Tags		subscriptions
 //	@Produce	json
-//	@Param		rowId	query		integer			true	"record id"
-//	@Success	200		{object}	Subscription	"data found"
+//	@Security	ApiKeyAuth
+//	@Param		rowId	query		integer			true	"record id"	minimum(1)
+//	@Success	200		{object}	models.Subscription	"data found"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string			"error"
 //	@Failure	404		{string}	string			"error"
 //	@Failure	405		{string}	string			"error"
-//	@Failure	400		{string}	string			"error"
 //	@Failure	500		{string}	string			"error"
+//	@Failure	503		{string}	string			"error"
+//	@Failure	504		{string}	string			"error"
 //	@Router		/subscription/read [get]
 func SubscriptionReadHandler(response http.ResponseWriter, request *http.Request) {
 	if request.Method != http.MethodGet {
@@ -70,20 +97,15 @@ func SubscriptionReadHandler(response http.ResponseWriter, request *http.Request
 	sIDParam := request.URL.Query().Get("rowId")
 	sID, errParam := strconv.Atoi(sIDParam)
 	if errParam != nil || sID < 1 {
-		ResponseWithError(response, request, &models.InvalidParameterError{ParamName: "rowId"})
+		ResponseWithError(response, request, fieldError("rowId", "query", "must be a positive integer"))
 		return
 	}
-	item, errRead := SubscriptionRead(int32(sID))
+	item, errRead := SubscriptionRead(request.Context(), int32(sID))
 	if errRead != nil {
 		ResponseWithError(response, request, errRead)
 		return
 	}
-	responseJson, errJson := json.Marshal(item)
-	if errJson != nil {
-		ResponseWithError(response, request, errJson)
-		return
-	}
-	WriteResponse(response, request, responseJson)
+	writeJson(response, request, item)
 }
 
 // SubscriptionUpdateHandler godoc
@@ -91,34 +113,34 @@ func SubscriptionReadHandler(response http.ResponseWriter, request *http.Request
 //	@Summary	record update
 //	@Tags		subscriptions
 //	@Accept		json
-//	@Param		item	body		Subscription	true	"item to update"
-//	@Success	200		{integer}	string			"updated"
-//	@Failure	404		{string}	string			"error"
-//	@Failure	405		{string}	string			"error"
-//	@Failure	400		{string}	string			"error"
-//	@Failure	500		{string}	string			"error"
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		item	body		models.Subscription	true	"item to update"
+//	@Success	200		"updated, empty body"
+//	@Header		200		{string}	Warning	"one per overlapping subscription of the same user and service, when DUPLICATE_WARNINGS is on"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
 //	@Router		/subscription/update [put]
 func SubscriptionUpdateHandler(response http.ResponseWriter, request *http.Request) {
 	if request.Method != http.MethodPut {
 		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "PUT"})
 		return
 	}
-	body, err := io.ReadAll(request.Body)
-	if err != nil {
-		http.Error(response, err.Error(), http.StatusBadRequest)
-		return
-	}
-	var sbscr Subscription
-	errJson := json.Unmarshal(body, &sbscr)
-	if errJson != nil {
-		ResponseWithError(response, request, errJson)
+	sbscr, errBody := readSubscription(request)
+	if errBody != nil {
+		ResponseWithError(response, request, errBody)
 		return
 	}
-	errUpdate := SubscriptionUpdate(sbscr)
+	errUpdate := SubscriptionUpdate(request.Context(), sbscr)
 	if errUpdate != nil {
 		ResponseWithError(response, request, errUpdate)
 		return
 	}
+	warnDuplicates(response, request, sbscr)
 	WriteResponse(response, request, nil)
 }
 
@@ -126,13 +148,16 @@ func SubscriptionUpdateHandler(response http.ResponseWriter, request *http.Reque
 //
 //	@Summary	record deleting
 //	@Tags		subscriptions
-//	@Produce	json
-//	@Param		rowId	query		integer			true	"record id"
-//	@Success	200		{object}	Subscription	"data deleted"
-//	@Failure	404		{string}	string			"error"
-//	@Failure	405		{string}	string			"error"
-//	@Failure	400		{string}	string			"error"
-//	@Failure	500		{string}	string			"error"
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		rowId	query		integer	true	"record id"	minimum(1)
+//	@Success	200		"deleted, empty body"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
 //	@Router		/subscription/delete [delete]
 func SubscriptionDeleteHandler(response http.ResponseWriter, request *http.Request) {
 	if request.Method != http.MethodDelete {
@@ -142,10 +167,10 @@ func SubscriptionDeleteHandler(response http.ResponseWriter, request *http.Reque
 	sIDParam := request.URL.Query().Get("rowId")
 	sID, errParam := strconv.Atoi(sIDParam)
 	if errParam != nil || sID < 1 {
-		ResponseWithError(response, request, &models.InvalidParameterError{ParamName: "rowId"})
+		ResponseWithError(response, request, fieldError("rowId", "query", "must be a positive integer"))
 		return
 	}
-	errDel := SubscriptionDelete(int32(sID))
+	errDel := SubscriptionDelete(request.Context(), int32(sID))
 	if errDel != nil {
 		ResponseWithError(response, request, errDel)
 		return
@@ -158,10 +183,17 @@ func SubscriptionDeleteHandler(response http.ResponseWriter, request *http.Reque
 //	@Summary	list of records
 //	@Tags		subscriptions
 //	@Produce	json
-//	@Param		page	query		integer					false	"page number"
-//	@Success	200		{object}	SubscriptionListPage	"loaded successfully"
-//	@Failure	405		{string}	string					"error"
-//	@Failure	500		{string}	string					"error"
+//	@Security	ApiKeyAuth
+//	@Param		page		query		integer					false	"page number"	minimum(1)
+//	@Param		userId		query		string					false	"user id"		format(uuid)
+//	@Param		serviceName	query		string					false	"service name"
+//	@Success	200			{object}	models.SubscriptionListPage	"loaded successfully"
+//	@Failure	400			{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401			{string}	string					"error"
+//	@Failure	405			{string}	string					"error"
+//	@Failure	500			{string}	string					"error"
+//	@Failure	503			{string}	string					"error"
+//	@Failure	504			{string}	string					"error"
 //	@Router		/subscription/list [get]
 func SubscriptionListHandler(response http.ResponseWriter, request *http.Request) {
 	if request.Method != http.MethodGet {
@@ -173,17 +205,23 @@ func SubscriptionListHandler(response http.ResponseWriter, request *http.Request
 	if page < 1 {
 		page = 1
 	}
-	list, errList := SubscriptionList(page)
+	var filter models.ListFilter
+	if userId := request.URL.Query().Get("userId"); userId != "" {
+		if err := uuid.Validate(userId); err != nil {
+			ResponseWithError(response, request, fieldError("userId", "query", "must be uuid"))
+			return
+		}
+		filter.UserId = &userId
+	}
+	if serviceName := request.URL.Query().Get("serviceName"); serviceName != "" {
+		filter.ServiceName = &serviceName
+	}
+	list, errList := SubscriptionList(request.Context(), page, filter)
 	if errList != nil {
 		ResponseWithError(response, request, errList)
 		return
 	}
-	jsonList, errJson := json.Marshal(list)
-	if errJson != nil {
-		ResponseWithError(response, request, errJson)
-		return
-	}
-	WriteResponse(response, request, jsonList)
+	writeJson(response, request, list)
 }
 
 // SubscriptionSumHandler godoc
@@ -192,16 +230,81 @@ func SubscriptionListHandler(response http.ResponseWriter, request *http.Request
 //	@Tags		subscriptions
 //	@Accept		x-www-form-urlencoded
 //	@Produce	plain
-//	@Param		filterFrom	formData	string	true	"period from"
-//	@Param		filterTo	formData	string	true	"period to"
-//	@Param		userId	formData	string	false	"user id"
-//	@Param		serviceName	formData	string	false	"service name "
-//	@Success	200			{integer}	string	"sum is ready"
+//	@Security	ApiKeyAuth
+//	@Param		filterFrom	formData	string	true	"period from, YYYY-MM-DD or MM-YYYY for the first day of the month"	format(billing-date)
+//	@Param		filterTo	formData	string	true	"period to, YYYY-MM-DD or MM-YYYY for the last day of the month"	format(billing-date)
+//	@Param		userId		formData	string	false	"user id"	format(uuid)
+//	@Param		serviceName	formData	string	false	"service name"
+//	@Param		mode		formData	string	false	"monthly charges every touched month in full, prorated charges partial billing periods by days"	Enums(monthly, prorated)	default(monthly)
+//	@Success	200			{integer}	integer	"sum for the period after discounts"
+//	@Failure	400			{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401			{string}	string	"error"
 //	@Failure	405			{string}	string	"error"
-//	@Failure	400			{string}	string	"error"
 //	@Failure	500			{string}	string	"error"
+//	@Failure	503			{string}	string	"error"
+//	@Failure	504			{string}	string	"error"
 //	@Router		/subscription/sum [post]
 func SubscriptionSumHandler(response http.ResponseWriter, request *http.Request) {
+	sum, errSum := sumFromRequest(request)
+	if errSum != nil {
+		ResponseWithError(response, request, errSum)
+		return
+	}
+	WriteResponse(response, request, []byte(strconv.Itoa(sum.Net)))
+}
+
+// SubscriptionSumTotalsHandler godoc
+//
+//	@Summary	sum calculation before and after discounts
+//	@Tags		subscriptions
+//	@Accept		x-www-form-urlencoded
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		filterFrom	formData	string	true	"period from, YYYY-MM-DD or MM-YYYY for the first day of the month"	format(billing-date)
+//	@Param		filterTo	formData	string	true	"period to, YYYY-MM-DD or MM-YYYY for the last day of the month"	format(billing-date)
+//	@Param		userId		formData	string	false	"user id"	format(uuid)
+//	@Param		serviceName	formData	string	false	"service name"
+//	@Param		mode		formData	string	false	"monthly charges every touched month in full, prorated charges partial billing periods by days"	Enums(monthly, prorated)	default(monthly)
+//	@Success	200			{object}	models.SpendTotals	"gross, discount and net sums for the period"
+//	@Failure	400			{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401			{string}	string	"error"
+//	@Failure	405			{string}	string	"error"
+//	@Failure	500			{string}	string	"error"
+//	@Failure	503			{string}	string	"error"
+//	@Failure	504			{string}	string	"error"
+//	@Router		/subscription/sum/totals [post]
+func SubscriptionSumTotalsHandler(response http.ResponseWriter, request *http.Request) {
+	sum, errSum := sumFromRequest(request)
+	if errSum != nil {
+		ResponseWithError(response, request, errSum)
+		return
+	}
+	writeJson(response, request, sum)
+}
+
+// SubscriptionReportHandler godoc
+//
+//	@Summary	spend by category or tag
+//	@Tags		subscriptions
+//	@Accept		x-www-form-urlencoded
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		filterFrom	formData	string	true	"period from, YYYY-MM-DD or MM-YYYY for the first day of the month"	format(billing-date)
+//	@Param		filterTo	formData	string	true	"period to, YYYY-MM-DD or MM-YYYY for the last day of the month"	format(billing-date)
+//	@Param		groupBy		formData	string	true	"category of the catalog entry, tag, or tag:KEY for the values of KEY:VALUE tags"
+//	@Param		tags		formData	string	false	"tag filter, e.g. team:platform AND NOT (env:test OR env:dev)"
+//	@Param		userId		formData	string	false	"user id"	format(uuid)
+//	@Param		serviceName	formData	string	false	"service name"
+//	@Param		mode		formData	string	false	"monthly charges every touched month in full, prorated charges partial billing periods by days"	Enums(monthly, prorated)	default(monthly)
+//	@Success	200			{object}	models.SpendReport	"groups ordered by net spend, a subscription with several tags is counted in each of their groups"
+//	@Failure	400			{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401			{string}	string	"error"
+//	@Failure	405			{string}	string	"error"
+//	@Failure	500			{string}	string	"error"
+//	@Failure	503			{string}	string	"error"
+//	@Failure	504			{string}	string	"error"
+//	@Router		/subscription/report [post]
+func SubscriptionReportHandler(response http.ResponseWriter, request *http.Request) {
 	if request.Method != http.MethodPost {
 		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "POST"})
 		return
@@ -209,13 +312,918 @@ func SubscriptionSumHandler(response http.ResponseWriter, request *http.Request)
 	filterFrom, filterTo, userId, serviceName, errRequest := getFilterParametersFromRequest(request)
 	if errRequest != nil {
 		ResponseWithError(response, request, errRequest)
+		return
 	}
-	sum, errSum := SubscriptionSum(*filterFrom, *filterTo, userId, serviceName)
-	if errSum != nil {
-		ResponseWithError(response, request, errSum)
+	mode, errMode := models.ParseSumMode(request.FormValue("mode"))
+	if errMode != nil {
+		ResponseWithError(response, request, fieldError("mode", "formData", errMode.Error()))
+		return
+	}
+	groupBy, errGroup := models.ParseReportGroup(request.FormValue("groupBy"))
+	if errGroup != nil {
+		ResponseWithError(response, request, fieldError("groupBy", "formData", errGroup.Error()))
+		return
+	}
+	filter, errFilter := models.ParseTagFilter(request.FormValue("tags"))
+	if errFilter != nil {
+		ResponseWithError(response, request, fieldError("tags", "formData", errFilter.Error()))
+		return
+	}
+	report, errReport := SubscriptionSpendReport(request.Context(), *filterFrom, *filterTo, userId, serviceName, mode, groupBy, filter)
+	if errReport != nil {
+		ResponseWithError(response, request, errReport)
+		return
+	}
+	writeJson(response, request, report)
+}
+
+// SubscriptionForecastHandler godoc
+//
+//	@Summary	spend forecast by month
+//	@Tags		subscriptions
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		months		query		integer		false	"number of months"	minimum(1)	maximum(36)	default(12)
+//	@Param		from		query		string		false	"first month, YYYY-MM-DD or MM-YYYY, the current month by default"	format(billing-date)
+//	@Param		userId		query		string		false	"user id"	format(uuid)
+//	@Param		serviceName	query		string		false	"service name"
+//	@Success	200			{object}	models.Forecast	"monthly totals and the subscriptions whose charge changes, with the reasons"
+//	@Failure	400			{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401			{string}	string	"error"
+//	@Failure	405			{string}	string	"error"
+//	@Failure	500			{string}	string	"error"
+//	@Failure	503			{string}	string	"error"
+//	@Failure	504			{string}	string	"error"
+//	@Router		/subscription/forecast [get]
+func SubscriptionForecastHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "GET"})
+		return
+	}
+	query := request.URL.Query()
+	months := 12
+	if value := query.Get("months"); value != "" {
+		var errMonths error
+		if months, errMonths = strconv.Atoi(value); errMonths != nil {
+			ResponseWithError(response, request, fieldError("months", "query", "must be an integer"))
+			return
+		}
+	}
+	from := models.Today()
+	if value := query.Get("from"); value != "" {
+		var errFrom error
+		if from, errFrom = models.ParseStartDate(value); errFrom != nil {
+			ResponseWithError(response, request, fieldError("from", "query", errFrom.Error()))
+			return
+		}
+	}
+	var userId, serviceName *string
+	if value := query.Get("userId"); value != "" {
+		userId = &value
+	}
+	if value := query.Get("serviceName"); value != "" {
+		serviceName = &value
+	}
+	forecast, errForecast := SubscriptionForecast(request.Context(), from, months, userId, serviceName)
+	if errForecast != nil {
+		ResponseWithError(response, request, errForecast)
+		return
+	}
+	writeJson(response, request, forecast)
+}
+
+// SubscriptionCompareHandler godoc
+//
+//	@Summary	spend of a period against another one
+//	@Tags		subscriptions
+//	@Accept		x-www-form-urlencoded
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		filterFrom	formData	string	true	"period from, YYYY-MM-DD or MM-YYYY for the first day of the month"	format(billing-date)
+//	@Param		filterTo	formData	string	true	"period to, YYYY-MM-DD or MM-YYYY for the last day of the month"	format(billing-date)
+//	@Param		baseFrom	formData	string	false	"base period from, given together with baseTo"	format(billing-date)
+//	@Param		baseTo		formData	string	false	"base period to, given together with baseFrom"	format(billing-date)
+//	@Param		against		formData	string	false	"base period when baseFrom and baseTo are omitted: the previous period of the same length or the same period a year earlier"	Enums(previous, year)	default(previous)
+//	@Param		userId		formData	string	false	"user id"	format(uuid)
+//	@Param		serviceName	formData	string	false	"service name"
+//	@Param		mode		formData	string	false	"monthly charges every touched month in full, prorated charges partial billing periods by days"	Enums(monthly, prorated)	default(monthly)
+//	@Success	200			{object}	models.Comparison	"totals of both periods, the deltas and the subscriptions that started, ended, changed price or did not change"
+//	@Failure	400			{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401			{string}	string	"error"
+//	@Failure	405			{string}	string	"error"
+//	@Failure	500			{string}	string	"error"
+//	@Failure	503			{string}	string	"error"
+//	@Failure	504			{string}	string	"error"
+//	@Router		/subscription/compare [post]
+func SubscriptionCompareHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "POST"})
+		return
+	}
+	filterFrom, filterTo, userId, serviceName, errRequest := getFilterParametersFromRequest(request)
+	if errRequest != nil {
+		ResponseWithError(response, request, errRequest)
+		return
+	}
+	mode, errMode := models.ParseSumMode(request.FormValue("mode"))
+	if errMode != nil {
+		ResponseWithError(response, request, fieldError("mode", "formData", errMode.Error()))
+		return
+	}
+	current := models.Period{From: *filterFrom, To: *filterTo}
+	base, errBase := basePeriodFromRequest(request, current)
+	if errBase != nil {
+		ResponseWithError(response, request, errBase)
+		return
+	}
+	comparison, errCompare := SubscriptionCompare(request.Context(), base, current, userId, serviceName, mode)
+	if errCompare != nil {
+		ResponseWithError(response, request, errCompare)
 		return
 	}
-	WriteResponse(response, request, []byte(strconv.Itoa(sum)))
+	writeJson(response, request, comparison)
+}
+
+// basePeriodFromRequest reads baseFrom and baseTo, or derives the base period from against
+func basePeriodFromRequest(request *http.Request, current models.Period) (models.Period, error) {
+	baseFrom, baseTo := request.FormValue("baseFrom"), request.FormValue("baseTo")
+	switch {
+	case baseFrom != "" && baseTo != "":
+		from, to, err := models.ParsePeriod(baseFrom, baseTo)
+		return models.Period{From: from, To: to}, err
+	case baseFrom != "":
+		return models.Period{}, fieldError("baseTo", "formData", "is required with baseFrom")
+	case baseTo != "":
+		return models.Period{}, fieldError("baseFrom", "formData", "is required with baseTo")
+	}
+	against, err := models.ParseCompareAgainst(request.FormValue("against"))
+	if err != nil {
+		return models.Period{}, fieldError("against", "formData", err.Error())
+	}
+	from, to := models.BasePeriod(current.From, current.To, against)
+	return models.Period{From: from, To: to}, nil
+}
+
+// sumFromRequest calculates the sum for the filters of a sum request
+func sumFromRequest(request *http.Request) (models.SpendTotals, error) {
+	if request.Method != http.MethodPost {
+		return models.SpendTotals{}, &models.MethodNotAllowedError{RequiredMethod: "POST"}
+	}
+	_, parseSpan := tracing.Start(request.Context(), "parse filters")
+	filterFrom, filterTo, userId, serviceName, errRequest := getFilterParametersFromRequest(request)
+	tracing.End(parseSpan, errRequest)
+	if errRequest != nil {
+		return models.SpendTotals{}, errRequest
+	}
+	mode, errMode := models.ParseSumMode(request.FormValue("mode"))
+	if errMode != nil {
+		return models.SpendTotals{}, fieldError("mode", "formData", errMode.Error())
+	}
+	return SubscriptionSum(request.Context(), *filterFrom, *filterTo, userId, serviceName, mode)
+}
+
+// SubscriptionTrialsHandler godoc
+//
+//	@Summary	subscriptions with ending trials
+//	@Tags		subscriptions
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		days	query		integer			false	"days ahead, TRIAL_WARNING_DAYS by default"	minimum(0)
+//	@Success	200		{array}		models.Subscription	"trials ending from today to today + days, soonest first"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string			"error"
+//	@Failure	405		{string}	string			"error"
+//	@Failure	500		{string}	string			"error"
+//	@Failure	503		{string}	string			"error"
+//	@Failure	504		{string}	string			"error"
+//	@Router		/subscription/trials [get]
+func SubscriptionTrialsHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "GET"})
+		return
+	}
+	days := models.TrialWarningDays()
+	if daysParam := request.URL.Query().Get("days"); daysParam != "" {
+		var errDays error
+		if days, errDays = strconv.Atoi(daysParam); errDays != nil || days < 0 {
+			ResponseWithError(response, request, fieldError("days", "query", "must be a non-negative integer"))
+			return
+		}
+	}
+	items, errTrials := SubscriptionTrialsEnding(request.Context(), days)
+	if errTrials != nil {
+		ResponseWithError(response, request, errTrials)
+		return
+	}
+	writeJson(response, request, items)
+}
+
+// SubscriptionPauseHandler godoc
+//
+//	@Summary	billing pause creation
+//	@Tags		pauses
+//	@Accept		json
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		pause	body		models.Pause		true	"pause to add, without finish_date it lasts until resumed"
+//	@Success	200		{integer}	integer		"id of the created pause"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields, overlapping pauses or pauses outside the subscription"
+//	@Failure	401		{string}	string		"error"
+//	@Failure	404		{string}	string		"subscription not found"
+//	@Failure	405		{string}	string		"error"
+//	@Failure	500		{string}	string		"error"
+//	@Failure	503		{string}	string		"error"
+//	@Failure	504		{string}	string		"error"
+//	@Router		/subscription/pause [post]
+func SubscriptionPauseHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "POST"})
+		return
+	}
+	var pause models.Pause
+	if errBody := readJson(request, &pause); errBody != nil {
+		ResponseWithError(response, request, errBody)
+		return
+	}
+	id, errPause := PauseCreate(request.Context(), pause)
+	if errPause != nil {
+		ResponseWithError(response, request, errPause)
+		return
+	}
+	WriteResponse(response, request, []byte(strconv.Itoa(int(*id))))
+}
+
+// SubscriptionResumeHandler godoc
+//
+//	@Summary	billing resumption
+//	@Tags		pauses
+//	@Accept		x-www-form-urlencoded
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		rowId	formData	integer	true	"subscription id"	minimum(1)
+//	@Param		date	formData	string	true	"first billed day, YYYY-MM-DD"	format(billing-date)
+//	@Success	200		"resumed, empty body"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	404		{string}	string	"subscription without an open pause"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
+//	@Router		/subscription/resume [post]
+func SubscriptionResumeHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "POST"})
+		return
+	}
+	sID, errParam := strconv.Atoi(request.FormValue("rowId"))
+	if errParam != nil || sID < 1 {
+		ResponseWithError(response, request, fieldError("rowId", "formData", "must be a positive integer"))
+		return
+	}
+	resumeDate, errDate := models.ParseStartDate(request.FormValue("date"))
+	if errDate != nil {
+		ResponseWithError(response, request, fieldError("date", "formData", errDate.Error()))
+		return
+	}
+	if errResume := PauseResume(request.Context(), int32(sID), resumeDate); errResume != nil {
+		ResponseWithError(response, request, errResume)
+		return
+	}
+	WriteResponse(response, request, nil)
+}
+
+// PauseDeleteHandler godoc
+//
+//	@Summary	billing pause deleting
+//	@Tags		pauses
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		rowId	query		integer	true	"pause id"	minimum(1)
+//	@Success	200		"deleted, empty body"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
+//	@Router		/subscription/pause/delete [delete]
+func PauseDeleteHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodDelete {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "DELETE"})
+		return
+	}
+	pID, errParam := strconv.Atoi(request.URL.Query().Get("rowId"))
+	if errParam != nil || pID < 1 {
+		ResponseWithError(response, request, fieldError("rowId", "query", "must be a positive integer"))
+		return
+	}
+	if errDel := PauseDelete(request.Context(), int32(pID)); errDel != nil {
+		ResponseWithError(response, request, errDel)
+		return
+	}
+	WriteResponse(response, request, nil)
+}
+
+// SubscriptionDiscountHandler godoc
+//
+//	@Summary	discount creation
+//	@Tags		discounts
+//	@Accept		json
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		discount	body		models.Discount	true	"discount to add, months 0 means until deleted"
+//	@Success	200			{integer}	integer		"id of the created discount"
+//	@Failure	400			{object}	models.ValidationErrorResponse	"invalid fields or overlapping discounts"
+//	@Failure	401			{string}	string		"error"
+//	@Failure	404			{string}	string		"subscription not found"
+//	@Failure	405			{string}	string		"error"
+//	@Failure	500			{string}	string		"error"
+//	@Failure	503			{string}	string		"error"
+//	@Failure	504			{string}	string		"error"
+//	@Router		/subscription/discount [post]
+func SubscriptionDiscountHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "POST"})
+		return
+	}
+	var discount models.Discount
+	if errBody := readJson(request, &discount); errBody != nil {
+		ResponseWithError(response, request, errBody)
+		return
+	}
+	id, errDiscount := DiscountCreate(request.Context(), discount)
+	if errDiscount != nil {
+		ResponseWithError(response, request, errDiscount)
+		return
+	}
+	WriteResponse(response, request, []byte(strconv.Itoa(int(*id))))
+}
+
+// DiscountDeleteHandler godoc
+//
+//	@Summary	discount deleting
+//	@Tags		discounts
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		rowId	query		integer	true	"discount id"	minimum(1)
+//	@Success	200		"deleted, empty body"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
+//	@Router		/subscription/discount/delete [delete]
+func DiscountDeleteHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodDelete {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "DELETE"})
+		return
+	}
+	dID, errParam := strconv.Atoi(request.URL.Query().Get("rowId"))
+	if errParam != nil || dID < 1 {
+		ResponseWithError(response, request, fieldError("rowId", "query", "must be a positive integer"))
+		return
+	}
+	if errDel := DiscountDelete(request.Context(), int32(dID)); errDel != nil {
+		ResponseWithError(response, request, errDel)
+		return
+	}
+	WriteResponse(response, request, nil)
+}
+
+// SubscriptionPriceChangeHandler godoc
+//
+//	@Summary	price change scheduling
+//	@Tags		price changes
+//	@Accept		json
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		change	body		models.PriceChange	true	"new regular monthly price from effective_date"
+//	@Success	200		{integer}	integer		"id of the created price change"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields, date outside the subscription or taken by another change"
+//	@Failure	401		{string}	string		"error"
+//	@Failure	404		{string}	string		"subscription not found"
+//	@Failure	405		{string}	string		"error"
+//	@Failure	500		{string}	string		"error"
+//	@Failure	503		{string}	string		"error"
+//	@Failure	504		{string}	string		"error"
+//	@Router		/subscription/price-change [post]
+func SubscriptionPriceChangeHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "POST"})
+		return
+	}
+	var change models.PriceChange
+	if errBody := readJson(request, &change); errBody != nil {
+		ResponseWithError(response, request, errBody)
+		return
+	}
+	id, errChange := PriceChangeCreate(request.Context(), change)
+	if errChange != nil {
+		ResponseWithError(response, request, errChange)
+		return
+	}
+	WriteResponse(response, request, []byte(strconv.Itoa(int(*id))))
+}
+
+// PriceChangeDeleteHandler godoc
+//
+//	@Summary	price change deleting
+//	@Tags		price changes
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		rowId	query		integer	true	"price change id"	minimum(1)
+//	@Success	200		"deleted, empty body"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
+//	@Router		/subscription/price-change/delete [delete]
+func PriceChangeDeleteHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodDelete {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "DELETE"})
+		return
+	}
+	cID, errParam := strconv.Atoi(request.URL.Query().Get("rowId"))
+	if errParam != nil || cID < 1 {
+		ResponseWithError(response, request, fieldError("rowId", "query", "must be a positive integer"))
+		return
+	}
+	if errDel := PriceChangeDelete(request.Context(), int32(cID)); errDel != nil {
+		ResponseWithError(response, request, errDel)
+		return
+	}
+	WriteResponse(response, request, nil)
+}
+
+// SubscriptionDuplicatesHandler godoc
+//
+//	@Summary	overlapping subscriptions of the same user and service
+//	@Tags		duplicates
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		userId	query		string			false	"user id, all users by default"	format(uuid)
+//	@Success	200		{array}		models.DuplicateGroup	"groups of subscriptions connected by overlaps, service names are matched ignoring case, punctuation, company suffixes and typos"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string			"error"
+//	@Failure	405		{string}	string			"error"
+//	@Failure	500		{string}	string			"error"
+//	@Failure	503		{string}	string			"error"
+//	@Failure	504		{string}	string			"error"
+//	@Router		/subscription/duplicates [get]
+func SubscriptionDuplicatesHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "GET"})
+		return
+	}
+	var userId *string
+	if value := request.URL.Query().Get("userId"); value != "" {
+		if err := uuid.Validate(value); err != nil {
+			ResponseWithError(response, request, fieldError("userId", "query", "must be uuid"))
+			return
+		}
+		userId = &value
+	}
+	groups, errDuplicates := SubscriptionDuplicates(request.Context(), userId)
+	if errDuplicates != nil {
+		ResponseWithError(response, request, errDuplicates)
+		return
+	}
+	writeJson(response, request, groups)
+}
+
+// SubscriptionMergeHandler godoc
+//
+//	@Summary	merging duplicates into one subscription
+//	@Tags		duplicates
+//	@Accept		json
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		merge	body		models.MergeRequest	true	"the subscription that stays and the duplicates merged into it"
+//	@Success	200		{object}	models.Subscription	"the merged subscription, the duplicates are deleted and kept in its merge history"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields, another user or service, overlapping discounts"
+//	@Failure	401		{string}	string			"error"
+//	@Failure	404		{string}	string			"subscription not found"
+//	@Failure	405		{string}	string			"error"
+//	@Failure	500		{string}	string			"error"
+//	@Failure	503		{string}	string			"error"
+//	@Failure	504		{string}	string			"error"
+//	@Router		/subscription/merge [post]
+func SubscriptionMergeHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "POST"})
+		return
+	}
+	var merge models.MergeRequest
+	if errBody := readJson(request, &merge); errBody != nil {
+		ResponseWithError(response, request, errBody)
+		return
+	}
+	merged, errMerge := SubscriptionMerge(request.Context(), merge)
+	if errMerge != nil {
+		ResponseWithError(response, request, errMerge)
+		return
+	}
+	writeJson(response, request, merged)
+}
+
+// SubscriptionMergesHandler godoc
+//
+//	@Summary	merge history of a subscription
+//	@Tags		duplicates
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		rowId	query		integer			true	"subscription id"	minimum(1)
+//	@Success	200		{array}		models.MergedRecord	"subscriptions merged into it, oldest first"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string			"error"
+//	@Failure	405		{string}	string			"error"
+//	@Failure	500		{string}	string			"error"
+//	@Failure	503		{string}	string			"error"
+//	@Failure	504		{string}	string			"error"
+//	@Router		/subscription/merges [get]
+func SubscriptionMergesHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "GET"})
+		return
+	}
+	sID, errParam := strconv.Atoi(request.URL.Query().Get("rowId"))
+	if errParam != nil || sID < 1 {
+		ResponseWithError(response, request, fieldError("rowId", "query", "must be a positive integer"))
+		return
+	}
+	records, errMerges := SubscriptionMerges(request.Context(), int32(sID))
+	if errMerges != nil {
+		ResponseWithError(response, request, errMerges)
+		return
+	}
+	writeJson(response, request, records)
+}
+
+// ServiceCreateHandler godoc
+//
+//	@Summary	catalog entry creation
+//	@Tags		catalog
+//	@Accept		json
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		service	body		models.Service		true	"entry to add, subscriptions matching its name or aliases are linked to it"
+//	@Success	200		{integer}	integer		"id of the created entry"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields, name or aliases of another entry"
+//	@Failure	401		{string}	string		"error"
+//	@Failure	405		{string}	string		"error"
+//	@Failure	500		{string}	string		"error"
+//	@Failure	503		{string}	string		"error"
+//	@Failure	504		{string}	string		"error"
+//	@Router		/service/create [post]
+func ServiceCreateHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "POST"})
+		return
+	}
+	var service models.Service
+	if errBody := readJson(request, &service); errBody != nil {
+		ResponseWithError(response, request, errBody)
+		return
+	}
+	id, errAdd := ServiceCreate(request.Context(), service)
+	if errAdd != nil {
+		ResponseWithError(response, request, errAdd)
+		return
+	}
+	WriteResponse(response, request, []byte(strconv.Itoa(int(*id))))
+}
+
+// ServiceReadHandler godoc
+//
+//	@Summary	catalog entry reading
+//	@Tags		catalog
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		rowId	query		integer	true	"entry id"	minimum(1)
+//	@Success	200		{object}	models.Service	"data found"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	404		{string}	string	"error"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
+//	@Router		/service/read [get]
+func ServiceReadHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "GET"})
+		return
+	}
+	sID, errParam := strconv.Atoi(request.URL.Query().Get("rowId"))
+	if errParam != nil || sID < 1 {
+		ResponseWithError(response, request, fieldError("rowId", "query", "must be a positive integer"))
+		return
+	}
+	service, errRead := ServiceRead(request.Context(), int32(sID))
+	if errRead != nil {
+		ResponseWithError(response, request, errRead)
+		return
+	}
+	writeJson(response, request, service)
+}
+
+// ServiceResolveHandler godoc
+//
+//	@Summary	catalog entry of a service name or alias
+//	@Tags		catalog
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		name	query		string	true	"service name or alias, case and repeated spaces are ignored"
+//	@Success	200		{object}	models.Service	"data found"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	404		{string}	string	"name is not in the catalog"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
+//	@Router		/service/resolve [get]
+func ServiceResolveHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "GET"})
+		return
+	}
+	name := request.URL.Query().Get("name")
+	if strings.TrimSpace(name) == "" {
+		ResponseWithError(response, request, fieldError("name", "query", "is empty"))
+		return
+	}
+	service, errResolve := ServiceResolve(request.Context(), name)
+	if errResolve != nil {
+		ResponseWithError(response, request, errResolve)
+		return
+	}
+	writeJson(response, request, service)
+}
+
+// ServiceListHandler godoc
+//
+//	@Summary	catalog entries
+//	@Tags		catalog
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Success	200		{array}		models.Service	"all entries ordered by name"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
+//	@Router		/service/list [get]
+func ServiceListHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "GET"})
+		return
+	}
+	services, errList := ServiceList(request.Context())
+	if errList != nil {
+		ResponseWithError(response, request, errList)
+		return
+	}
+	writeJson(response, request, services)
+}
+
+// ServiceUpdateHandler godoc
+//
+//	@Summary	catalog entry update
+//	@Tags		catalog
+//	@Accept		json
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		service	body		models.Service	true	"entry to update, its subscriptions are renamed to the new name"
+//	@Success	200		"updated, empty body"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields, name or aliases of another entry"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	404		{string}	string	"error"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
+//	@Router		/service/update [put]
+func ServiceUpdateHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPut {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "PUT"})
+		return
+	}
+	var service models.Service
+	if errBody := readJson(request, &service); errBody != nil {
+		ResponseWithError(response, request, errBody)
+		return
+	}
+	if errUpdate := ServiceUpdate(request.Context(), service); errUpdate != nil {
+		ResponseWithError(response, request, errUpdate)
+		return
+	}
+	WriteResponse(response, request, nil)
+}
+
+// ServiceDeleteHandler godoc
+//
+//	@Summary	catalog entry deleting
+//	@Tags		catalog
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		rowId	query		integer	true	"entry id"	minimum(1)
+//	@Success	200		"deleted, empty body, subscriptions keep their names"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
+//	@Router		/service/delete [delete]
+func ServiceDeleteHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodDelete {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "DELETE"})
+		return
+	}
+	sID, errParam := strconv.Atoi(request.URL.Query().Get("rowId"))
+	if errParam != nil || sID < 1 {
+		ResponseWithError(response, request, fieldError("rowId", "query", "must be a positive integer"))
+		return
+	}
+	if errDel := ServiceDelete(request.Context(), int32(sID)); errDel != nil {
+		ResponseWithError(response, request, errDel)
+		return
+	}
+	WriteResponse(response, request, nil)
+}
+
+// BudgetCreateHandler godoc
+//
+//	@Summary	budget creation
+//	@Tags		budgets
+//	@Accept		json
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		budget	body		models.Budget		true	"monthly budget of a user, service or tag, thresholds are percents of the amount"
+//	@Success	200		{integer}	integer		"id of the created budget"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields or the target already has a budget"
+//	@Failure	401		{string}	string		"error"
+//	@Failure	405		{string}	string		"error"
+//	@Failure	500		{string}	string		"error"
+//	@Failure	503		{string}	string		"error"
+//	@Failure	504		{string}	string		"error"
+//	@Router		/budget/create [post]
+func BudgetCreateHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "POST"})
+		return
+	}
+	var budget models.Budget
+	if errBody := readJson(request, &budget); errBody != nil {
+		ResponseWithError(response, request, errBody)
+		return
+	}
+	id, errAdd := BudgetCreate(request.Context(), budget)
+	if errAdd != nil {
+		ResponseWithError(response, request, errAdd)
+		return
+	}
+	WriteResponse(response, request, []byte(strconv.Itoa(int(*id))))
+}
+
+// BudgetListHandler godoc
+//
+//	@Summary	budgets with the spend of the current month
+//	@Tags		budgets
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Success	200		{array}		models.BudgetStatus	"month-to-date and projected spend of every budget"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
+//	@Router		/budget/list [get]
+func BudgetListHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "GET"})
+		return
+	}
+	statuses, errList := BudgetStatuses(request.Context(), models.Today())
+	if errList != nil {
+		ResponseWithError(response, request, errList)
+		return
+	}
+	writeJson(response, request, statuses)
+}
+
+// BudgetDeleteHandler godoc
+//
+//	@Summary	budget deleting
+//	@Tags		budgets
+//	@Produce	plain
+//	@Security	ApiKeyAuth
+//	@Param		rowId	query		integer	true	"budget id"	minimum(1)
+//	@Success	200		"deleted with its alerts, empty body"
+//	@Failure	400		{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401		{string}	string	"error"
+//	@Failure	405		{string}	string	"error"
+//	@Failure	500		{string}	string	"error"
+//	@Failure	503		{string}	string	"error"
+//	@Failure	504		{string}	string	"error"
+//	@Router		/budget/delete [delete]
+func BudgetDeleteHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodDelete {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "DELETE"})
+		return
+	}
+	bID, errParam := strconv.Atoi(request.URL.Query().Get("rowId"))
+	if errParam != nil || bID < 1 {
+		ResponseWithError(response, request, fieldError("rowId", "query", "must be a positive integer"))
+		return
+	}
+	if errDel := BudgetDelete(request.Context(), int32(bID)); errDel != nil {
+		ResponseWithError(response, request, errDel)
+		return
+	}
+	WriteResponse(response, request, nil)
+}
+
+// BudgetAlertsHandler godoc
+//
+//	@Summary	budget alerts
+//	@Tags		budgets
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		budgetId	query		integer	false	"only alerts of this budget"	minimum(1)
+//	@Success	200			{array}		models.BudgetAlert	"reached thresholds, newest first"
+//	@Failure	400			{object}	models.ValidationErrorResponse	"invalid fields"
+//	@Failure	401			{string}	string	"error"
+//	@Failure	405			{string}	string	"error"
+//	@Failure	500			{string}	string	"error"
+//	@Failure	503			{string}	string	"error"
+//	@Failure	504			{string}	string	"error"
+//	@Router		/budget/alerts [get]
+func BudgetAlertsHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		ResponseWithError(response, request, &models.MethodNotAllowedError{RequiredMethod: "GET"})
+		return
+	}
+	var budgetId *int32
+	if value := request.URL.Query().Get("budgetId"); value != "" {
+		bID, errParam := strconv.Atoi(value)
+		if errParam != nil || bID < 1 {
+			ResponseWithError(response, request, fieldError("budgetId", "query", "must be a positive integer"))
+			return
+		}
+		id := int32(bID)
+		budgetId = &id
+	}
+	alerts, errList := BudgetAlerts(request.Context(), budgetId)
+	if errList != nil {
+		ResponseWithError(response, request, errList)
+		return
+	}
+	writeJson(response, request, alerts)
+}
+
+// readSubscription decodes the json body of create/update requests
+func readSubscription(request *http.Request) (sbscr models.Subscription, err error) {
+	err = readJson(request, &sbscr)
+	return sbscr, err
+}
+
+// readJson decodes the json body of a request into value
+func readJson(request *http.Request, value any) (err error) {
+	_, span := tracing.Start(request.Context(), "parse request")
+	defer func() { tracing.End(span, err) }()
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(body, value); err != nil {
+		var (
+			jsonErr *models.JsonError
+			valErr  *models.ValidationError
+		)
+		if !errors.As(err, &jsonErr) && !errors.As(err, &valErr) {
+			err = &models.JsonError{Err: err}
+		}
+	}
+	return err
+}
+
+// writeJson encodes value and writes it as a successful response
+func writeJson(response http.ResponseWriter, request *http.Request, value any) {
+	_, span := tracing.Start(request.Context(), "encode response")
+	data, errJson := json.Marshal(value)
+	tracing.End(span, errJson)
+	if errJson != nil {
+		ResponseWithError(response, request, errJson)
+		return
+	}
+	response.Header().Set("Content-Type", "application/json")
+	WriteResponse(response, request, data)
 }
 
 func ResponseWithError(response http.ResponseWriter, request *http.Request, err error) {
@@ -225,11 +1233,16 @@ func ResponseWithError(response http.ResponseWriter, request *http.Request, err
 		paramErr       *models.InvalidParameterError
 		notFoundErr    *models.ResourceNotFoundError
 		wrongMethodErr *models.MethodNotAllowedError
+		canceledErr    *models.QueryCanceledError
 	)
 	switch {
+	case errors.As(err, &canceledErr) && errors.Is(err, context.DeadlineExceeded):
+		http.Error(response, err.Error(), http.StatusGatewayTimeout)
+	case errors.As(err, &canceledErr):
+		http.Error(response, err.Error(), http.StatusServiceUnavailable)
 	case errors.As(err, &valErr):
-	case errors.As(err, &jsonErr):
-	case errors.As(err, &paramErr):
+		writeValidationError(response, valErr)
+	case errors.As(err, &jsonErr), errors.As(err, &paramErr):
 		http.Error(response, err.Error(), http.StatusBadRequest)
 	case errors.As(err, &notFoundErr):
 		http.Error(response, err.Error(), http.StatusNotFound)
@@ -243,6 +1256,19 @@ func ResponseWithError(response http.ResponseWriter, request *http.Request, err
 	LogRequest(request, nil, err)
 }
 
+// writeValidationError responds with 400 and the per-field details of the error as json
+func writeValidationError(response http.ResponseWriter, err *models.ValidationError) {
+	data, _ := json.Marshal(models.ValidationErrorResponse{Message: "invalid request data", Fields: err.Fields()})
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(http.StatusBadRequest)
+	_, _ = response.Write(data)
+}
+
+// fieldError is a ValidationError of a single request field
+func fieldError(field string, in string, message string) error {
+	return &models.ValidationError{Errors: []error{&models.FieldError{Field: field, In: in, Message: message}}}
+}
+
 func WriteResponse(response http.ResponseWriter, request *http.Request, data []byte) {
 	response.WriteHeader(http.StatusOK)
 	_, _ = response.Write(data)
@@ -250,29 +1276,33 @@ func WriteResponse(response http.ResponseWriter, request *http.Request, data []b
 }
 
 func LogRequest(r *http.Request, data []byte, err error) {
+	attrs := []any{"method", r.Method, "remote_addr", r.RemoteAddr, "uri", r.RequestURI}
 	if err == nil {
-		log.Printf("%s: response [%s] %s %s with data %s\n", time.Now().Format("2006-01-02 15:04:05"), r.Method, r.RemoteAddr, r.RequestURI, data)
+		slog.InfoContext(r.Context(), "response", append(attrs, logging.Body(data))...)
 	} else {
-		log.Printf("%s: response [%s] %s %s with error %v\n", time.Now().Format("2006-01-02 15:04:05"), r.Method, r.RemoteAddr, r.RequestURI, err)
+		slog.WarnContext(r.Context(), "response with error", append(attrs, "error", err)...)
 	}
 }
 
 func getFilterParametersFromRequest(request *http.Request) (filterFrom *time.Time, filterTo *time.Time, userId *string, serviceName *string, err error) {
 	filterFromParam := request.FormValue("filterFrom")
 	filterToParam := request.FormValue("filterTo")
-	if filterFromParam == "" || filterToParam == "" {
-		return nil, nil, nil, nil, &models.InvalidParameterError{ParamName: "filter dates are empty"}
+	if filterFromParam == "" {
+		return nil, nil, nil, nil, fieldError("filterFrom", "formData", "is required")
+	}
+	if filterToParam == "" {
+		return nil, nil, nil, nil, fieldError("filterTo", "formData", "is required")
 	}
-	filterFromTime, errFrom := time.Parse("01-2006", filterFromParam)
-	filterToTime, errTo := time.Parse("01-2006", filterToParam)
-	if errFrom != nil || errTo != nil {
-		return nil, nil, nil, nil, &models.InvalidParameterError{ParamName: "cannot parse filter dates"}
+	filterFromTime, filterToTime, errPeriod := models.ParsePeriod(filterFromParam, filterToParam)
+	if errPeriod != nil {
+		return nil, nil, nil, nil, errPeriod
 	}
 	filterFrom = &filterFromTime
-	filterToTime = filterToTime.AddDate(0, 1, -1)
 	filterTo = &filterToTime
-	userIdParam := request.FormValue("userId")
-	if err := uuid.Validate(userIdParam); err == nil {
+	if userIdParam := request.FormValue("userId"); userIdParam != "" {
+		if err := uuid.Validate(userIdParam); err != nil {
+			return nil, nil, nil, nil, fieldError("userId", "formData", "must be uuid")
+		}
 		userId = &userIdParam
 	}
 	serviceNameParam := request.FormValue("serviceName")
@@ -0,0 +1,189 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/zakharova-e/subscriptions-info/internal/config"
+)
+
+// billing holds the settings applied by Configure
+var billing = config.Billing{TrialWarningDays: 7, DuplicateWarnings: true}
+
+// Configure applies the billing settings, it is called once on start
+func Configure(cfg config.Billing) {
+	billing = cfg
+}
+
+// DuplicateWarnings reports whether create and update look for duplicates of the written subscription
+func DuplicateWarnings() bool {
+	return billing.DuplicateWarnings
+}
+
+// TrialWarningDays is the default window for subscriptions with ending trials
+func TrialWarningDays() int {
+	return billing.TrialWarningDays
+}
+
+// SumMode selects how months a subscription was active only partly are charged
+type SumMode string
+
+const (
+	// SumModeMonthly charges every calendar month the subscription was active in at full price
+	SumModeMonthly SumMode = "monthly"
+	// SumModeProrated charges each billing period by the fraction of its days the subscription was active
+	SumModeProrated SumMode = "prorated"
+)
+
+// ParseSumMode accepts the api names of the modes, empty value means monthly
+func ParseSumMode(value string) (SumMode, error) {
+	switch SumMode(value) {
+	case "", SumModeMonthly:
+		return SumModeMonthly, nil
+	case SumModeProrated:
+		return SumModeProrated, nil
+	default:
+		return "", errors.New("must be monthly or prorated")
+	}
+}
+
+// Charge returns the cost of the subscription within the period after discounts, from and to days included
+func Charge(item Subscription, from time.Time, to time.Time, mode SumMode) float64 {
+	return ChargeTotals(item, from, to, mode).Net
+}
+
+// Totals is a charge before and after discounts
+type Totals struct {
+	Gross float64
+	Net   float64
+}
+
+func (t Totals) Add(other Totals) Totals {
+	return Totals{Gross: t.Gross + other.Gross, Net: t.Net + other.Net}
+}
+
+// Rounded converts the totals into whole amounts, the discount is the difference of the rounded ones
+func (t Totals) Rounded() SpendTotals {
+	gross, net := int(math.Round(t.Gross)), int(math.Round(t.Net))
+	return SpendTotals{Gross: gross, Discount: gross - net, Net: net}
+}
+
+// ChargeTotals returns the cost of the subscription within the period before and after discounts
+func ChargeTotals(item Subscription, from time.Time, to time.Time, mode SumMode) Totals {
+	activeFrom, activeTo, ok := activeWithin(item, from, to)
+	if !ok {
+		return Totals{}
+	}
+	if mode == SumModeProrated {
+		return proratedCharge(item, activeFrom, activeTo)
+	}
+	var total Totals
+	for month := billingDate(activeFrom.Year(), activeFrom.Month(), 1); !month.After(activeTo); month = month.AddDate(0, 1, 0) {
+		day, monthEnd := month, month.AddDate(0, 1, -1)
+		if activeFrom.After(day) {
+			day = activeFrom
+		}
+		if activeTo.Before(monthEnd) {
+			monthEnd = activeTo
+		}
+		// the month is charged the price of its first billed day, months paused entirely are free
+		for ; !day.After(monthEnd); day = day.AddDate(0, 0, 1) {
+			if !item.PausedAt(day) {
+				total = total.Add(Totals{Gross: float64(item.PriceAt(day)), Net: item.NetPriceAt(day)})
+				break
+			}
+		}
+	}
+	return total
+}
+
+// TrialEnd is the last day of the trial, ok is false for subscriptions without one
+func (s Subscription) TrialEnd() (_ time.Time, ok bool) {
+	return s.StartDate.AddDate(0, 0, s.TrialDays-1), s.TrialDays > 0
+}
+
+// PriceAt returns the monthly price in effect on the day: the trial price, then the intro price,
+// then the regular one with the price changes effective on the day
+func (s Subscription) PriceAt(day time.Time) int {
+	introStart, introEnd := s.introPeriod()
+	switch {
+	case day.Before(introStart):
+		return s.TrialPrice
+	case day.Before(introEnd):
+		return s.IntroPrice
+	default:
+		return s.regularPriceAt(day)
+	}
+}
+
+// introPeriod returns the first intro day and the first regular day after the intro months, months are added
+// like postgres adds intervals, an intro month from January 31 ends with February
+func (s Subscription) introPeriod() (time.Time, time.Time) {
+	start := s.StartDate.AddDate(0, 0, s.TrialDays)
+	return start, billingDate(start.Year(), start.Month()+time.Month(s.IntroMonths), start.Day())
+}
+
+// activeWithin clips the subscription dates to the period
+func activeWithin(item Subscription, from time.Time, to time.Time) (time.Time, time.Time, bool) {
+	activeFrom, activeTo := from, to
+	if item.StartDate.After(activeFrom) {
+		activeFrom = item.StartDate
+	}
+	if item.FinishDate.Valid && item.FinishDate.Time.Before(activeTo) {
+		activeTo = item.FinishDate.Time
+	}
+	return activeFrom, activeTo, !activeTo.Before(activeFrom)
+}
+
+// proratedCharge walks the billing periods, each one starts on the billing day and ends the day before the next one
+func proratedCharge(item Subscription, activeFrom time.Time, activeTo time.Time) Totals {
+	billingDay := item.BillingDay
+	if billingDay == 0 {
+		billingDay = item.StartDate.Day()
+	}
+	periodStart := billingDate(activeFrom.Year(), activeFrom.Month(), billingDay)
+	if periodStart.After(activeFrom) {
+		periodStart = billingDate(activeFrom.Year(), activeFrom.Month()-1, billingDay)
+	}
+	var total Totals
+	for !periodStart.After(activeTo) {
+		periodEnd := billingDate(periodStart.Year(), periodStart.Month()+1, billingDay)
+		overlapFrom, overlapTo := periodStart, periodEnd.AddDate(0, 0, -1)
+		if activeFrom.After(overlapFrom) {
+			overlapFrom = activeFrom
+		}
+		if activeTo.Before(overlapTo) {
+			overlapTo = activeTo
+		}
+		var charged Totals
+		for day := overlapFrom; !day.After(overlapTo); day = day.AddDate(0, 0, 1) {
+			if !item.PausedAt(day) {
+				charged = charged.Add(Totals{Gross: float64(item.PriceAt(day)), Net: item.NetPriceAt(day)})
+			}
+		}
+		days := float64(daysBetween(periodStart, periodEnd))
+		total = total.Add(Totals{Gross: charged.Gross / days, Net: charged.Net / days})
+		periodStart = periodEnd
+	}
+	return total
+}
+
+// billingDate is the billing day of the month, moved to the month end for short months
+func billingDate(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+func daysBetween(from time.Time, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// Today is the current date in UTC, the time zone dates are stored in
+func Today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,230 @@
+package models_test
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func date(value string) time.Time {
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func finish(value string) sql.NullTime {
+	return sql.NullTime{Valid: true, Time: date(value)}
+}
+
+func TestCharge(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     models.Subscription
+		from, to string
+		mode     models.SumMode
+		want     float64
+	}{
+		{"test monthly charges partial months in full",
+			models.Subscription{Price: 400, StartDate: date("2025-07-20"), BillingDay: 20},
+			"2025-07-01", "2025-12-31", models.SumModeMonthly, 2400},
+		{"test monthly with finish date",
+			models.Subscription{Price: 400, StartDate: date("2025-07-20"), FinishDate: finish("2025-08-05"), BillingDay: 20},
+			"2025-01-01", "2025-12-31", models.SumModeMonthly, 800},
+		{"test prorated charges the last period by days",
+			models.Subscription{Price: 400, StartDate: date("2025-07-20"), BillingDay: 20},
+			"2025-07-01", "2025-12-31", models.SumModeProrated, 2000 + 400*12.0/31},
+		{"test prorated with finish inside the period",
+			models.Subscription{Price: 400, StartDate: date("2025-07-01"), FinishDate: finish("2025-07-15"), BillingDay: 1},
+			"2025-07-01", "2025-07-31", models.SumModeProrated, 400 * 15.0 / 31},
+		{"test prorated billing day after the month end",
+			models.Subscription{Price: 400, StartDate: date("2025-01-31"), BillingDay: 31},
+			"2025-02-01", "2025-02-28", models.SumModeProrated, 400*27.0/28 + 400*1.0/31},
+		{"test prorated without billing day uses the start day",
+			models.Subscription{Price: 300, StartDate: date("2025-03-10")},
+			"2025-03-10", "2025-04-09", models.SumModeProrated, 300},
+		{"test monthly charges the price of the first active day during trial and intro",
+			models.Subscription{Price: 400, StartDate: date("2025-07-20"), BillingDay: 20, TrialDays: 14, IntroMonths: 2, IntroPrice: 200},
+			"2025-07-01", "2025-12-31", models.SumModeMonthly, 0 + 0 + 200 + 200 + 400 + 400},
+		{"test prorated free trial days",
+			models.Subscription{Price: 310, StartDate: date("2025-07-01"), BillingDay: 1, TrialDays: 10},
+			"2025-07-01", "2025-07-31", models.SumModeProrated, 310 * 21.0 / 31},
+		{"test monthly skips months paused entirely",
+			models.Subscription{Price: 400, StartDate: date("2025-07-01"), BillingDay: 1,
+				Pauses: []models.Pause{{StartDate: date("2025-08-01"), FinishDate: finish("2025-09-30")}}},
+			"2025-07-01", "2025-12-31", models.SumModeMonthly, 1600},
+		{"test monthly charges months paused partly",
+			models.Subscription{Price: 400, StartDate: date("2025-07-01"), BillingDay: 1,
+				Pauses: []models.Pause{{StartDate: date("2025-08-15"), FinishDate: finish("2025-09-14")}}},
+			"2025-07-01", "2025-12-31", models.SumModeMonthly, 2400},
+		{"test prorated skips paused days until resumed",
+			models.Subscription{Price: 310, StartDate: date("2025-07-01"), BillingDay: 1,
+				Pauses: []models.Pause{{StartDate: date("2025-07-11")}}},
+			"2025-07-01", "2025-12-31", models.SumModeProrated, 100},
+		{"test subscription outside the period",
+			models.Subscription{Price: 400, StartDate: date("2025-07-01"), FinishDate: finish("2025-07-31"), BillingDay: 1},
+			"2025-08-01", "2025-12-31", models.SumModeProrated, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := models.Charge(tt.item, date(tt.from), date(tt.to), tt.mode)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Charge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSumMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    models.SumMode
+		wantErr bool
+	}{
+		{"test empty value", "", models.SumModeMonthly, false},
+		{"test monthly", "monthly", models.SumModeMonthly, false},
+		{"test prorated", "prorated", models.SumModeProrated, false},
+		{"test unknown mode", "daily", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := models.ParseSumMode(tt.value)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseSumMode() = %v, %v, want %v, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseDates(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		wantStart  string
+		wantFinish string
+		wantErr    bool
+	}{
+		{"test iso date", "2025-07-20", "2025-07-20", "2025-07-20", false},
+		{"test month fallback", "07-2025", "2025-07-01", "2025-07-31", false},
+		{"test month fallback in february", "02-2024", "2024-02-01", "2024-02-29", false},
+		{"test invalid date", "2025-07", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, errStart := models.ParseStartDate(tt.value)
+			finish, errFinish := models.ParseFinishDate(tt.value)
+			if tt.wantErr {
+				if errStart == nil || errFinish == nil {
+					t.Errorf("want errors, got %v, %v", errStart, errFinish)
+				}
+				return
+			}
+			if errStart != nil || errFinish != nil {
+				t.Fatalf("unexpected errors %v, %v", errStart, errFinish)
+			}
+			if got := models.FormatDate(start); got != tt.wantStart {
+				t.Errorf("ParseStartDate() = %v, want %v", got, tt.wantStart)
+			}
+			if got := models.FormatDate(finish.Time); !finish.Valid || got != tt.wantFinish {
+				t.Errorf("ParseFinishDate() = %v, want %v", got, tt.wantFinish)
+			}
+		})
+	}
+}
+
+func TestSubscription_PriceAt(t *testing.T) {
+	// trial from 2025-07-20 to 2025-08-02, intro from 2025-08-03 to 2025-10-02
+	item := models.Subscription{Price: 400, StartDate: date("2025-07-20"), TrialDays: 14, TrialPrice: 50, IntroMonths: 2, IntroPrice: 200,
+		PriceChanges: []models.PriceChange{{EffectiveDate: date("2025-09-01"), Price: 500}}}
+	tests := []struct {
+		name string
+		item models.Subscription
+		day  string
+		want int
+	}{
+		{"test with first trial day", item, "2025-07-20", 50},
+		{"test with last trial day", item, "2025-08-02", 50},
+		{"test with intro start", item, "2025-08-03", 200},
+		{"test with price change during intro", item, "2025-09-01", 200},
+		{"test with last intro day", item, "2025-10-02", 200},
+		{"test with intro end", item, "2025-10-03", 500},
+		{"test without trial", models.Subscription{Price: 400, StartDate: date("2025-07-20"), IntroMonths: 1, IntroPrice: 200},
+			"2025-07-20", 200},
+		{"test without trial and intro", models.Subscription{Price: 400, StartDate: date("2025-07-20")}, "2025-07-20", 400},
+		{"test with intro month ending in february", models.Subscription{Price: 400, StartDate: date("2025-01-31"), IntroMonths: 1, IntroPrice: 200},
+			"2025-02-27", 200},
+		{"test with intro month ended in february", models.Subscription{Price: 400, StartDate: date("2025-01-31"), IntroMonths: 1, IntroPrice: 200},
+			"2025-02-28", 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.PriceAt(date(tt.day)); got != tt.want {
+				t.Errorf("PriceAt(%s) = %d, want %d", tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscription_TrialEnd(t *testing.T) {
+	tests := []struct {
+		name      string
+		trialDays int
+		want      string
+		wantOk    bool
+	}{
+		{"test without trial", 0, "", false},
+		{"test with one day trial", 1, "2025-07-20", true},
+		{"test with trial across months", 14, "2025-08-02", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := models.Subscription{Price: 400, StartDate: date("2025-07-20"), TrialDays: tt.trialDays}
+			got, ok := item.TrialEnd()
+			if ok != tt.wantOk || ok && models.FormatDate(got) != tt.want {
+				t.Errorf("TrialEnd() = %s, %v, want %s, %v", models.FormatDate(got), ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestSubscription_IsValidTrial(t *testing.T) {
+	tests := []struct {
+		name                                           string
+		trialDays, trialPrice, introMonths, introPrice int
+		want                                           []string
+	}{
+		{"test without trial and intro", 0, 0, 0, 0, nil},
+		{"test with free trial and intro", 14, 0, 3, 200, nil},
+		{"test with negative trial days", -1, 0, 0, 0, []string{"trial_days: must not be negative"}},
+		{"test with negative trial price", 14, -50, 0, 0, []string{"trial_price: must not be negative"}},
+		{"test with negative intro months", 0, 0, -3, 200, []string{"intro_months: must not be negative"}},
+		{"test with negative intro price", 0, 0, 3, -200, []string{"intro_price: must not be negative"}},
+		{"test with intro months without intro price", 14, 0, 3, 0,
+			[]string{"intro_price: is required when intro_months is set, use trial_days for free periods"}},
+		{"test with intro price without intro months", 0, 0, 0, 200, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := models.Subscription{ServiceName: "Yandex Plus", Price: 400, UserId: "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+				StartDate: date("2025-07-20"), BillingDay: 20,
+				TrialDays: tt.trialDays, TrialPrice: tt.trialPrice, IntroMonths: tt.introMonths, IntroPrice: tt.introPrice}
+			var got []string
+			var vErr *models.ValidationError
+			if err := item.IsValid(); errors.As(err, &vErr) {
+				for _, field := range vErr.Fields() {
+					got = append(got, field.Field+": "+field.Message)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IsValid() messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
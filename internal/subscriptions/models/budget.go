@@ -0,0 +1,212 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BudgetScope tells which subscriptions a budget covers
+type BudgetScope string
+
+const (
+	// BudgetUser covers the subscriptions of the user id in Target
+	BudgetUser BudgetScope = "user"
+	// BudgetService covers the subscriptions of the service in Target, aliases of the catalog included
+	BudgetService BudgetScope = "service"
+	// BudgetTag covers the subscriptions tagged with Target
+	BudgetTag BudgetScope = "tag"
+)
+
+// defaultThresholds are the alert thresholds of budgets created without them
+var defaultThresholds = []int{80, 100}
+
+// Budget is a monthly spend limit, an alert is raised once a month for every threshold the projected spend reaches
+type Budget struct {
+	Id     int32       `json:"id" example:"1"`
+	Scope  BudgetScope `json:"scope" validate:"required" enums:"user,service,tag" example:"user"`
+	Target string      `json:"target" validate:"required" minLength:"1" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	Amount int         `json:"amount" validate:"required" minimum:"1" example:"5000"`
+	// Thresholds are percents of Amount, 80 and 100 when empty
+	Thresholds []int `json:"thresholds" example:"80,100"`
+}
+
+// BudgetStatus is the spend of the current month against a budget
+type BudgetStatus struct {
+	Budget Budget `json:"budget"`
+	// Month is the first day of the evaluated month
+	Month time.Time `json:"month" swaggertype:"string" format:"billing-date" example:"2025-09-01"`
+	// Spent is accrued from the first day of the month to today, prorated by days
+	Spent int `json:"spent" example:"3200"`
+	// Projected is charged for the whole month like monthly sums
+	Projected int `json:"projected" example:"4100"`
+	// Reached are the thresholds the projected spend has reached
+	Reached []int `json:"reached" example:"80"`
+}
+
+// BudgetAlert records that a threshold of a budget was reached in a month
+type BudgetAlert struct {
+	Id        int32       `json:"id" example:"1"`
+	BudgetId  int32       `json:"budget_id" example:"1"`
+	Scope     BudgetScope `json:"scope" enums:"user,service,tag" example:"user"`
+	Target    string      `json:"target" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	Month     time.Time   `json:"month" swaggertype:"string" format:"billing-date" example:"2025-09-01"`
+	Threshold int         `json:"threshold" example:"80"`
+	Amount    int         `json:"amount" example:"5000"`
+	Spent     int         `json:"spent" example:"3200"`
+	Projected int         `json:"projected" example:"4100"`
+	CreatedAt time.Time   `json:"created_at" example:"2025-09-12T10:00:00Z"`
+}
+
+// override json marshaling
+func (s *BudgetStatus) MarshalJSON() ([]byte, error) {
+	type status BudgetStatus
+	res, err := json.Marshal(struct {
+		*status
+		Month string `json:"month"`
+	}{(*status)(s), FormatDate(s.Month)})
+	if err != nil {
+		err = &JsonError{Err: err}
+	}
+	return res, err
+}
+
+// override json unmarshaling
+func (s *BudgetStatus) UnmarshalJSON(body []byte) error {
+	type status BudgetStatus
+	temp := struct {
+		*status
+		Month string `json:"month"`
+	}{status: (*status)(s)}
+	if err := json.Unmarshal(body, &temp); err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	month, err := time.Parse(dateLayout, temp.Month)
+	if err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	s.Month = month
+	return nil
+}
+
+// override json marshaling
+func (a *BudgetAlert) MarshalJSON() ([]byte, error) {
+	type alert BudgetAlert
+	res, err := json.Marshal(struct {
+		*alert
+		Month string `json:"month"`
+	}{(*alert)(a), FormatDate(a.Month)})
+	if err != nil {
+		err = &JsonError{Err: err}
+	}
+	return res, err
+}
+
+// override json unmarshaling
+func (a *BudgetAlert) UnmarshalJSON(body []byte) error {
+	type alert BudgetAlert
+	temp := struct {
+		*alert
+		Month string `json:"month"`
+	}{alert: (*alert)(a)}
+	if err := json.Unmarshal(body, &temp); err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	month, err := time.Parse(dateLayout, temp.Month)
+	if err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	a.Month = month
+	return nil
+}
+
+// Message describes the alert for notifications
+func (a BudgetAlert) Message() string {
+	return fmt.Sprintf("%s %s is projected to spend %d of its %d budget in %s, %d%% threshold reached",
+		a.Scope, a.Target, a.Projected, a.Amount, a.Month.Format("01-2006"), a.Threshold)
+}
+
+// Normalize trims the target, lowercases tags and sorts the thresholds
+func (b *Budget) Normalize() {
+	b.Target = strings.Join(strings.Fields(b.Target), " ")
+	if b.Scope == BudgetTag {
+		b.Target = strings.ToLower(b.Target)
+	}
+	if len(b.Thresholds) == 0 {
+		b.Thresholds = slices.Clone(defaultThresholds)
+	}
+	slices.Sort(b.Thresholds)
+	b.Thresholds = slices.Compact(b.Thresholds)
+}
+
+func (b Budget) IsValid() error {
+	var vErr ValidationError
+	switch b.Scope {
+	case BudgetUser:
+		if err := uuid.Validate(b.Target); err != nil {
+			vErr.Errors = append(vErr.Errors, &FieldError{Field: "target", In: "body", Message: "must be uuid for user budgets"})
+		}
+	case BudgetService:
+		if b.Target == "" {
+			vErr.Errors = append(vErr.Errors, &FieldError{Field: "target", In: "body", Message: "is empty"})
+		}
+	case BudgetTag:
+		if !tagPattern.MatchString(b.Target) {
+			vErr.Errors = append(vErr.Errors, &FieldError{Field: "target", In: "body", Message: "must be a tag for tag budgets"})
+		}
+	default:
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "scope", In: "body", Message: "must be user, service or tag"})
+	}
+	if b.Amount < 1 {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "amount", In: "body", Message: "must be positive"})
+	}
+	for i, threshold := range b.Thresholds {
+		if threshold < 1 || threshold > 1000 {
+			vErr.Errors = append(vErr.Errors, &FieldError{Field: fmt.Sprintf("thresholds[%d]", i), In: "body", Message: "must be between 1 and 1000 percent"})
+		}
+	}
+	if len(vErr.Errors) == 0 {
+		return nil
+	}
+	return &vErr
+}
+
+// covers reports whether the item counts against the budget, serviceKeys are the match keys of a service target
+func (b Budget) covers(item Subscription, serviceKeys map[string]bool) bool {
+	switch b.Scope {
+	case BudgetUser:
+		return item.UserId == b.Target
+	case BudgetService:
+		return serviceKeys[ServiceKey(item.ServiceName)]
+	case BudgetTag:
+		return slices.Contains(item.Tags, b.Target)
+	}
+	return false
+}
+
+// EvaluateBudget charges the items covered by the budget in the month of today like SubscriptionSum, prorated up to today
+// and monthly for the whole month, serviceKeys are the name and the catalog aliases of a service target
+func EvaluateBudget(budget Budget, items []Subscription, serviceKeys map[string]bool, today time.Time) BudgetStatus {
+	month := MonthOf(today)
+	monthEnd := month.AddDate(0, 1, -1)
+	var spent, projected float64
+	for _, item := range items {
+		if !budget.covers(item, serviceKeys) {
+			continue
+		}
+		spent += Charge(item, month, today, SumModeProrated)
+		projected += Charge(item, month, monthEnd, SumModeMonthly)
+	}
+	status := BudgetStatus{Budget: budget, Month: month, Spent: int(math.Round(spent)), Projected: int(math.Round(projected)), Reached: []int{}}
+	for _, threshold := range budget.Thresholds {
+		if status.Projected*100 >= budget.Amount*threshold {
+			status.Reached = append(status.Reached, threshold)
+		}
+	}
+	return status
+}
@@ -0,0 +1,77 @@
+package models_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func TestBudget_IsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget models.Budget
+		want   []string
+	}{
+		{"test user budget", models.Budget{Scope: models.BudgetUser, Target: "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+			Amount: 5000, Thresholds: []int{80, 100}}, nil},
+		{"test tag budget", models.Budget{Scope: models.BudgetTag, Target: "team:platform", Amount: 100000}, nil},
+		{"test user budget without uuid", models.Budget{Scope: models.BudgetUser, Target: "alice", Amount: 5000},
+			[]string{"must be uuid for user budgets"}},
+		{"test unknown scope and zero amount", models.Budget{Scope: "team", Target: "platform"},
+			[]string{"must be user, service or tag", "must be positive"}},
+		{"test threshold out of range", models.Budget{Scope: models.BudgetService, Target: "Netflix", Amount: 1000,
+			Thresholds: []int{0, 80}}, []string{"must be between 1 and 1000 percent"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			var vErr *models.ValidationError
+			if err := tt.budget.IsValid(); errors.As(err, &vErr) {
+				for _, field := range vErr.Fields() {
+					got = append(got, field.Message)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IsValid() messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateBudget(t *testing.T) {
+	user := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	items := []models.Subscription{
+		{ServiceName: "Netflix", Price: 800, UserId: user, StartDate: date("2025-01-01"), BillingDay: 1, Tags: []string{"home"}},
+		{ServiceName: "AWS", Price: 3000, UserId: user, StartDate: date("2025-01-01"), BillingDay: 1, Tags: []string{"team:platform"}},
+		{ServiceName: "netflix", Price: 500, UserId: "0b6f4a36-7d5e-4a57-9a8e-3a1f1ad3d0d2", StartDate: date("2025-02-01"), BillingDay: 1},
+	}
+	tests := []struct {
+		name          string
+		budget        models.Budget
+		serviceKeys   map[string]bool
+		wantSpent     int
+		wantProjected int
+		wantReached   []int
+	}{
+		{"test user budget", models.Budget{Scope: models.BudgetUser, Target: user,
+			Amount: 4000, Thresholds: []int{80, 100}}, nil, 1520, 3800, []int{80}},
+		{"test service budget matches every spelling", models.Budget{Scope: models.BudgetService, Target: "Netflix",
+			Amount: 1300, Thresholds: []int{100}}, map[string]bool{"netflix": true}, 520, 1300, []int{100}},
+		{"test tag budget under the thresholds", models.Budget{Scope: models.BudgetTag, Target: "team:platform",
+			Amount: 10000, Thresholds: []int{50, 80}}, nil, 1200, 3000, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := models.EvaluateBudget(tt.budget, items, tt.serviceKeys, date("2025-09-12"))
+			if got.Spent != tt.wantSpent || got.Projected != tt.wantProjected || !reflect.DeepEqual(got.Reached, tt.wantReached) {
+				t.Errorf("EvaluateBudget() = spent %d, projected %d, reached %v, want %d, %d, %v",
+					got.Spent, got.Projected, got.Reached, tt.wantSpent, tt.wantProjected, tt.wantReached)
+			}
+			if got.Month != date("2025-09-01") {
+				t.Errorf("EvaluateBudget() month = %v, want 2025-09-01", got.Month)
+			}
+		})
+	}
+}
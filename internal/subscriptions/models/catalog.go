@@ -0,0 +1,92 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Service is a catalog entry, subscriptions whose service name matches its name or one of its aliases
+// reference it and are stored under the canonical name
+type Service struct {
+	Id       int32    `json:"id" example:"1"`
+	Name     string   `json:"name" validate:"required" minLength:"1" example:"Netflix"`
+	Aliases  []string `json:"aliases" example:"Netflix Inc,netflix.com"`
+	Category string   `json:"category" example:"streaming"`
+	// VendorURL is the site of the vendor, empty when unknown
+	VendorURL string `json:"vendor_url" example:"https://www.netflix.com"`
+	Plans     []Plan `json:"plans"`
+}
+
+// Plan is a default plan of a catalog service with its monthly price
+type Plan struct {
+	Name  string `json:"name" validate:"required" minLength:"1" example:"Standard"`
+	Price int    `json:"price" minimum:"0" example:"799"`
+}
+
+// ServiceKey is the form names are matched in, case and repeated spaces do not matter
+func ServiceKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// ServiceKeySQL computes ServiceKey of the column in sql
+func ServiceKeySQL(column string) string {
+	return fmt.Sprintf(`lower(regexp_replace(btrim(%s), '\s+', ' ', 'g'))`, column)
+}
+
+// Keys returns the distinct match keys of the name and the aliases
+func (s Service) Keys() []string {
+	keys := []string{ServiceKey(s.Name)}
+	seen := map[string]bool{keys[0]: true}
+	for _, alias := range s.Aliases {
+		if key := ServiceKey(alias); !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Normalize trims the names and drops empty aliases and aliases repeating the name
+func (s *Service) Normalize() {
+	s.Name = strings.Join(strings.Fields(s.Name), " ")
+	s.Category = strings.ToLower(strings.TrimSpace(s.Category))
+	s.VendorURL = strings.TrimSpace(s.VendorURL)
+	aliases := []string{}
+	seen := map[string]bool{ServiceKey(s.Name): true}
+	for _, alias := range s.Aliases {
+		alias = strings.Join(strings.Fields(alias), " ")
+		if key := ServiceKey(alias); key != "" && !seen[key] {
+			seen[key] = true
+			aliases = append(aliases, alias)
+		}
+	}
+	s.Aliases = aliases
+	if s.Plans == nil {
+		s.Plans = []Plan{}
+	}
+}
+
+func (s Service) IsValid() error {
+	var vErr ValidationError
+	if s.Name == "" {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "name", In: "body", Message: "is empty"})
+	}
+	if s.VendorURL != "" {
+		if parsed, err := url.Parse(s.VendorURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			vErr.Errors = append(vErr.Errors, &FieldError{Field: "vendor_url", In: "body", Message: "must be an http or https url"})
+		}
+	}
+	for i, plan := range s.Plans {
+		if strings.TrimSpace(plan.Name) == "" {
+			vErr.Errors = append(vErr.Errors, &FieldError{Field: fmt.Sprintf("plans[%d].name", i), In: "body", Message: "is empty"})
+		}
+		if plan.Price < 0 {
+			vErr.Errors = append(vErr.Errors, &FieldError{Field: fmt.Sprintf("plans[%d].price", i), In: "body", Message: "must not be negative"})
+		}
+	}
+	if len(vErr.Errors) == 0 {
+		return nil
+	}
+	return &vErr
+}
@@ -0,0 +1,40 @@
+package models_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func TestService_IsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		service models.Service
+		want    []string
+	}{
+		{"test full entry", models.Service{Name: "Netflix", Aliases: []string{"Netflix Inc"}, Category: "streaming",
+			VendorURL: "https://www.netflix.com", Plans: []models.Plan{{Name: "Standard", Price: 799}}}, nil},
+		{"test name only", models.Service{Name: "Yandex Plus"}, nil},
+		{"test empty name", models.Service{}, []string{"name is empty"}},
+		{"test vendor url without scheme", models.Service{Name: "Netflix", VendorURL: "netflix.com"},
+			[]string{"vendor_url must be an http or https url"}},
+		{"test invalid plans", models.Service{Name: "Netflix", Plans: []models.Plan{{Name: " ", Price: -1}}},
+			[]string{"plans[0].name is empty", "plans[0].price must not be negative"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			var vErr *models.ValidationError
+			if err := tt.service.IsValid(); errors.As(err, &vErr) {
+				for _, field := range vErr.Fields() {
+					got = append(got, field.Field+" "+field.Message)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IsValid() messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
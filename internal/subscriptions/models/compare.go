@@ -0,0 +1,208 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// CompareAgainst picks the base period of a comparison when it is not given explicitly
+type CompareAgainst string
+
+const (
+	// ComparePrevious is the period of the same length right before the current one
+	ComparePrevious CompareAgainst = "previous"
+	// CompareYear is the same period a year earlier
+	CompareYear CompareAgainst = "year"
+)
+
+// ParseCompareAgainst checks the base of a comparison, empty means previous
+func ParseCompareAgainst(value string) (CompareAgainst, error) {
+	switch CompareAgainst(value) {
+	case "", ComparePrevious:
+		return ComparePrevious, nil
+	case CompareYear:
+		return CompareYear, nil
+	default:
+		return "", errors.New("must be previous or year")
+	}
+}
+
+// BasePeriod returns the period compared with from..to, periods of whole months are shifted by months
+// so that September is compared with August and not with the last 30 days of August
+func BasePeriod(from time.Time, to time.Time, against CompareAgainst) (time.Time, time.Time) {
+	wholeMonths := from.Day() == 1 && to.AddDate(0, 0, 1).Day() == 1
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+	switch {
+	case against == CompareYear && wholeMonths:
+		return from.AddDate(-1, 0, 0), MonthOf(to).AddDate(-1, 1, -1)
+	case against == CompareYear:
+		return from.AddDate(-1, 0, 0), to.AddDate(-1, 0, 0)
+	case wholeMonths:
+		return from.AddDate(0, -months, 0), from.AddDate(0, 0, -1)
+	default:
+		return from.AddDate(0, 0, -daysBetween(from, to)-1), from.AddDate(0, 0, -1)
+	}
+}
+
+// ComparisonKind explains the difference of a subscription between the periods
+type ComparisonKind string
+
+const (
+	// ComparisonStarted is billed in the current period only
+	ComparisonStarted ComparisonKind = "started"
+	// ComparisonEnded is billed in the base period only
+	ComparisonEnded ComparisonKind = "ended"
+	// ComparisonPriceChanged is billed in both periods with different charges: a new price, the end of a trial
+	// or of intro months, a discount or a pause
+	ComparisonPriceChanged ComparisonKind = "price_changed"
+	// ComparisonUnchanged is billed in both periods with the same charge
+	ComparisonUnchanged ComparisonKind = "unchanged"
+)
+
+var comparisonKinds = []ComparisonKind{ComparisonStarted, ComparisonEnded, ComparisonPriceChanged, ComparisonUnchanged}
+
+// Period is the days from From to To, both included
+type Period struct {
+	From time.Time `json:"from" swaggertype:"string" format:"billing-date" example:"2025-09-01"`
+	To   time.Time `json:"to" swaggertype:"string" format:"billing-date" example:"2025-09-30"`
+}
+
+// override json marshaling
+func (p *Period) MarshalJSON() ([]byte, error) {
+	res, err := json.Marshal(struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}{FormatDate(p.From), FormatDate(p.To)})
+	if err != nil {
+		err = &JsonError{Err: err}
+	}
+	return res, err
+}
+
+// override json unmarshaling
+func (p *Period) UnmarshalJSON(body []byte) error {
+	var temp struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+	if err := json.Unmarshal(body, &temp); err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	from, to, err := ParsePeriod(temp.From, temp.To)
+	if err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	p.From, p.To = from, to
+	return nil
+}
+
+// Comparison is the spend of the current period against the base one, the attribution explains the net delta
+type Comparison struct {
+	Base          Period      `json:"base"`
+	Current       Period      `json:"current"`
+	BaseTotals    SpendTotals `json:"base_totals"`
+	CurrentTotals SpendTotals `json:"current_totals"`
+	// Delta is CurrentTotals minus BaseTotals
+	Delta SpendTotals `json:"delta"`
+	// Percent is the net delta relative to the base net spend, null when the base spend is zero
+	Percent *float64 `json:"percent" example:"12.5"`
+	// Attribution has every kind in the order started, ended, price_changed, unchanged,
+	// the deltas add up to the net delta
+	Attribution   []ComparisonGroup   `json:"attribution"`
+	Subscriptions []SubscriptionDelta `json:"subscriptions"`
+}
+
+// ComparisonGroup sums the net charges of the subscriptions of a kind
+type ComparisonGroup struct {
+	Kind          ComparisonKind `json:"kind" enums:"started,ended,price_changed,unchanged" example:"started"`
+	Subscriptions int            `json:"subscriptions" example:"2"`
+	Base          int            `json:"base" example:"0"`
+	Current       int            `json:"current" example:"800"`
+	Delta         int            `json:"delta" example:"800"`
+}
+
+// SubscriptionDelta is the net charge of a subscription in both periods
+type SubscriptionDelta struct {
+	SubscriptionId int32          `json:"subscription_id" example:"1"`
+	ServiceName    string         `json:"service_name" example:"Yandex Plus"`
+	UserId         string         `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	Kind           ComparisonKind `json:"kind" enums:"started,ended,price_changed,unchanged" example:"price_changed"`
+	Base           int            `json:"base" example:"400"`
+	Current        int            `json:"current" example:"500"`
+	Delta          int            `json:"delta" example:"100"`
+}
+
+// BuildComparison charges the items in both periods like SubscriptionSum, subscriptions active in neither
+// are skipped, the subscriptions are ordered by kind and then by the size of the delta
+func BuildComparison(items []Subscription, base Period, current Period, mode SumMode) Comparison {
+	var baseTotal, currentTotal Totals
+	groups := map[ComparisonKind]*ComparisonGroup{}
+	for _, kind := range comparisonKinds {
+		groups[kind] = &ComparisonGroup{Kind: kind}
+	}
+	comparison := Comparison{Base: base, Current: current, Subscriptions: []SubscriptionDelta{}}
+	for _, item := range items {
+		_, _, inBase := activeWithin(item, base.From, base.To)
+		_, _, inCurrent := activeWithin(item, current.From, current.To)
+		if !inBase && !inCurrent {
+			continue
+		}
+		baseCharge, currentCharge := ChargeTotals(item, base.From, base.To, mode), ChargeTotals(item, current.From, current.To, mode)
+		baseTotal, currentTotal = baseTotal.Add(baseCharge), currentTotal.Add(currentCharge)
+		delta := SubscriptionDelta{SubscriptionId: item.Id, ServiceName: item.ServiceName, UserId: item.UserId,
+			Base: int(math.Round(baseCharge.Net)), Current: int(math.Round(currentCharge.Net))}
+		delta.Delta = delta.Current - delta.Base
+		switch {
+		case !inBase:
+			delta.Kind = ComparisonStarted
+		case !inCurrent:
+			delta.Kind = ComparisonEnded
+		case math.Abs(currentCharge.Net-baseCharge.Net) > 1e-9:
+			delta.Kind = ComparisonPriceChanged
+		default:
+			delta.Kind = ComparisonUnchanged
+		}
+		group := groups[delta.Kind]
+		group.Subscriptions++
+		group.Base += delta.Base
+		group.Current += delta.Current
+		group.Delta += delta.Delta
+		comparison.Subscriptions = append(comparison.Subscriptions, delta)
+	}
+	// the totals add up the rounded charges of the subscriptions, so the attribution explains the delta exactly
+	for _, kind := range comparisonKinds {
+		group := groups[kind]
+		comparison.Attribution = append(comparison.Attribution, *group)
+		comparison.BaseTotals.Net += group.Base
+		comparison.CurrentTotals.Net += group.Current
+	}
+	comparison.BaseTotals.Gross = int(math.Round(baseTotal.Gross))
+	comparison.BaseTotals.Discount = comparison.BaseTotals.Gross - comparison.BaseTotals.Net
+	comparison.CurrentTotals.Gross = int(math.Round(currentTotal.Gross))
+	comparison.CurrentTotals.Discount = comparison.CurrentTotals.Gross - comparison.CurrentTotals.Net
+	comparison.Delta = SpendTotals{Gross: comparison.CurrentTotals.Gross - comparison.BaseTotals.Gross,
+		Discount: comparison.CurrentTotals.Discount - comparison.BaseTotals.Discount,
+		Net:      comparison.CurrentTotals.Net - comparison.BaseTotals.Net}
+	if comparison.BaseTotals.Net != 0 {
+		percent := math.Round(float64(comparison.Delta.Net)*1000/float64(comparison.BaseTotals.Net)) / 10
+		comparison.Percent = &percent
+	}
+	order := map[ComparisonKind]int{}
+	for i, kind := range comparisonKinds {
+		order[kind] = i
+	}
+	sort.SliceStable(comparison.Subscriptions, func(i, j int) bool {
+		a, b := comparison.Subscriptions[i], comparison.Subscriptions[j]
+		if a.Kind != b.Kind {
+			return order[a.Kind] < order[b.Kind]
+		}
+		if math.Abs(float64(a.Delta)) != math.Abs(float64(b.Delta)) {
+			return math.Abs(float64(a.Delta)) > math.Abs(float64(b.Delta))
+		}
+		return a.SubscriptionId < b.SubscriptionId
+	})
+	return comparison
+}
@@ -0,0 +1,69 @@
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func TestBasePeriod(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		against  models.CompareAgainst
+		wantFrom string
+		wantTo   string
+	}{
+		{"test previous month", "2025-10-01", "2025-10-31", models.ComparePrevious, "2025-09-01", "2025-09-30"},
+		{"test previous quarter", "2025-07-01", "2025-09-30", models.ComparePrevious, "2025-04-01", "2025-06-30"},
+		{"test previous days", "2025-10-10", "2025-10-19", models.ComparePrevious, "2025-09-30", "2025-10-09"},
+		{"test leap february a year earlier", "2024-02-01", "2024-02-29", models.CompareYear, "2023-02-01", "2023-02-28"},
+		{"test days a year earlier", "2025-10-10", "2025-10-19", models.CompareYear, "2024-10-10", "2024-10-19"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := models.BasePeriod(date(tt.from), date(tt.to), tt.against)
+			if !from.Equal(date(tt.wantFrom)) || !to.Equal(date(tt.wantTo)) {
+				t.Errorf("BasePeriod() = %s..%s, want %s..%s", models.FormatDate(from), models.FormatDate(to), tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestBuildComparison(t *testing.T) {
+	items := []models.Subscription{
+		{Id: 1, ServiceName: "Netflix", Price: 800, StartDate: date("2025-01-01"), BillingDay: 1},
+		{Id: 2, ServiceName: "Yandex Plus", Price: 400, StartDate: date("2025-10-05"), BillingDay: 5},
+		{Id: 3, ServiceName: "Kinopoisk", Price: 300, StartDate: date("2025-01-01"), FinishDate: finish("2025-09-20"), BillingDay: 1},
+		{Id: 4, ServiceName: "AWS", Price: 1000, StartDate: date("2025-01-01"), BillingDay: 1,
+			PriceChanges: []models.PriceChange{{EffectiveDate: date("2025-10-01"), Price: 1200}}},
+		{Id: 5, ServiceName: "Okko", Price: 500, StartDate: date("2024-01-01"), FinishDate: finish("2025-08-31"), BillingDay: 1},
+	}
+	base := models.Period{From: date("2025-09-01"), To: date("2025-09-30")}
+	current := models.Period{From: date("2025-10-01"), To: date("2025-10-31")}
+	got := models.BuildComparison(items, base, current, models.SumModeMonthly)
+
+	if got.BaseTotals.Net != 2100 || got.CurrentTotals.Net != 2400 || got.Delta.Net != 300 {
+		t.Errorf("BuildComparison() totals = %+v, %+v, delta %+v, want 2100, 2400, 300", got.BaseTotals, got.CurrentTotals, got.Delta)
+	}
+	if got.Percent == nil || *got.Percent != 14.3 {
+		t.Errorf("BuildComparison() percent = %v, want 14.3", got.Percent)
+	}
+	wantAttribution := []models.ComparisonGroup{
+		{Kind: models.ComparisonStarted, Subscriptions: 1, Base: 0, Current: 400, Delta: 400},
+		{Kind: models.ComparisonEnded, Subscriptions: 1, Base: 300, Current: 0, Delta: -300},
+		{Kind: models.ComparisonPriceChanged, Subscriptions: 1, Base: 1000, Current: 1200, Delta: 200},
+		{Kind: models.ComparisonUnchanged, Subscriptions: 1, Base: 800, Current: 800, Delta: 0},
+	}
+	if !reflect.DeepEqual(got.Attribution, wantAttribution) {
+		t.Errorf("BuildComparison() attribution = %+v, want %+v", got.Attribution, wantAttribution)
+	}
+	var ids []int32
+	for _, delta := range got.Subscriptions {
+		ids = append(ids, delta.SubscriptionId)
+	}
+	if !reflect.DeepEqual(ids, []int32{2, 3, 4, 1}) {
+		t.Errorf("BuildComparison() subscriptions = %v, want [2 3 4 1]", ids)
+	}
+}
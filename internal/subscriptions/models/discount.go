@@ -0,0 +1,150 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DiscountKind tells how Discount.Amount is applied to the price
+type DiscountKind string
+
+const (
+	// DiscountPercent takes Amount percent off the price
+	DiscountPercent DiscountKind = "percent"
+	// DiscountFixed takes Amount off the price, the price does not go below zero
+	DiscountFixed DiscountKind = "fixed"
+)
+
+// Discount lowers the monthly price of a subscription for Months calendar months from StartMonth,
+// a discount with zero Months lasts until it is deleted
+type Discount struct {
+	Id             int32        `json:"id" example:"1"`
+	SubscriptionId int32        `json:"subscription_id" validate:"required" minimum:"1" example:"1"`
+	Kind           DiscountKind `json:"kind" validate:"required" enums:"percent,fixed" example:"percent"`
+	Amount         int          `json:"amount" validate:"required" minimum:"1" example:"20"`
+	StartMonth     time.Time    `json:"start_month" validate:"required" swaggertype:"string" format:"billing-date" example:"2025-09-01"`
+	Months         int          `json:"months" minimum:"0" example:"3"`
+	// Code is the coupon or promo code the discount came from, informational only
+	Code string `json:"code" example:"AUTUMN20"`
+}
+
+// SpendTotals splits spend into the price before discounts, the discounts and the charged amount
+type SpendTotals struct {
+	Gross    int `json:"gross" example:"2400"`
+	Discount int `json:"discount" example:"240"`
+	Net      int `json:"net" example:"2160"`
+}
+
+// override json marshaling
+func (d *Discount) MarshalJSON() ([]byte, error) {
+	res, err := json.Marshal(struct {
+		Id             int32        `json:"id"`
+		SubscriptionId int32        `json:"subscription_id"`
+		Kind           DiscountKind `json:"kind"`
+		Amount         int          `json:"amount"`
+		StartMonth     string       `json:"start_month"`
+		Months         int          `json:"months"`
+		Code           string       `json:"code"`
+	}{d.Id, d.SubscriptionId, d.Kind, d.Amount, FormatDate(d.StartMonth), d.Months, d.Code})
+	if err != nil {
+		err = &JsonError{Err: err}
+	}
+	return res, err
+}
+
+// override json unmarshaling
+func (d *Discount) UnmarshalJSON(body []byte) error {
+	var temp struct {
+		Id             int32        `json:"id"`
+		SubscriptionId int32        `json:"subscription_id"`
+		Kind           DiscountKind `json:"kind"`
+		Amount         int          `json:"amount"`
+		StartMonth     string       `json:"start_month"`
+		Months         int          `json:"months"`
+		Code           string       `json:"code"`
+	}
+	if err := json.Unmarshal(body, &temp); err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	d.Id, d.SubscriptionId = temp.Id, temp.SubscriptionId
+	d.Kind, d.Amount, d.Months, d.Code = temp.Kind, temp.Amount, temp.Months, temp.Code
+	startMonth, errDate := ParseStartDate(temp.StartMonth)
+	if errDate != nil {
+		return &ValidationError{Errors: []error{&FieldError{Field: "start_month", In: "body", Message: errDate.Error()}}}
+	}
+	d.StartMonth = MonthOf(startMonth)
+	return nil
+}
+
+// MonthOf is the first day of the month of the day
+func MonthOf(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// covers reports whether the discount is in effect in the month of the day
+func (d Discount) covers(day time.Time) bool {
+	month := MonthOf(day)
+	return !month.Before(d.StartMonth) && (d.Months == 0 || month.Before(d.StartMonth.AddDate(0, d.Months, 0)))
+}
+
+// apply returns the price after the discount
+func (d Discount) apply(price int) float64 {
+	if d.Kind == DiscountPercent {
+		return float64(price) * float64(100-d.Amount) / 100
+	}
+	return float64(max(price-d.Amount, 0))
+}
+
+// NetPriceAt returns PriceAt after the discount in effect in the month of the day
+func (s Subscription) NetPriceAt(day time.Time) float64 {
+	price := s.PriceAt(day)
+	for _, discount := range s.Discounts {
+		if discount.covers(day) {
+			return discount.apply(price)
+		}
+	}
+	return float64(price)
+}
+
+// IsValid checks the fields of the discount itself, overlaps are checked with the subscription
+func (d Discount) IsValid() error {
+	var vErr ValidationError
+	switch d.Kind {
+	case DiscountPercent:
+		if d.Amount < 1 || d.Amount > 100 {
+			vErr.Errors = append(vErr.Errors, &FieldError{Field: "amount", In: "body", Message: "must be between 1 and 100 for percent discounts"})
+		}
+	case DiscountFixed:
+		if d.Amount < 1 {
+			vErr.Errors = append(vErr.Errors, &FieldError{Field: "amount", In: "body", Message: "must be positive"})
+		}
+	default:
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "kind", In: "body", Message: "must be percent or fixed"})
+	}
+	if d.Months < 0 {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "months", In: "body", Message: "must not be negative"})
+	}
+	if d.StartMonth.Year() < 2020 {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "start_month", In: "body", Message: "incorrect date, years after 2020 accepted"})
+	}
+	if len(vErr.Errors) == 0 {
+		return nil
+	}
+	return &vErr
+}
+
+// DiscountErrors checks that no two discounts are in effect in the same month
+func (s Subscription) DiscountErrors() []error {
+	var errs []error
+	discounts := append([]Discount(nil), s.Discounts...)
+	sort.Slice(discounts, func(i, j int) bool { return discounts[i].StartMonth.Before(discounts[j].StartMonth) })
+	for i := 1; i < len(discounts); i++ {
+		if discounts[i-1].covers(discounts[i].StartMonth) {
+			errs = append(errs, &FieldError{Field: "discounts", In: "body",
+				Message: fmt.Sprintf("discount from %s overlaps the previous discount", FormatDate(discounts[i].StartMonth))})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,124 @@
+package models_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func TestChargeTotals(t *testing.T) {
+	tests := []struct {
+		name      string
+		discounts []models.Discount
+		mode      models.SumMode
+		wantGross float64
+		wantNet   float64
+	}{
+		{"test without discounts", nil, models.SumModeMonthly, 2400, 2400},
+		{"test percent discount for limited months",
+			[]models.Discount{{Kind: models.DiscountPercent, Amount: 25, StartMonth: date("2025-08-01"), Months: 2}},
+			models.SumModeMonthly, 2400, 2400 - 2*100},
+		{"test fixed discount until deleted",
+			[]models.Discount{{Kind: models.DiscountFixed, Amount: 150, StartMonth: date("2025-11-01")}},
+			models.SumModeMonthly, 2400, 2400 - 2*150},
+		{"test fixed discount above the price is free",
+			[]models.Discount{{Kind: models.DiscountFixed, Amount: 1000, StartMonth: date("2025-12-01"), Months: 1}},
+			models.SumModeMonthly, 2400, 2000},
+		{"test prorated discount by days of the discounted month",
+			[]models.Discount{{Kind: models.DiscountPercent, Amount: 50, StartMonth: date("2025-07-01"), Months: 1}},
+			models.SumModeProrated, 2400, 2400 - 200*10.0/31 - 200*21.0/31},
+	}
+	item := models.Subscription{Price: 400, StartDate: date("2025-07-01"), BillingDay: 1}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item.Discounts = tt.discounts
+			got := models.ChargeTotals(item, date("2025-07-01"), date("2025-12-31"), tt.mode)
+			if math.Abs(got.Gross-tt.wantGross) > 1e-9 || math.Abs(got.Net-tt.wantNet) > 1e-9 {
+				t.Errorf("ChargeTotals() = %+v, want gross %v, net %v", got, tt.wantGross, tt.wantNet)
+			}
+		})
+	}
+}
+
+func TestTotals_Rounded(t *testing.T) {
+	got := models.Totals{Gross: 1000.4, Net: 899.6}.Rounded()
+	want := models.SpendTotals{Gross: 1000, Discount: 100, Net: 900}
+	if got != want {
+		t.Errorf("Rounded() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDiscount_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		discount models.Discount
+		want     []string
+	}{
+		{"test percent discount", models.Discount{Kind: models.DiscountPercent, Amount: 100, StartMonth: date("2025-07-01")}, nil},
+		{"test fixed discount", models.Discount{Kind: models.DiscountFixed, Amount: 5000, StartMonth: date("2025-07-01"), Months: 3}, nil},
+		{"test percent above 100", models.Discount{Kind: models.DiscountPercent, Amount: 120, StartMonth: date("2025-07-01")},
+			[]string{"must be between 1 and 100 for percent discounts"}},
+		{"test unknown kind and negative months", models.Discount{Kind: "coupon", Amount: 10, StartMonth: date("2025-07-01"), Months: -1},
+			[]string{"must be percent or fixed", "must not be negative"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			var vErr *models.ValidationError
+			if err := tt.discount.IsValid(); errors.As(err, &vErr) {
+				for _, field := range vErr.Fields() {
+					got = append(got, field.Message)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IsValid() messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscription_IsValidDiscounts(t *testing.T) {
+	item := models.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserId:      "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		StartDate:   date("2025-07-01"),
+		BillingDay:  1,
+	}
+	tests := []struct {
+		name      string
+		discounts []models.Discount
+		want      []string
+	}{
+		{"test consecutive discounts", []models.Discount{
+			{Kind: models.DiscountFixed, Amount: 100, StartMonth: date("2025-10-01")},
+			{Kind: models.DiscountPercent, Amount: 10, StartMonth: date("2025-07-01"), Months: 3},
+		}, nil},
+		{"test overlapping discounts", []models.Discount{
+			{Kind: models.DiscountPercent, Amount: 10, StartMonth: date("2025-07-01"), Months: 4},
+			{Kind: models.DiscountFixed, Amount: 100, StartMonth: date("2025-10-01")},
+		}, []string{"discount from 2025-10-01 overlaps the previous discount"}},
+		{"test after a discount until deleted", []models.Discount{
+			{Kind: models.DiscountPercent, Amount: 10, StartMonth: date("2025-07-01")},
+			{Kind: models.DiscountFixed, Amount: 100, StartMonth: date("2026-01-01"), Months: 1},
+		}, []string{"discount from 2026-01-01 overlaps the previous discount"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item.Discounts = tt.discounts
+			var got []string
+			var vErr *models.ValidationError
+			if err := item.IsValid(); errors.As(err, &vErr) {
+				for _, field := range vErr.Fields() {
+					got = append(got, field.Message)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IsValid() messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
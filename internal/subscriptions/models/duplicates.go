@@ -0,0 +1,373 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DuplicateMatch tells how the services of two duplicates were matched
+type DuplicateMatch string
+
+const (
+	// DuplicateSameService is the same catalog entry or the same name up to case and spaces
+	DuplicateSameService DuplicateMatch = "same_service"
+	// DuplicateSimilarName is a name differing in punctuation, company suffixes or a typo
+	DuplicateSimilarName DuplicateMatch = "similar_name"
+)
+
+// noiseWords are dropped from service names before they are compared for duplicates
+var noiseWords = map[string]bool{"the": true, "inc": true, "llc": true, "ltd": true, "www": true, "com": true, "ru": true}
+
+// duplicateKey is the form names are compared in for duplicates: letters and digits only, without
+// company and domain suffixes, so "Yandex.Plus" and "yandex plus" get the same key
+func duplicateKey(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	var key strings.Builder
+	for _, word := range words {
+		if !noiseWords[word] {
+			key.WriteString(word)
+		}
+	}
+	if key.Len() == 0 {
+		return strings.Join(words, "")
+	}
+	return key.String()
+}
+
+// editDistance is the levenshtein distance of the runes of a and b
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		diagonal := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			diagonal, row[j] = row[j], min(row[j]+1, row[j-1]+1, diagonal+cost)
+		}
+	}
+	return row[len(rb)]
+}
+
+// MatchServices reports whether two subscriptions are for the same service, entries of the catalog
+// are trusted, so different catalog entries never match
+func MatchServices(a Subscription, b Subscription) (DuplicateMatch, bool) {
+	if a.ServiceId != nil && b.ServiceId != nil {
+		return DuplicateSameService, *a.ServiceId == *b.ServiceId
+	}
+	if ServiceKey(a.ServiceName) == ServiceKey(b.ServiceName) {
+		return DuplicateSameService, true
+	}
+	keyA, keyB := duplicateKey(a.ServiceName), duplicateKey(b.ServiceName)
+	// one edit is allowed for every five letters of the shorter key
+	if keyA != "" && editDistance(keyA, keyB) <= min(len([]rune(keyA)), len([]rune(keyB)))/5 {
+		return DuplicateSimilarName, true
+	}
+	return "", false
+}
+
+// Overlap is a pair of duplicates of the same user billed on the same days from From to To,
+// To is not set when neither of them finishes
+type Overlap struct {
+	SubscriptionIds [2]int32       `json:"subscription_ids" example:"1,2"`
+	Match           DuplicateMatch `json:"match" enums:"same_service,similar_name" example:"same_service"`
+	From            time.Time      `json:"from" swaggertype:"string" format:"billing-date" example:"2025-09-01"`
+	To              sql.NullTime   `json:"to" swaggertype:"string" format:"billing-date" example:"2025-12-31" extensions:"x-nullable"`
+}
+
+// override json marshaling
+func (o *Overlap) MarshalJSON() ([]byte, error) {
+	var to *string
+	if o.To.Valid {
+		toFormatted := FormatDate(o.To.Time)
+		to = &toFormatted
+	}
+	res, err := json.Marshal(struct {
+		SubscriptionIds [2]int32       `json:"subscription_ids"`
+		Match           DuplicateMatch `json:"match"`
+		From            string         `json:"from"`
+		To              *string        `json:"to"`
+	}{o.SubscriptionIds, o.Match, FormatDate(o.From), to})
+	if err != nil {
+		err = &JsonError{Err: err}
+	}
+	return res, err
+}
+
+// override json unmarshaling
+func (o *Overlap) UnmarshalJSON(body []byte) error {
+	var temp struct {
+		SubscriptionIds [2]int32       `json:"subscription_ids"`
+		Match           DuplicateMatch `json:"match"`
+		From            string         `json:"from"`
+		To              string         `json:"to"`
+	}
+	if err := json.Unmarshal(body, &temp); err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	o.SubscriptionIds, o.Match = temp.SubscriptionIds, temp.Match
+	var errDate error
+	if o.From, errDate = ParseStartDate(temp.From); errDate != nil {
+		return &JsonError{Err: errDate, Json: string(body)}
+	}
+	if o.To, errDate = ParseFinishDate(temp.To); errDate != nil {
+		return &JsonError{Err: errDate, Json: string(body)}
+	}
+	return nil
+}
+
+// DuplicateGroup is a set of subscriptions of a user for the same service connected by overlaps,
+// they are double counted by the sums on the overlapping days
+type DuplicateGroup struct {
+	UserId string `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	// ServiceName is the name of the earliest subscription
+	ServiceName string `json:"service_name" example:"Yandex Plus"`
+	// Subscriptions are ordered by start date
+	Subscriptions []Subscription `json:"subscriptions"`
+	Overlaps      []Overlap      `json:"overlaps"`
+}
+
+// overlapOf returns the overlap of the lifetimes of two duplicates
+func overlapOf(a Subscription, b Subscription) (Overlap, bool) {
+	match, ok := MatchServices(a, b)
+	if !ok || a.UserId != b.UserId || a.Id == b.Id {
+		return Overlap{}, false
+	}
+	overlap := Overlap{SubscriptionIds: [2]int32{a.Id, b.Id}, Match: match, From: a.StartDate}
+	if b.StartDate.After(overlap.From) {
+		overlap.From = b.StartDate
+	}
+	for _, finish := range []sql.NullTime{a.FinishDate, b.FinishDate} {
+		if finish.Valid && (!overlap.To.Valid || finish.Time.Before(overlap.To.Time)) {
+			overlap.To = finish
+		}
+	}
+	return overlap, !overlap.To.Valid || !overlap.To.Time.Before(overlap.From)
+}
+
+// FindDuplicates groups the items overlapping another item of the same user and service,
+// groups are ordered by user and by their first subscription
+func FindDuplicates(items []Subscription) []DuplicateGroup {
+	sorted := append([]Subscription(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].UserId != sorted[j].UserId {
+			return sorted[i].UserId < sorted[j].UserId
+		}
+		if !sorted[i].StartDate.Equal(sorted[j].StartDate) {
+			return sorted[i].StartDate.Before(sorted[j].StartDate)
+		}
+		return sorted[i].Id < sorted[j].Id
+	})
+	// every index points to the first subscription of its group
+	root := make([]int, len(sorted))
+	for i := range root {
+		root[i] = i
+	}
+	find := func(i int) int {
+		for root[i] != i {
+			i = root[i]
+		}
+		return i
+	}
+	overlaps := map[int][]Overlap{}
+	for i := range sorted {
+		for j := i + 1; j < len(sorted) && sorted[j].UserId == sorted[i].UserId; j++ {
+			overlap, ok := overlapOf(sorted[i], sorted[j])
+			if !ok {
+				continue
+			}
+			ri, rj := find(i), find(j)
+			if ri != rj {
+				root[max(ri, rj)] = min(ri, rj)
+				overlaps[min(ri, rj)] = append(overlaps[min(ri, rj)], overlaps[max(ri, rj)]...)
+				delete(overlaps, max(ri, rj))
+			}
+			overlaps[min(ri, rj)] = append(overlaps[min(ri, rj)], overlap)
+		}
+	}
+	groups := []DuplicateGroup{}
+	index := map[int]int{}
+	for i, item := range sorted {
+		r := find(i)
+		if _, ok := overlaps[r]; !ok {
+			continue
+		}
+		if _, ok := index[r]; !ok {
+			index[r] = len(groups)
+			groups = append(groups, DuplicateGroup{UserId: item.UserId, ServiceName: item.ServiceName, Overlaps: overlaps[r]})
+		}
+		group := &groups[index[r]]
+		group.Subscriptions = append(group.Subscriptions, item)
+	}
+	for _, group := range groups {
+		sort.Slice(group.Overlaps, func(i, j int) bool {
+			if !group.Overlaps[i].From.Equal(group.Overlaps[j].From) {
+				return group.Overlaps[i].From.Before(group.Overlaps[j].From)
+			}
+			return group.Overlaps[i].SubscriptionIds[1] < group.Overlaps[j].SubscriptionIds[1]
+		})
+	}
+	return groups
+}
+
+// DuplicatesOf returns the overlaps of the item with the others, the item comes first in every pair
+func DuplicatesOf(item Subscription, others []Subscription) []Overlap {
+	overlaps := []Overlap{}
+	for _, other := range others {
+		if overlap, ok := overlapOf(item, other); ok {
+			overlaps = append(overlaps, overlap)
+		}
+	}
+	return overlaps
+}
+
+// MergeRequest names the subscription that stays and the duplicates merged into it
+type MergeRequest struct {
+	Into int32   `json:"into" validate:"required" minimum:"1" example:"1"`
+	Ids  []int32 `json:"ids" validate:"required" example:"2,3"`
+}
+
+// MergedRecord is a subscription deleted by a merge, Record keeps it with its pauses, discounts and price changes
+type MergedRecord struct {
+	Id             int32        `json:"id" example:"1"`
+	SubscriptionId int32        `json:"subscription_id" example:"1"`
+	MergedId       int32        `json:"merged_id" example:"2"`
+	Record         Subscription `json:"record"`
+	MergedAt       time.Time    `json:"merged_at" example:"2025-10-19T12:00:00Z"`
+}
+
+// MergeSubscriptions combines the duplicates into the survivor. The lifetime covers all of them and the days
+// none of them was billed become pauses. The start, billing day, trial and intro come from the earliest of them,
+// the regular price of a day is the one of the survivor when it is active and otherwise of the duplicate billed
+// that day, so the price changes keep the charges of every record. The discounts of the duplicates move to the survivor.
+func MergeSubscriptions(survivor Subscription, duplicates []Subscription) Subscription {
+	all := append([]Subscription{survivor}, duplicates...)
+	merged := survivor
+	earliest := survivor
+	for _, item := range duplicates {
+		if item.StartDate.Before(earliest.StartDate) {
+			earliest = item
+		}
+		if !item.FinishDate.Valid || merged.FinishDate.Valid && item.FinishDate.Time.After(merged.FinishDate.Time) {
+			merged.FinishDate = item.FinishDate
+		}
+		merged.Tags = append(append([]string(nil), merged.Tags...), item.Tags...)
+		for _, discount := range item.Discounts {
+			discount.SubscriptionId = survivor.Id
+			merged.Discounts = append(append([]Discount(nil), merged.Discounts...), discount)
+		}
+	}
+	merged.Tags = normalizeTags(merged.Tags)
+	merged.StartDate, merged.BillingDay = earliest.StartDate, earliest.BillingDay
+	merged.TrialDays, merged.TrialPrice = earliest.TrialDays, earliest.TrialPrice
+	merged.IntroMonths, merged.IntroPrice = earliest.IntroMonths, earliest.IntroPrice
+	merged.Price, merged.PriceChanges = mergedPrices(survivor, all, merged.StartDate)
+	merged.Pauses = unbilledPauses(merged, all)
+	return merged
+}
+
+// mergedPrices returns the regular price from start and the changes of it, a day is priced by the survivor
+// when it is active, otherwise by the item active that day, days without any keep the previous price
+func mergedPrices(survivor Subscription, items []Subscription, start time.Time) (int, []PriceChange) {
+	active := func(item Subscription, day time.Time) bool {
+		return !day.Before(item.StartDate) && (!item.FinishDate.Valid || !day.After(item.FinishDate.Time))
+	}
+	owner := survivor
+	priceAt := func(day time.Time) int {
+		if !active(owner, day) || owner.Id != survivor.Id && active(survivor, day) {
+			for _, item := range items {
+				if active(item, day) {
+					owner = item
+					break
+				}
+			}
+		}
+		return owner.regularPriceAt(day)
+	}
+	// the price can only change on a start, on the day after a finish or on a price change
+	var days []time.Time
+	for _, item := range items {
+		days = append(days, item.StartDate)
+		if item.FinishDate.Valid {
+			days = append(days, item.FinishDate.Time.AddDate(0, 0, 1))
+		}
+		for _, change := range item.PriceChanges {
+			days = append(days, change.EffectiveDate)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	price := priceAt(start)
+	changes := []PriceChange{}
+	current := price
+	for i, day := range days {
+		if !day.After(start) || i > 0 && day.Equal(days[i-1]) {
+			continue
+		}
+		if next := priceAt(day); next != current {
+			changes = append(changes, PriceChange{SubscriptionId: survivor.Id, EffectiveDate: day, Price: next})
+			current = next
+		}
+	}
+	return price, changes
+}
+
+// unbilledPauses returns pauses of merged covering the days on which none of the items is billed
+func unbilledPauses(merged Subscription, items []Subscription) []Pause {
+	// after the last date any of the items changes on, every day is billed like the last one
+	last := merged.StartDate
+	later := func(day time.Time) {
+		if day.After(last) {
+			last = day
+		}
+	}
+	for _, item := range items {
+		later(item.StartDate)
+		if item.FinishDate.Valid {
+			later(item.FinishDate.Time.AddDate(0, 0, 1))
+		}
+		for _, pause := range item.Pauses {
+			later(pause.StartDate)
+			if pause.FinishDate.Valid {
+				later(pause.FinishDate.Time.AddDate(0, 0, 1))
+			}
+		}
+	}
+	if merged.FinishDate.Valid && merged.FinishDate.Time.Before(last) {
+		last = merged.FinishDate.Time
+	}
+	billed := func(day time.Time) bool {
+		for _, item := range items {
+			active := !day.Before(item.StartDate) && (!item.FinishDate.Valid || !day.After(item.FinishDate.Time))
+			if active && !item.PausedAt(day) {
+				return true
+			}
+		}
+		return false
+	}
+	pauses := []Pause{}
+	var open *Pause
+	for day := merged.StartDate; !day.After(last); day = day.AddDate(0, 0, 1) {
+		switch isBilled := billed(day); {
+		case !isBilled && open == nil:
+			pauses = append(pauses, Pause{SubscriptionId: merged.Id, StartDate: day})
+			open = &pauses[len(pauses)-1]
+		case isBilled && open != nil:
+			open.FinishDate = sql.NullTime{Valid: true, Time: day.AddDate(0, 0, -1)}
+			open = nil
+		}
+	}
+	if open != nil && merged.FinishDate.Valid {
+		open.FinishDate = merged.FinishDate
+	}
+	return pauses
+}
@@ -0,0 +1,144 @@
+package models_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	alice, bob := "60601fee-2bf1-4721-ae6f-7636e79a0cba", "0b6f4a36-7d5e-4a57-9a8e-3a1f1ad3d0d2"
+	music, musik := int32(7), int32(8)
+	items := []models.Subscription{
+		{Id: 1, UserId: alice, ServiceName: "Yandex Plus", StartDate: date("2025-01-01")},
+		{Id: 2, UserId: alice, ServiceName: "yandex.plus", StartDate: date("2025-06-01"), FinishDate: finish("2025-08-31")},
+		{Id: 3, UserId: alice, ServiceName: "Yandex  Plus", StartDate: date("2025-09-15")},
+		{Id: 4, UserId: alice, ServiceName: "Netflix", StartDate: date("2024-01-01"), FinishDate: finish("2024-12-31")},
+		{Id: 5, UserId: alice, ServiceName: "Netflix Inc", StartDate: date("2025-01-01")},
+		{Id: 6, UserId: alice, ServiceName: "Apple Music", ServiceId: &music, StartDate: date("2025-01-01")},
+		{Id: 7, UserId: alice, ServiceName: "Apple Musik", ServiceId: &musik, StartDate: date("2025-01-01")},
+		{Id: 8, UserId: bob, ServiceName: "Yandex Plus", StartDate: date("2025-01-01")},
+		{Id: 9, UserId: bob, ServiceName: "Kinopoisk", StartDate: date("2025-01-01")},
+		{Id: 10, UserId: bob, ServiceName: "Kinopoisk HD", StartDate: date("2025-02-01")},
+		{Id: 11, UserId: bob, ServiceName: "Kinopoysk", StartDate: date("2025-03-01")},
+	}
+	got := models.FindDuplicates(items)
+
+	type overlap struct {
+		ids   [2]int32
+		match models.DuplicateMatch
+		from  string
+		to    string
+	}
+	tests := []struct {
+		name     string
+		user     string
+		ids      []int32
+		overlaps []overlap
+	}{
+		{"test typo of the name", bob, []int32{9, 11}, []overlap{{[2]int32{9, 11}, models.DuplicateSimilarName, "2025-03-01", ""}}},
+		{"test punctuation and spaces", alice, []int32{1, 2, 3}, []overlap{
+			{[2]int32{1, 2}, models.DuplicateSimilarName, "2025-06-01", "2025-08-31"},
+			{[2]int32{1, 3}, models.DuplicateSameService, "2025-09-15", ""},
+		}},
+	}
+	if len(got) != len(tests) {
+		t.Fatalf("FindDuplicates() = %d groups, want %d: %+v", len(got), len(tests), got)
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := got[i]
+			var ids []int32
+			for _, item := range group.Subscriptions {
+				ids = append(ids, item.Id)
+			}
+			var overlaps []overlap
+			for _, o := range group.Overlaps {
+				to := ""
+				if o.To.Valid {
+					to = models.FormatDate(o.To.Time)
+				}
+				overlaps = append(overlaps, overlap{o.SubscriptionIds, o.Match, models.FormatDate(o.From), to})
+			}
+			if group.UserId != tt.user || !reflect.DeepEqual(ids, tt.ids) || !reflect.DeepEqual(overlaps, tt.overlaps) {
+				t.Errorf("group = %s %v %+v, want %s %v %+v", group.UserId, ids, overlaps, tt.user, tt.ids, tt.overlaps)
+			}
+		})
+	}
+}
+
+func TestMergeSubscriptions(t *testing.T) {
+	user := "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+	tests := []struct {
+		name       string
+		survivor   models.Subscription
+		duplicates []models.Subscription
+		wantStart  string
+		wantPauses [][2]string
+		wantPrices []int
+		// wantDiscounts are the start months of the discounts of the merged subscription
+		wantDiscounts []string
+		wantCharge    float64
+	}{
+		{"test overlapping duplicate is absorbed",
+			models.Subscription{Id: 1, ServiceName: "Netflix", Price: 500, UserId: user, StartDate: date("2025-01-01"), BillingDay: 1},
+			[]models.Subscription{{Id: 2, ServiceName: "Netflix", Price: 500, UserId: user, StartDate: date("2025-03-01"),
+				FinishDate: finish("2025-04-30"), BillingDay: 1}},
+			"2025-01-01", nil, nil, nil, 12 * 500},
+		// without overlaps the merged subscription is charged what both were charged
+		{"test earlier duplicate keeps its price and the gap is paused",
+			models.Subscription{Id: 2, ServiceName: "Yandex Plus", Price: 500, UserId: user, StartDate: date("2025-06-01"), BillingDay: 1,
+				Tags: []string{"home"}, PriceChanges: []models.PriceChange{{SubscriptionId: 2, EffectiveDate: date("2025-09-01"), Price: 600}}},
+			[]models.Subscription{{Id: 1, ServiceName: "yandex.plus", Price: 400, UserId: user, StartDate: date("2025-01-01"),
+				FinishDate: finish("2025-03-31"), BillingDay: 1, TrialDays: 14, Tags: []string{"team:platform"},
+				Discounts: []models.Discount{{SubscriptionId: 1, Kind: models.DiscountPercent, Amount: 50,
+					StartMonth: date("2025-02-01"), Months: 2}}}},
+			"2025-01-01", [][2]string{{"2025-04-01", "2025-05-31"}}, []int{500, 600}, []string{"2025-02-01"}, 0 + 2*200 + 3*500 + 4*600},
+		{"test later duplicate keeps its price, pauses, price changes and discounts",
+			models.Subscription{Id: 1, ServiceName: "Netflix", Price: 500, UserId: user, StartDate: date("2025-01-01"),
+				FinishDate: finish("2025-04-30"), BillingDay: 1, Discounts: []models.Discount{{SubscriptionId: 1,
+					Kind: models.DiscountFixed, Amount: 100, StartMonth: date("2025-02-01"), Months: 1}}},
+			[]models.Subscription{{Id: 2, ServiceName: "Netflix", Price: 600, UserId: user, StartDate: date("2025-05-01"), BillingDay: 1,
+				Pauses:       []models.Pause{{SubscriptionId: 2, StartDate: date("2025-07-01"), FinishDate: finish("2025-07-31")}},
+				PriceChanges: []models.PriceChange{{SubscriptionId: 2, EffectiveDate: date("2025-10-01"), Price: 700}},
+				Discounts: []models.Discount{{SubscriptionId: 2, Kind: models.DiscountPercent, Amount: 50,
+					StartMonth: date("2025-11-01"), Months: 1}}}},
+			"2025-01-01", [][2]string{{"2025-07-01", "2025-07-31"}}, []int{600, 700}, []string{"2025-02-01", "2025-11-01"},
+			500 + 400 + 500 + 500 + 600 + 600 + 0 + 600 + 600 + 700 + 350 + 700},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := models.MergeSubscriptions(tt.survivor, tt.duplicates)
+			if err := got.IsValid(); err != nil {
+				t.Fatalf("merged IsValid() = %v", err)
+			}
+			var pauses [][2]string
+			for _, pause := range got.Pauses {
+				pauses = append(pauses, [2]string{models.FormatDate(pause.StartDate), models.FormatDate(pause.FinishDate.Time)})
+			}
+			var prices []int
+			for _, change := range got.PriceChanges {
+				prices = append(prices, change.Price)
+			}
+			var discounts []string
+			for _, discount := range got.Discounts {
+				if discount.SubscriptionId != tt.survivor.Id {
+					t.Errorf("discount from %s belongs to %d", models.FormatDate(discount.StartMonth), discount.SubscriptionId)
+				}
+				discounts = append(discounts, models.FormatDate(discount.StartMonth))
+			}
+			if models.FormatDate(got.StartDate) != tt.wantStart || got.FinishDate.Valid || got.Id != tt.survivor.Id ||
+				!reflect.DeepEqual(pauses, tt.wantPauses) || !reflect.DeepEqual(prices, tt.wantPrices) ||
+				!reflect.DeepEqual(discounts, tt.wantDiscounts) {
+				t.Errorf("MergeSubscriptions() = start %s, finish %v, pauses %v, price changes %v, discounts %v",
+					models.FormatDate(got.StartDate), got.FinishDate, pauses, prices, discounts)
+			}
+			charge := models.Charge(got, date("2025-01-01"), date("2025-12-31"), models.SumModeMonthly)
+			if math.Abs(charge-tt.wantCharge) > 1e-9 {
+				t.Errorf("merged Charge() = %v, want %v", charge, tt.wantCharge)
+			}
+		})
+	}
+}
@@ -21,6 +21,37 @@ func (err *ValidationError) Unwrap() error {
 	return errors.Join(err.Errors...)
 }
 
+// Fields returns the per-field details of the errors, errors not bound to a field get an empty Field
+func (err *ValidationError) Fields() []FieldError {
+	fields := make([]FieldError, 0, len(err.Errors))
+	for _, e := range err.Errors {
+		var fieldErr *FieldError
+		if errors.As(e, &fieldErr) {
+			fields = append(fields, *fieldErr)
+		} else {
+			fields = append(fields, FieldError{Message: e.Error()})
+		}
+	}
+	return fields
+}
+
+// FieldError describes one invalid value of a request, In is query, formData or body
+type FieldError struct {
+	Field   string `json:"field"`
+	In      string `json:"in"`
+	Message string `json:"message"`
+}
+
+func (err *FieldError) Error() string {
+	return fmt.Sprintf("%s %s", err.Field, err.Message)
+}
+
+// ValidationErrorResponse is the json body of 400 responses caused by a ValidationError
+type ValidationErrorResponse struct {
+	Message string       `json:"message" example:"invalid request data"`
+	Fields  []FieldError `json:"fields"`
+}
+
 type ResourceNotFoundError struct {
 	Err error
 }
@@ -77,3 +108,16 @@ func (err *InvalidParameterError) Error() string {
 func (err *InvalidParameterError) Unwrap() error {
 	return nil
 }
+
+// QueryCanceledError means the query was interrupted by its context: the client went away or the timeout elapsed
+type QueryCanceledError struct {
+	Operation string
+	Err       error
+}
+
+func (err *QueryCanceledError) Error() string {
+	return fmt.Sprintf("query %s was canceled: %v", err.Operation, err.Err)
+}
+func (err *QueryCanceledError) Unwrap() error {
+	return err.Err
+}
@@ -1,6 +1,7 @@
 package models_test
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -72,6 +73,34 @@ func TestValidationError_Unwrap(t *testing.T) {
 	}
 }
 
+func TestValidationError_Fields(t *testing.T) {
+	tests := []struct {
+		name   string
+		errors []error
+		want   []models.FieldError
+	}{
+		{"test without errors inside", nil, []models.FieldError{}},
+		{"test with field error", []error{&models.FieldError{Field: "rowId", In: "query", Message: "is required"}},
+			[]models.FieldError{{Field: "rowId", In: "query", Message: "is required"}}},
+		{"test with plain error", []error{errors.New("error 1 message")}, []models.FieldError{{Message: "error 1 message"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &models.ValidationError{Errors: tt.errors}
+			if got := err.Fields(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidationError.Fields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldError_Error(t *testing.T) {
+	err := &models.FieldError{Field: "user_id", In: "body", Message: "must be uuid"}
+	if got := err.Error(); got != "user_id must be uuid" {
+		t.Errorf("FieldError.Error() = %v, want %v", got, "user_id must be uuid")
+	}
+}
+
 func TestResourceNotFoundError_Error(t *testing.T) {
 	type fields struct {
 		Err error
@@ -335,3 +364,57 @@ func TestInvalidParameterError_Unwrap(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryCanceledError_Error(t *testing.T) {
+	type fields struct {
+		Operation string
+		Err       error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{"test with nil error", fields{Err: nil}, "query  was canceled: <nil>"},
+		{"test with deadline exceeded error", fields{Operation: "subscription_sum", Err: context.DeadlineExceeded}, "query subscription_sum was canceled: context deadline exceeded"},
+		{"test with canceled error", fields{Operation: "subscription_read", Err: context.Canceled}, "query subscription_read was canceled: context canceled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &models.QueryCanceledError{
+				Operation: tt.fields.Operation,
+				Err:       tt.fields.Err,
+			}
+			if got := err.Error(); got != tt.want {
+				t.Errorf("QueryCanceledError.Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryCanceledError_Unwrap(t *testing.T) {
+	type fields struct {
+		Operation string
+		Err       error
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		expected error
+	}{
+		{"test with nil error", fields{Err: nil}, nil},
+		{"test with deadline exceeded error", fields{Operation: "subscription_sum", Err: context.DeadlineExceeded}, context.DeadlineExceeded},
+		{"test with canceled error", fields{Operation: "subscription_read", Err: context.Canceled}, context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &models.QueryCanceledError{
+				Operation: tt.fields.Operation,
+				Err:       tt.fields.Err,
+			}
+			if err := err.Unwrap(); err != tt.expected {
+				t.Errorf("QueryCanceledError.Unwrap() error = %v, wantErr %v", err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+)
+
+// MaxForecastMonths limits how far ahead a forecast goes
+const MaxForecastMonths = 36
+
+// ChangeReason explains why the charge of a subscription differs from the previous month
+type ChangeReason string
+
+const (
+	ChangeStarted         ChangeReason = "started"
+	ChangeFinished        ChangeReason = "finished"
+	ChangeTrialEnded      ChangeReason = "trial_ended"
+	ChangeIntroEnded      ChangeReason = "intro_ended"
+	ChangePrice           ChangeReason = "price_changed"
+	ChangeDiscountStarted ChangeReason = "discount_started"
+	ChangeDiscountEnded   ChangeReason = "discount_ended"
+	ChangePaused          ChangeReason = "paused"
+	ChangeResumed         ChangeReason = "resumed"
+)
+
+// ForecastMonth is the projected spend of a calendar month, charged like monthly sums
+type ForecastMonth struct {
+	Month         time.Time `json:"month" swaggertype:"string" format:"billing-date" example:"2025-10-01"`
+	Subscriptions int       `json:"subscriptions" example:"4"`
+	Gross         int       `json:"gross" example:"2400"`
+	Discount      int       `json:"discount" example:"240"`
+	Net           int       `json:"net" example:"2160"`
+}
+
+// ForecastChange is a subscription charged differently than in the previous month
+type ForecastChange struct {
+	Month          time.Time      `json:"month" swaggertype:"string" format:"billing-date" example:"2025-10-01"`
+	SubscriptionId int32          `json:"subscription_id" example:"1"`
+	ServiceName    string         `json:"service_name" example:"Yandex Plus"`
+	UserId         string         `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	Reasons        []ChangeReason `json:"reasons" example:"trial_ended"`
+	// Before and After are the net charges of the previous and of this month
+	Before int `json:"before" example:"0"`
+	After  int `json:"after" example:"400"`
+}
+
+// Forecast is the projected spend of the months from the first one, Changes explain the differences
+// between consecutive months, the first month is compared with the month before it
+type Forecast struct {
+	Months  []ForecastMonth  `json:"months"`
+	Changes []ForecastChange `json:"changes"`
+}
+
+// override json marshaling
+func (m *ForecastMonth) MarshalJSON() ([]byte, error) {
+	type month ForecastMonth
+	res, err := json.Marshal(struct {
+		*month
+		Month string `json:"month"`
+	}{(*month)(m), FormatDate(m.Month)})
+	if err != nil {
+		err = &JsonError{Err: err}
+	}
+	return res, err
+}
+
+// override json unmarshaling
+func (m *ForecastMonth) UnmarshalJSON(body []byte) error {
+	type month ForecastMonth
+	temp := struct {
+		*month
+		Month string `json:"month"`
+	}{month: (*month)(m)}
+	if err := json.Unmarshal(body, &temp); err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	first, err := time.Parse(dateLayout, temp.Month)
+	if err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	m.Month = first
+	return nil
+}
+
+// override json marshaling
+func (c *ForecastChange) MarshalJSON() ([]byte, error) {
+	type change ForecastChange
+	res, err := json.Marshal(struct {
+		*change
+		Month string `json:"month"`
+	}{(*change)(c), FormatDate(c.Month)})
+	if err != nil {
+		err = &JsonError{Err: err}
+	}
+	return res, err
+}
+
+// override json unmarshaling
+func (c *ForecastChange) UnmarshalJSON(body []byte) error {
+	type change ForecastChange
+	temp := struct {
+		*change
+		Month string `json:"month"`
+	}{change: (*change)(c)}
+	if err := json.Unmarshal(body, &temp); err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	first, err := time.Parse(dateLayout, temp.Month)
+	if err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	c.Month = first
+	return nil
+}
+
+// BuildForecast charges the items for every month from the month of from, the items must include
+// the subscriptions of the month before it to explain the changes of the first month
+func BuildForecast(items []Subscription, from time.Time, months int) Forecast {
+	first := MonthOf(from)
+	forecast := Forecast{Months: make([]ForecastMonth, 0, months), Changes: []ForecastChange{}}
+	totals := make([]Totals, months)
+	counts := make([]int, months)
+	for _, item := range items {
+		previous := ChargeTotals(item, first.AddDate(0, -1, 0), first.AddDate(0, 0, -1), SumModeMonthly).Net
+		for i := 0; i < months; i++ {
+			month := first.AddDate(0, i, 0)
+			charge := ChargeTotals(item, month, month.AddDate(0, 1, -1), SumModeMonthly)
+			totals[i] = totals[i].Add(charge)
+			if _, _, active := activeWithin(item, month, month.AddDate(0, 1, -1)); active {
+				counts[i]++
+			}
+			if math.Abs(charge.Net-previous) > 1e-9 {
+				forecast.Changes = append(forecast.Changes, ForecastChange{Month: month, SubscriptionId: item.Id,
+					ServiceName: item.ServiceName, UserId: item.UserId, Reasons: changeReasons(item, month),
+					Before: int(math.Round(previous)), After: int(math.Round(charge.Net))})
+			}
+			previous = charge.Net
+		}
+	}
+	for i := range totals {
+		rounded := totals[i].Rounded()
+		forecast.Months = append(forecast.Months, ForecastMonth{Month: first.AddDate(0, i, 0), Subscriptions: counts[i],
+			Gross: rounded.Gross, Discount: rounded.Discount, Net: rounded.Net})
+	}
+	sort.SliceStable(forecast.Changes, func(i, j int) bool {
+		if !forecast.Changes[i].Month.Equal(forecast.Changes[j].Month) {
+			return forecast.Changes[i].Month.Before(forecast.Changes[j].Month)
+		}
+		return forecast.Changes[i].SubscriptionId < forecast.Changes[j].SubscriptionId
+	})
+	return forecast
+}
+
+// changeReasons lists the events that can change the charge of the month compared with the previous one,
+// they happen after the first day of the previous month and not later than the end of this month
+func changeReasons(item Subscription, month time.Time) []ChangeReason {
+	after, until := month.AddDate(0, -1, 0), month.AddDate(0, 1, -1)
+	within := func(day time.Time) bool { return day.After(after) && !day.After(until) }
+	reasons := []ChangeReason{}
+	add := func(reason ChangeReason, happened bool) {
+		if happened && (len(reasons) == 0 || reasons[len(reasons)-1] != reason) {
+			reasons = append(reasons, reason)
+		}
+	}
+	add(ChangeStarted, !item.StartDate.Before(month) && !item.StartDate.After(until))
+	add(ChangeFinished, item.FinishDate.Valid && item.FinishDate.Time.Before(month) && !item.FinishDate.Time.Before(after))
+	introStart, introEnd := item.introPeriod()
+	add(ChangeTrialEnded, item.TrialDays > 0 && within(introStart))
+	add(ChangeIntroEnded, item.IntroMonths > 0 && within(introEnd))
+	for _, change := range item.PriceChanges {
+		add(ChangePrice, within(change.EffectiveDate))
+	}
+	for _, discount := range item.Discounts {
+		add(ChangeDiscountStarted, discount.StartMonth.Equal(month))
+		add(ChangeDiscountEnded, discount.Months > 0 && discount.StartMonth.AddDate(0, discount.Months, 0).Equal(month))
+	}
+	for _, pause := range item.Pauses {
+		add(ChangePaused, within(pause.StartDate))
+		add(ChangeResumed, pause.FinishDate.Valid && within(pause.FinishDate.Time.AddDate(0, 0, 1)))
+	}
+	return reasons
+}
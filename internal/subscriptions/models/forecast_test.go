@@ -0,0 +1,55 @@
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func TestBuildForecast(t *testing.T) {
+	items := []models.Subscription{
+		{Id: 1, ServiceName: "Yandex Plus", Price: 400, StartDate: date("2025-09-20"), BillingDay: 20, TrialDays: 14,
+			PriceChanges: []models.PriceChange{{EffectiveDate: date("2026-01-01"), Price: 500}}},
+		{Id: 2, ServiceName: "Kinopoisk", Price: 300, StartDate: date("2025-01-01"), FinishDate: finish("2025-11-15"), BillingDay: 1},
+		{Id: 3, ServiceName: "AWS", Price: 1000, StartDate: date("2025-01-01"), BillingDay: 1,
+			Discounts: []models.Discount{{Kind: models.DiscountPercent, Amount: 10, StartMonth: date("2025-08-01"), Months: 3}}},
+	}
+	got := models.BuildForecast(items, date("2025-10-12"), 4)
+
+	wantMonths := []models.ForecastMonth{
+		{Month: date("2025-10-01"), Subscriptions: 3, Gross: 1300, Discount: 100, Net: 1200},
+		{Month: date("2025-11-01"), Subscriptions: 3, Gross: 1700, Discount: 0, Net: 1700},
+		{Month: date("2025-12-01"), Subscriptions: 2, Gross: 1400, Discount: 0, Net: 1400},
+		{Month: date("2026-01-01"), Subscriptions: 2, Gross: 1500, Discount: 0, Net: 1500},
+	}
+	if !reflect.DeepEqual(got.Months, wantMonths) {
+		t.Errorf("BuildForecast() months = %+v, want %+v", got.Months, wantMonths)
+	}
+
+	tests := []struct {
+		name   string
+		month  string
+		id     int32
+		before int
+		after  int
+		reason models.ChangeReason
+	}{
+		{"test trial end", "2025-11-01", 1, 0, 400, models.ChangeTrialEnded},
+		{"test discount end", "2025-11-01", 3, 900, 1000, models.ChangeDiscountEnded},
+		{"test finish", "2025-12-01", 2, 300, 0, models.ChangeFinished},
+		{"test price change", "2026-01-01", 1, 400, 500, models.ChangePrice},
+	}
+	if len(got.Changes) != len(tests) {
+		t.Fatalf("BuildForecast() changes = %+v, want %d", got.Changes, len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := got.Changes[i]
+			if !change.Month.Equal(date(tt.month)) || change.SubscriptionId != tt.id || change.Before != tt.before || change.After != tt.after ||
+				!reflect.DeepEqual(change.Reasons, []models.ChangeReason{tt.reason}) {
+				t.Errorf("change = %+v, want %s of %d from %d to %d because %s", change, tt.month, tt.id, tt.before, tt.after, tt.reason)
+			}
+		})
+	}
+}
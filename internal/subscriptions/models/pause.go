@@ -0,0 +1,108 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Pause stops the billing of a subscription from StartDate to FinishDate including both,
+// FinishDate is not set until the subscription is resumed
+type Pause struct {
+	Id             int32        `json:"id" example:"1"`
+	SubscriptionId int32        `json:"subscription_id" validate:"required" minimum:"1" example:"1"`
+	StartDate      time.Time    `json:"start_date" validate:"required" swaggertype:"string" format:"billing-date" example:"2025-09-01"`
+	FinishDate     sql.NullTime `json:"finish_date" swaggertype:"string" format:"billing-date" example:"2025-10-31" extensions:"x-nullable"`
+}
+
+// override json marshaling
+func (p *Pause) MarshalJSON() ([]byte, error) {
+	var finishDate *string
+	if p.FinishDate.Valid {
+		finishDateFormatted := FormatDate(p.FinishDate.Time)
+		finishDate = &finishDateFormatted
+	}
+	res, err := json.Marshal(struct {
+		Id             int32   `json:"id"`
+		SubscriptionId int32   `json:"subscription_id"`
+		StartDate      string  `json:"start_date"`
+		FinishDate     *string `json:"finish_date,omitempty"`
+	}{p.Id, p.SubscriptionId, FormatDate(p.StartDate), finishDate})
+	if err != nil {
+		err = &JsonError{Err: err}
+	}
+	return res, err
+}
+
+// override json unmarshaling
+func (p *Pause) UnmarshalJSON(body []byte) error {
+	var temp struct {
+		Id             int32  `json:"id"`
+		SubscriptionId int32  `json:"subscription_id"`
+		StartDate      string `json:"start_date"`
+		FinishDate     string `json:"finish_date"`
+	}
+	if err := json.Unmarshal(body, &temp); err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	p.Id = temp.Id
+	p.SubscriptionId = temp.SubscriptionId
+	var vErr ValidationError
+	var errDate error
+	if p.StartDate, errDate = ParseStartDate(temp.StartDate); errDate != nil {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "start_date", In: "body", Message: errDate.Error()})
+	}
+	if p.FinishDate, errDate = ParseFinishDate(temp.FinishDate); errDate != nil {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "finish_date", In: "body", Message: errDate.Error()})
+	}
+	if len(vErr.Errors) != 0 {
+		return &vErr
+	}
+	return nil
+}
+
+// covers reports whether the day is inside the pause
+func (p Pause) covers(day time.Time) bool {
+	return !day.Before(p.StartDate) && (!p.FinishDate.Valid || !day.After(p.FinishDate.Time))
+}
+
+// PausedAt reports whether billing of the subscription is paused on the day
+func (s Subscription) PausedAt(day time.Time) bool {
+	for _, pause := range s.Pauses {
+		if pause.covers(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// pauseErrors checks that the pauses are inside the subscription lifetime and do not overlap
+func (s Subscription) pauseErrors() []error {
+	var errs []error
+	invalid := func(pause Pause, message string) {
+		errs = append(errs, &FieldError{Field: "pauses", In: "body",
+			Message: fmt.Sprintf("pause from %s %s", FormatDate(pause.StartDate), message)})
+	}
+	pauses := append([]Pause(nil), s.Pauses...)
+	sort.Slice(pauses, func(i, j int) bool { return pauses[i].StartDate.Before(pauses[j].StartDate) })
+	for i, pause := range pauses {
+		if pause.FinishDate.Valid && pause.FinishDate.Time.Before(pause.StartDate) {
+			invalid(pause, "finishes before it starts")
+		}
+		if pause.StartDate.Before(s.StartDate) {
+			invalid(pause, "starts before the subscription")
+		}
+		if s.FinishDate.Valid && pause.FinishDate.Valid && pause.FinishDate.Time.After(s.FinishDate.Time) {
+			invalid(pause, "finishes after the subscription")
+		}
+		if s.FinishDate.Valid && pause.StartDate.After(s.FinishDate.Time) {
+			invalid(pause, "starts after the subscription")
+		}
+		if i > 0 && pauses[i-1].covers(pause.StartDate) {
+			invalid(pause, "overlaps the previous pause")
+		}
+	}
+	return errs
+}
@@ -0,0 +1,56 @@
+package models_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func TestSubscription_IsValidPauses(t *testing.T) {
+	item := models.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserId:      "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		StartDate:   date("2025-07-01"),
+		FinishDate:  finish("2025-12-31"),
+		BillingDay:  1,
+	}
+	tests := []struct {
+		name   string
+		pauses []models.Pause
+		want   []string
+	}{
+		{"test without pauses", nil, nil},
+		{"test with pauses inside the lifetime", []models.Pause{
+			{StartDate: date("2025-10-01")},
+			{StartDate: date("2025-08-01"), FinishDate: finish("2025-08-31")},
+		}, nil},
+		{"test with pause before the start", []models.Pause{{StartDate: date("2025-06-01"), FinishDate: finish("2025-07-15")}},
+			[]string{"pause from 2025-06-01 starts before the subscription"}},
+		{"test with pause after the finish", []models.Pause{{StartDate: date("2025-12-01"), FinishDate: finish("2026-01-31")}},
+			[]string{"pause from 2025-12-01 finishes after the subscription"}},
+		{"test with overlapping pauses", []models.Pause{
+			{StartDate: date("2025-08-01"), FinishDate: finish("2025-08-31")},
+			{StartDate: date("2025-08-20"), FinishDate: finish("2025-09-10")},
+		}, []string{"pause from 2025-08-20 overlaps the previous pause"}},
+		{"test with pause finishing before it starts", []models.Pause{{StartDate: date("2025-09-10"), FinishDate: finish("2025-09-01")}},
+			[]string{"pause from 2025-09-10 finishes before it starts"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item.Pauses = tt.pauses
+			var got []string
+			var vErr *models.ValidationError
+			if err := item.IsValid(); errors.As(err, &vErr) {
+				for _, field := range vErr.Fields() {
+					got = append(got, field.Message)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IsValid() messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
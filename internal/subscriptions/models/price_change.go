@@ -0,0 +1,88 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PriceChange replaces the regular monthly price of a subscription from EffectiveDate,
+// the trial and intro prices are not affected
+type PriceChange struct {
+	Id             int32     `json:"id" example:"1"`
+	SubscriptionId int32     `json:"subscription_id" validate:"required" minimum:"1" example:"1"`
+	EffectiveDate  time.Time `json:"effective_date" validate:"required" swaggertype:"string" format:"billing-date" example:"2026-01-01"`
+	Price          int       `json:"price" minimum:"0" example:"500"`
+}
+
+// override json marshaling
+func (c *PriceChange) MarshalJSON() ([]byte, error) {
+	res, err := json.Marshal(struct {
+		Id             int32  `json:"id"`
+		SubscriptionId int32  `json:"subscription_id"`
+		EffectiveDate  string `json:"effective_date"`
+		Price          int    `json:"price"`
+	}{c.Id, c.SubscriptionId, FormatDate(c.EffectiveDate), c.Price})
+	if err != nil {
+		err = &JsonError{Err: err}
+	}
+	return res, err
+}
+
+// override json unmarshaling
+func (c *PriceChange) UnmarshalJSON(body []byte) error {
+	var temp struct {
+		Id             int32  `json:"id"`
+		SubscriptionId int32  `json:"subscription_id"`
+		EffectiveDate  string `json:"effective_date"`
+		Price          int    `json:"price"`
+	}
+	if err := json.Unmarshal(body, &temp); err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	c.Id, c.SubscriptionId, c.Price = temp.Id, temp.SubscriptionId, temp.Price
+	effectiveDate, errDate := ParseStartDate(temp.EffectiveDate)
+	if errDate != nil {
+		return &ValidationError{Errors: []error{&FieldError{Field: "effective_date", In: "body", Message: errDate.Error()}}}
+	}
+	c.EffectiveDate = effectiveDate
+	return nil
+}
+
+// regularPriceAt returns the price of the last change effective on the day, Price before the first change
+func (s Subscription) regularPriceAt(day time.Time) int {
+	price, effective := s.Price, time.Time{}
+	for _, change := range s.PriceChanges {
+		if !change.EffectiveDate.After(day) && !change.EffectiveDate.Before(effective) {
+			price, effective = change.Price, change.EffectiveDate
+		}
+	}
+	return price
+}
+
+// priceChangeErrors checks that the changes are inside the subscription lifetime and fall on different days
+func (s Subscription) priceChangeErrors() []error {
+	var errs []error
+	invalid := func(change PriceChange, message string) {
+		errs = append(errs, &FieldError{Field: "price_changes", In: "body",
+			Message: fmt.Sprintf("price change on %s %s", FormatDate(change.EffectiveDate), message)})
+	}
+	changes := append([]PriceChange(nil), s.PriceChanges...)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].EffectiveDate.Before(changes[j].EffectiveDate) })
+	for i, change := range changes {
+		if change.Price < 0 {
+			invalid(change, "has a negative price")
+		}
+		if !change.EffectiveDate.After(s.StartDate) {
+			invalid(change, "is not after the subscription start")
+		}
+		if s.FinishDate.Valid && change.EffectiveDate.After(s.FinishDate.Time) {
+			invalid(change, "is after the subscription finish")
+		}
+		if i > 0 && changes[i-1].EffectiveDate.Equal(change.EffectiveDate) {
+			invalid(change, "repeats the previous change")
+		}
+	}
+	return errs
+}
@@ -0,0 +1,87 @@
+package models_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func TestCharge_PriceChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []models.PriceChange
+		mode    models.SumMode
+		want    float64
+	}{
+		{"test without changes", nil, models.SumModeMonthly, 2400},
+		{"test monthly charges the new price from its month",
+			[]models.PriceChange{{EffectiveDate: date("2025-10-01"), Price: 500}},
+			models.SumModeMonthly, 3*400 + 3*500},
+		{"test monthly uses the latest change",
+			[]models.PriceChange{{EffectiveDate: date("2025-12-01"), Price: 600}, {EffectiveDate: date("2025-09-01"), Price: 500}},
+			models.SumModeMonthly, 2*400 + 3*500 + 600},
+		{"test prorated splits the month of the change",
+			[]models.PriceChange{{EffectiveDate: date("2025-12-17"), Price: 710}},
+			models.SumModeProrated, 5*400 + 400*16.0/31 + 710*15.0/31},
+	}
+	item := models.Subscription{Price: 400, StartDate: date("2025-07-01"), BillingDay: 1}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item.PriceChanges = tt.changes
+			got := models.Charge(item, date("2025-07-01"), date("2025-12-31"), tt.mode)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Charge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscription_IsValidPriceChanges(t *testing.T) {
+	item := models.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserId:      "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		StartDate:   date("2025-07-01"),
+		FinishDate:  finish("2025-12-31"),
+		BillingDay:  1,
+	}
+	tests := []struct {
+		name    string
+		changes []models.PriceChange
+		want    []string
+	}{
+		{"test without changes", nil, nil},
+		{"test with changes inside the lifetime", []models.PriceChange{
+			{EffectiveDate: date("2025-11-01"), Price: 600},
+			{EffectiveDate: date("2025-09-01"), Price: 500},
+		}, nil},
+		{"test with change on the start", []models.PriceChange{{EffectiveDate: date("2025-07-01"), Price: 500}},
+			[]string{"price change on 2025-07-01 is not after the subscription start"}},
+		{"test with change after the finish", []models.PriceChange{{EffectiveDate: date("2026-01-01"), Price: 500}},
+			[]string{"price change on 2026-01-01 is after the subscription finish"}},
+		{"test with repeated day", []models.PriceChange{
+			{EffectiveDate: date("2025-09-01"), Price: 500},
+			{EffectiveDate: date("2025-09-01"), Price: 550},
+		}, []string{"price change on 2025-09-01 repeats the previous change"}},
+		{"test with negative price", []models.PriceChange{{EffectiveDate: date("2025-09-01"), Price: -1}},
+			[]string{"price change on 2025-09-01 has a negative price"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item.PriceChanges = tt.changes
+			var got []string
+			var vErr *models.ValidationError
+			if err := item.IsValid(); errors.As(err, &vErr) {
+				for _, field := range vErr.Fields() {
+					got = append(got, field.Message)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IsValid() messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
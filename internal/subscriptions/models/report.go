@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReportGroup is what spend is grouped by: "category", "tag" or "tag:KEY" for the values of KEY:VALUE tags
+type ReportGroup string
+
+// ParseReportGroup checks the group of a report
+func ParseReportGroup(value string) (ReportGroup, error) {
+	switch key, ok := strings.CutPrefix(value, "tag:"); {
+	case value == "category", value == "tag":
+		return ReportGroup(value), nil
+	case ok && key != "" && tagPattern.MatchString(key+":"):
+		return ReportGroup(value), nil
+	default:
+		return "", errors.New("must be category, tag or tag:KEY")
+	}
+}
+
+// keys returns the groups the subscription belongs to, the empty group holds subscriptions without a category or tag
+func (g ReportGroup) keys(item Subscription, category string) []string {
+	if g == "category" {
+		return []string{category}
+	}
+	var keys []string
+	prefix, byKey := strings.CutPrefix(string(g), "tag:")
+	for _, tag := range item.Tags {
+		if !byKey {
+			keys = append(keys, tag)
+		} else if value, ok := strings.CutPrefix(tag, prefix+":"); ok {
+			keys = append(keys, value)
+		}
+	}
+	if len(keys) == 0 {
+		return []string{""}
+	}
+	return keys
+}
+
+// SpendReport is the spend of a period grouped by category or tag, a subscription with several tags
+// is counted in each of their groups, so Total is not the sum of the groups
+type SpendReport struct {
+	GroupBy ReportGroup  `json:"group_by" example:"category"`
+	Groups  []SpendGroup `json:"groups"`
+	Total   SpendTotals  `json:"total"`
+}
+
+type SpendGroup struct {
+	// Name is the category, the tag or the tag value, empty for subscriptions without them
+	Name          string `json:"name" example:"streaming"`
+	Subscriptions int    `json:"subscriptions" example:"3"`
+	Gross         int    `json:"gross" example:"2400"`
+	Discount      int    `json:"discount" example:"240"`
+	Net           int    `json:"net" example:"2160"`
+}
+
+// BuildSpendReport charges the items matching the filter like SubscriptionSum and groups the charges,
+// categories maps catalog ids to categories, groups are ordered by net spend descending
+func BuildSpendReport(items []Subscription, categories map[int32]string, from time.Time, to time.Time, mode SumMode,
+	groupBy ReportGroup, filter TagFilter) SpendReport {
+	charges := map[string]Totals{}
+	counts := map[string]int{}
+	var total Totals
+	for _, item := range items {
+		if !filter.Match(item.Tags) {
+			continue
+		}
+		charge := ChargeTotals(item, from, to, mode)
+		total = total.Add(charge)
+		var category string
+		if item.ServiceId != nil {
+			category = categories[*item.ServiceId]
+		}
+		for _, key := range groupBy.keys(item, category) {
+			charges[key] = charges[key].Add(charge)
+			counts[key]++
+		}
+	}
+	report := SpendReport{GroupBy: groupBy, Groups: make([]SpendGroup, 0, len(charges)), Total: total.Rounded()}
+	for name, charge := range charges {
+		rounded := charge.Rounded()
+		report.Groups = append(report.Groups, SpendGroup{Name: name, Subscriptions: counts[name],
+			Gross: rounded.Gross, Discount: rounded.Discount, Net: rounded.Net})
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Net != report.Groups[j].Net {
+			return report.Groups[i].Net > report.Groups[j].Net
+		}
+		return report.Groups[i].Name < report.Groups[j].Name
+	})
+	return report
+}
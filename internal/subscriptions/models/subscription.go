@@ -0,0 +1,237 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// dateLayout is the api format of dates
+	dateLayout = "2006-01-02"
+	// monthLayout is still accepted on input, it means the first or the last day of the month
+	monthLayout = "01-2006"
+)
+
+type Subscription struct {
+	Id          int32        `json:"id" example:"1"`
+	ServiceName string       `json:"service_name" validate:"required" minLength:"1" example:"Yandex Plus"`
+	Price       int          `json:"price" validate:"required" example:"400"`
+	UserId      string       `json:"user_id" validate:"required" format:"uuid" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	StartDate   time.Time    `json:"start_date" validate:"required" swaggertype:"string" format:"billing-date" example:"2025-07-20"`
+	FinishDate  sql.NullTime `json:"finish_date" swaggertype:"string" format:"billing-date" example:"2025-12-31" extensions:"x-nullable"`
+	// ServiceId is the catalog entry the service name resolved to, it is set by create and update
+	ServiceId *int32 `json:"service_id" example:"1" extensions:"x-nullable"`
+	// BillingDay is the day of month the charge periods start on, the day of StartDate when not set
+	BillingDay int `json:"billing_day" minimum:"1" maximum:"31" example:"20"`
+	// TrialDays is the length of the trial from StartDate, TrialPrice is the monthly price during it, zero for free trials
+	TrialDays  int `json:"trial_days" minimum:"0" example:"14"`
+	TrialPrice int `json:"trial_price" minimum:"0" example:"0"`
+	// IntroMonths follow the trial and are charged IntroPrice instead of Price, IntroPrice is required with them
+	IntroMonths int `json:"intro_months" minimum:"0" example:"3"`
+	IntroPrice  int `json:"intro_price" minimum:"0" example:"200"`
+	// Tags are free labels like "team:platform", they are stored lowercased and sorted
+	Tags []string `json:"tags" example:"cost-center:42,team:platform"`
+	// Pauses, Discounts and PriceChanges are managed by their own endpoints, they are ignored by create and update
+	Pauses    []Pause    `json:"pauses"`
+	Discounts []Discount `json:"discounts"`
+	// PriceChanges schedule new regular prices, Price is charged until the first of them
+	PriceChanges []PriceChange `json:"price_changes"`
+}
+
+type SubscriptionListPage struct {
+	List    []Subscription `json:"List"`
+	Page    int            `json:"Page"`
+	PerPage int            `json:"PerPage"`
+	Total   int            `json:"Total"`
+}
+
+// Summary describes subscriptions active today
+type Summary struct {
+	Active       int
+	MonthlySpend int
+}
+
+// ListFilter narrows the list, nil fields are not applied
+type ListFilter struct {
+	UserId      *string
+	ServiceName *string
+}
+
+// override json marshaling
+func (s *Subscription) MarshalJSON() ([]byte, error) {
+	var finishDate *string
+	finishDate = nil
+	if s.FinishDate.Valid {
+		finishDateFormatted := FormatDate(s.FinishDate.Time)
+		finishDate = &finishDateFormatted
+	}
+	tags := s.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	res, err := json.Marshal(struct {
+		Id           int32         `json:"id"`
+		ServiceName  string        `json:"service_name"`
+		ServiceId    *int32        `json:"service_id"`
+		Price        int           `json:"price"`
+		UserId       string        `json:"user_id"`
+		StartDate    string        `json:"start_date"`
+		FinishDate   *string       `json:"finish_date,omitempty"`
+		BillingDay   int           `json:"billing_day"`
+		TrialDays    int           `json:"trial_days"`
+		TrialPrice   int           `json:"trial_price"`
+		IntroMonths  int           `json:"intro_months"`
+		IntroPrice   int           `json:"intro_price"`
+		Tags         []string      `json:"tags"`
+		Pauses       []Pause       `json:"pauses"`
+		Discounts    []Discount    `json:"discounts"`
+		PriceChanges []PriceChange `json:"price_changes"`
+	}{s.Id, s.ServiceName, s.ServiceId, s.Price, s.UserId, FormatDate(s.StartDate), finishDate, s.BillingDay,
+		s.TrialDays, s.TrialPrice, s.IntroMonths, s.IntroPrice, tags, s.Pauses, s.Discounts, s.PriceChanges})
+	if err != nil {
+		err = &JsonError{Err: err}
+	}
+	return res, err
+}
+
+// override json unmarshaling
+func (s *Subscription) UnmarshalJSON(body []byte) error {
+	var temp struct {
+		Id           int32         `json:"id"`
+		ServiceName  string        `json:"service_name"`
+		ServiceId    *int32        `json:"service_id"`
+		Price        int           `json:"price"`
+		UserId       string        `json:"user_id"`
+		StartDate    string        `json:"start_date"`
+		FinishDate   string        `json:"finish_date"`
+		BillingDay   int           `json:"billing_day"`
+		TrialDays    int           `json:"trial_days"`
+		TrialPrice   int           `json:"trial_price"`
+		IntroMonths  int           `json:"intro_months"`
+		IntroPrice   int           `json:"intro_price"`
+		Tags         []string      `json:"tags"`
+		Pauses       []Pause       `json:"pauses"`
+		Discounts    []Discount    `json:"discounts"`
+		PriceChanges []PriceChange `json:"price_changes"`
+	}
+	if err := json.Unmarshal(body, &temp); err != nil {
+		return &JsonError{Err: err, Json: string(body)}
+	}
+	s.Id = temp.Id
+	s.ServiceName = temp.ServiceName
+	s.ServiceId = temp.ServiceId
+	s.Price = temp.Price
+	s.UserId = temp.UserId
+	s.BillingDay = temp.BillingDay
+	s.TrialDays, s.TrialPrice = temp.TrialDays, temp.TrialPrice
+	s.IntroMonths, s.IntroPrice = temp.IntroMonths, temp.IntroPrice
+	s.Tags = temp.Tags
+	s.Pauses, s.Discounts, s.PriceChanges = temp.Pauses, temp.Discounts, temp.PriceChanges
+	var vErr ValidationError
+	var errDate error
+	if s.StartDate, errDate = ParseStartDate(temp.StartDate); errDate != nil {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "start_date", In: "body", Message: errDate.Error()})
+	}
+	if s.FinishDate, errDate = ParseFinishDate(temp.FinishDate); errDate != nil {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "finish_date", In: "body", Message: errDate.Error()})
+	}
+	if len(vErr.Errors) != 0 {
+		return &vErr
+	}
+	return nil
+}
+
+var errDateFormat = errors.New("must be YYYY-MM-DD or MM-YYYY")
+
+// ParseStartDate parses an api date, a bare "MM-YYYY" month means its first day
+func ParseStartDate(value string) (time.Time, error) {
+	if date, err := time.Parse(dateLayout, value); err == nil {
+		return date, nil
+	}
+	month, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return time.Time{}, errDateFormat
+	}
+	return month, nil
+}
+
+// ParseFinishDate parses an api date, a bare "MM-YYYY" month means its last day, empty value means no finish date
+func ParseFinishDate(value string) (sql.NullTime, error) {
+	if value == "" {
+		return sql.NullTime{}, nil
+	}
+	if date, err := time.Parse(dateLayout, value); err == nil {
+		return sql.NullTime{Valid: true, Time: date}, nil
+	}
+	month, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return sql.NullTime{Valid: true}, errDateFormat
+	}
+	return sql.NullTime{Valid: true, Time: month.AddDate(0, 1, -1)}, nil
+}
+
+// ParsePeriod converts the period bounds into days, bare "MM-YYYY" months mean the first day of from and the last day of to
+func ParsePeriod(from string, to string) (time.Time, time.Time, error) {
+	filterFrom, errFrom := ParseStartDate(from)
+	filterTo, errTo := ParseFinishDate(to)
+	if errFrom != nil || errTo != nil || !filterTo.Valid {
+		return time.Time{}, time.Time{}, &InvalidParameterError{ParamName: "cannot parse filter dates"}
+	}
+	return filterFrom, filterTo.Time, nil
+}
+
+// FormatDate converts a date into the api format
+func FormatDate(date time.Time) string {
+	return date.Format(dateLayout)
+}
+
+// ApplyDefaults fills optional fields that were not sent
+func (s *Subscription) ApplyDefaults() {
+	if s.BillingDay == 0 {
+		s.BillingDay = s.StartDate.Day()
+	}
+	s.Tags = normalizeTags(s.Tags)
+}
+
+func (s Subscription) IsValid() error {
+	var vErr ValidationError
+	if len(s.ServiceName) == 0 {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "service_name", In: "body", Message: "is empty"})
+	}
+	if err := uuid.Validate(s.UserId); err != nil {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "user_id", In: "body", Message: "must be uuid"})
+	}
+	if s.StartDate.Year() < 2020 {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "start_date", In: "body", Message: "incorrect date, years after 2020 accepted"})
+	}
+	if s.FinishDate.Valid && s.FinishDate.Time.Year() < 2020 {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "finish_date", In: "body", Message: "incorrect date, years after 2020 accepted"})
+	}
+	if s.BillingDay < 1 || s.BillingDay > 31 {
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "billing_day", In: "body", Message: "must be between 1 and 31"})
+	}
+	for _, field := range []struct {
+		name  string
+		value int
+	}{{"trial_days", s.TrialDays}, {"trial_price", s.TrialPrice}, {"intro_months", s.IntroMonths}, {"intro_price", s.IntroPrice}} {
+		if field.value < 0 {
+			vErr.Errors = append(vErr.Errors, &FieldError{Field: field.name, In: "body", Message: "must not be negative"})
+		}
+	}
+	if s.IntroMonths > 0 && s.IntroPrice == 0 {
+		// a missing intro price would make the intro months free, free periods are trials
+		vErr.Errors = append(vErr.Errors, &FieldError{Field: "intro_price", In: "body", Message: "is required when intro_months is set, use trial_days for free periods"})
+	}
+	vErr.Errors = append(vErr.Errors, s.tagErrors()...)
+	vErr.Errors = append(vErr.Errors, s.pauseErrors()...)
+	vErr.Errors = append(vErr.Errors, s.DiscountErrors()...)
+	vErr.Errors = append(vErr.Errors, s.priceChangeErrors()...)
+	if len(vErr.Errors) == 0 {
+		return nil
+	}
+	return &vErr
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// tagPattern allows tags like "team:platform" or "cost-center:42"
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.:/-]{0,99}$`)
+
+// tagOperators are the words of filter expressions, they can not be tags
+var tagOperators = map[string]bool{"and": true, "or": true, "not": true}
+
+// normalizeTags lowercases and trims the tags, drops duplicates and sorts them
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func (s Subscription) tagErrors() []error {
+	var errs []error
+	for _, tag := range s.Tags {
+		if !tagPattern.MatchString(tag) || tagOperators[tag] {
+			errs = append(errs, &FieldError{Field: "tags", In: "body",
+				Message: fmt.Sprintf("%q must be letters, digits and _.:/- up to 100 characters and not and, or, not", tag)})
+		}
+	}
+	return errs
+}
+
+// TagFilter selects subscriptions by their tags, the nil filter selects all of them
+type TagFilter func(tags map[string]bool) bool
+
+// Match reports whether the tags pass the filter
+func (f TagFilter) Match(tags []string) bool {
+	if f == nil {
+		return true
+	}
+	set := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		set[tag] = true
+	}
+	return f(set)
+}
+
+// ParseTagFilter parses expressions like "team:platform AND NOT (env:test OR env:dev)",
+// NOT binds tighter than AND and AND tighter than OR, operators are case insensitive, empty expression means no filter
+func ParseTagFilter(expression string) (TagFilter, error) {
+	p := tagParser{tokens: tokenizeTags(expression)}
+	if len(p.tokens) == 0 {
+		return nil, nil
+	}
+	filter, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return filter, nil
+}
+
+func tokenizeTags(expression string) []string {
+	expression = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(strings.ToLower(expression))
+	return strings.Fields(expression)
+}
+
+// tagParser is a recursive descent parser over the tokens of an expression
+type tagParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *tagParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *tagParser) or() (TagFilter, error) {
+	left, err := p.and()
+	for err == nil && p.peek() == "or" {
+		p.pos++
+		var right TagFilter
+		if right, err = p.and(); err == nil {
+			l := left
+			left = func(tags map[string]bool) bool { return l(tags) || right(tags) }
+		}
+	}
+	return left, err
+}
+
+func (p *tagParser) and() (TagFilter, error) {
+	left, err := p.not()
+	for err == nil && p.peek() == "and" {
+		p.pos++
+		var right TagFilter
+		if right, err = p.not(); err == nil {
+			l := left
+			left = func(tags map[string]bool) bool { return l(tags) && right(tags) }
+		}
+	}
+	return left, err
+}
+
+func (p *tagParser) not() (TagFilter, error) {
+	if p.peek() != "not" {
+		return p.primary()
+	}
+	p.pos++
+	operand, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	return func(tags map[string]bool) bool { return !operand(tags) }, nil
+}
+
+func (p *tagParser) primary() (TagFilter, error) {
+	token := p.peek()
+	switch {
+	case token == "":
+		return nil, errors.New("unexpected end of expression")
+	case token == "(":
+		p.pos++
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, errors.New("missing )")
+		}
+		p.pos++
+		return inner, nil
+	case token == ")" || tagOperators[token] || !tagPattern.MatchString(token):
+		return nil, fmt.Errorf("unexpected %q", token)
+	default:
+		p.pos++
+		return func(tags map[string]bool) bool { return tags[token] }, nil
+	}
+}
@@ -0,0 +1,134 @@
+package models_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func TestParseTagFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		tags       []string
+		want       bool
+		wantErr    string
+	}{
+		{"test empty expression selects all", "", []string{"env:test"}, true, ""},
+		{"test single tag", "team:platform", []string{"env:prod", "team:platform"}, true, ""},
+		{"test and", "team:platform AND env:prod", []string{"team:platform"}, false, ""},
+		{"test or", "team:platform or team:data", []string{"team:data"}, true, ""},
+		{"test not binds tighter than and", "team:platform AND NOT env:test", []string{"env:test", "team:platform"}, false, ""},
+		{"test and binds tighter than or", "env:prod OR team:platform AND env:test", []string{"env:prod"}, true, ""},
+		{"test parentheses", "team:platform AND NOT (env:test OR env:dev)", []string{"env:dev", "team:platform"}, false, ""},
+		{"test case insensitive", "Team:Platform", []string{"team:platform"}, true, ""},
+		{"test missing parenthesis", "(team:platform OR env:dev", nil, false, "missing )"},
+		{"test dangling operator", "team:platform AND", nil, false, "unexpected end of expression"},
+		{"test two tags without operator", "team:platform env:dev", nil, false, `unexpected "env:dev"`},
+		{"test invalid tag", "team:platform OR $x", nil, false, `unexpected "$x"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := models.ParseTagFilter(tt.expression)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ParseTagFilter() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTagFilter() unexpected error: %v", err)
+			}
+			if got := filter.Match(tt.tags); got != tt.want {
+				t.Errorf("Match(%v) = %v, want %v", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscription_IsValidTags(t *testing.T) {
+	item := models.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserId:      "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		StartDate:   date("2025-07-01"),
+		BillingDay:  1,
+	}
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{"test valid tags", []string{"team:platform", "cost-center:42", "env/prod"}, nil},
+		{"test operator word", []string{"not"}, []string{`"not" must be letters, digits and _.:/- up to 100 characters and not and, or, not`}},
+		{"test space inside tag", []string{"team platform"},
+			[]string{`"team platform" must be letters, digits and _.:/- up to 100 characters and not and, or, not`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item.Tags = tt.tags
+			var got []string
+			var vErr *models.ValidationError
+			if err := item.IsValid(); errors.As(err, &vErr) {
+				for _, field := range vErr.Fields() {
+					got = append(got, field.Message)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IsValid() messages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSpendReport(t *testing.T) {
+	streaming, cloud := int32(1), int32(2)
+	items := []models.Subscription{
+		{ServiceName: "Netflix", Price: 800, StartDate: date("2025-07-01"), BillingDay: 1, ServiceId: &streaming,
+			Tags: []string{"env:prod", "team:media"}},
+		{ServiceName: "AWS", Price: 3000, StartDate: date("2025-07-01"), BillingDay: 1, ServiceId: &cloud,
+			Tags: []string{"env:prod", "team:platform"}},
+		{ServiceName: "GCP", Price: 1000, StartDate: date("2025-07-01"), BillingDay: 1, ServiceId: &cloud,
+			Tags: []string{"env:test", "team:platform"}},
+		{ServiceName: "Misc", Price: 100, StartDate: date("2025-07-01"), BillingDay: 1},
+	}
+	categories := map[int32]string{streaming: "streaming", cloud: "cloud"}
+	tests := []struct {
+		name       string
+		groupBy    models.ReportGroup
+		expression string
+		want       []models.SpendGroup
+		wantNet    int
+	}{
+		{"test by category", "category", "", []models.SpendGroup{
+			{Name: "cloud", Subscriptions: 2, Gross: 4000, Net: 4000},
+			{Name: "streaming", Subscriptions: 1, Gross: 800, Net: 800},
+			{Name: "", Subscriptions: 1, Gross: 100, Net: 100},
+		}, 4900},
+		{"test by tag key", "tag:team", "", []models.SpendGroup{
+			{Name: "platform", Subscriptions: 2, Gross: 4000, Net: 4000},
+			{Name: "media", Subscriptions: 1, Gross: 800, Net: 800},
+			{Name: "", Subscriptions: 1, Gross: 100, Net: 100},
+		}, 4900},
+		{"test by tag with filter", "tag", "env:prod", []models.SpendGroup{
+			{Name: "env:prod", Subscriptions: 2, Gross: 3800, Net: 3800},
+			{Name: "team:platform", Subscriptions: 1, Gross: 3000, Net: 3000},
+			{Name: "team:media", Subscriptions: 1, Gross: 800, Net: 800},
+		}, 3800},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := models.ParseTagFilter(tt.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := models.BuildSpendReport(items, categories, date("2025-07-01"), date("2025-07-31"),
+				models.SumModeMonthly, tt.groupBy, filter)
+			if !reflect.DeepEqual(got.Groups, tt.want) || got.Total.Net != tt.wantNet {
+				t.Errorf("BuildSpendReport() = %+v, want groups %+v, net %d", got, tt.want, tt.wantNet)
+			}
+		})
+	}
+}
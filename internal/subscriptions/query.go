@@ -0,0 +1,35 @@
+package subscriptions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zakharova-e/subscriptions-info/internal/connections"
+	"github.com/zakharova-e/subscriptions-info/internal/metrics"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+	"github.com/zakharova-e/subscriptions-info/internal/tracing"
+)
+
+// startQuery applies the operation timeout and starts the span and the duration metric of the statement,
+// the returned func must be called with the operation result
+func startQuery(ctx context.Context, statement string, op connections.Operation) (context.Context, func(err error)) {
+	ctx, cancel := connections.WithTimeout(ctx, op)
+	ctx, span := tracing.StartQuery(ctx, statement)
+	observe := metrics.ObserveQuery(statement)
+	return ctx, func(err error) {
+		observe()
+		tracing.End(span, err)
+		cancel()
+	}
+}
+
+// queryError wraps a database failure, queries interrupted by their context become QueryCanceledError
+func queryError(ctx context.Context, statement string, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return &models.QueryCanceledError{Operation: statement, Err: ctxErr}
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return &models.QueryCanceledError{Operation: statement, Err: err}
+	}
+	return &models.DatabaseError{Err: err}
+}
@@ -1,10 +1,13 @@
 package subscriptions
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"sort"
 	"time"
 
 	"github.com/zakharova-e/subscriptions-info/internal/config"
@@ -12,107 +15,373 @@ import (
 	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
 )
 
-func SubscriptionCreate(item Subscription) (*int32, error) {
+// subscriptionColumns are the columns read by scanSubscription, in its order
+const subscriptionColumns = "id, service_name, service_id, price, user_id, start_date, finish_date, billing_day, trial_days, trial_price, intro_months, intro_price, tags"
+
+// currentPriceSQL is the monthly price in effect today, see Subscription.PriceAt
+const currentPriceSQL = `CASE
+		WHEN CURRENT_DATE < start_date + trial_days THEN trial_price
+		WHEN CURRENT_DATE < start_date + trial_days + make_interval(months => intro_months) THEN intro_price
+		ELSE COALESCE((SELECT c.price FROM subscription_price_change c
+			WHERE c.subscription_id = subscription.id AND c.effective_date <= CURRENT_DATE
+			ORDER BY c.effective_date DESC LIMIT 1), price)
+	END`
+
+// currentNetPriceSQL is currentPriceSQL after the discount in effect this month, see Subscription.NetPriceAt
+const currentNetPriceSQL = `COALESCE((SELECT CASE d.kind
+			WHEN 'percent' THEN (` + currentPriceSQL + `) * (100 - d.amount) / 100.0
+			ELSE GREATEST((` + currentPriceSQL + `) - d.amount, 0)
+		END FROM subscription_discount d
+		WHERE d.subscription_id = subscription.id AND d.start_month <= CURRENT_DATE
+			AND (d.months = 0 OR CURRENT_DATE < d.start_month + make_interval(months => d.months))
+		LIMIT 1), ` + currentPriceSQL + `)`
+
+// notPausedTodaySQL excludes subscriptions with billing paused today
+const notPausedTodaySQL = `NOT EXISTS (SELECT 1 FROM subscription_pause p
+		WHERE p.subscription_id = subscription.id AND p.start_date <= CURRENT_DATE
+			AND (p.finish_date >= CURRENT_DATE OR p.finish_date IS NULL))`
+
+// queryer is implemented by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// resolveService links the item to the catalog entry matching its service name and uses the canonical name,
+// names missing from the catalog are kept as they are
+func resolveService(ctx context.Context, db queryer, item *models.Subscription) error {
+	var (
+		id   int32
+		name string
+	)
+	err := db.QueryRowContext(ctx, `SELECT s.id, s.name FROM service_alias a JOIN service s ON s.id = a.service_id
+	WHERE a.key = $1`, models.ServiceKey(item.ServiceName)).Scan(&id, &name)
+	if errors.Is(err, sql.ErrNoRows) {
+		item.ServiceId = nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	item.ServiceId, item.ServiceName = &id, name
+	return nil
+}
+
+// serviceFilterSQL matches subscriptions by the service name or by the catalog entry the name resolves to
+func serviceFilterSQL(paramNum int) string {
+	param := fmt.Sprintf("$%d", paramNum)
+	return fmt.Sprintf("AND (service_name = %s OR service_id = (SELECT service_id FROM service_alias WHERE key = %s)) ", param, models.ServiceKeySQL(param))
+}
+
+// attachPauses loads the pauses of the items, items without pauses get an empty list
+func attachPauses(ctx context.Context, db queryer, items []models.Subscription) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]int32, len(items))
+	index := make(map[int32]int, len(items))
+	for i := range items {
+		ids[i] = items[i].Id
+		index[items[i].Id] = i
+		items[i].Pauses = []models.Pause{}
+	}
+	rows, err := db.QueryContext(ctx, `SELECT id, subscription_id, start_date, finish_date FROM subscription_pause
+	WHERE subscription_id = ANY($1) ORDER BY start_date`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var pause models.Pause
+		if err := rows.Scan(&pause.Id, &pause.SubscriptionId, &pause.StartDate, &pause.FinishDate); err != nil {
+			return err
+		}
+		item := &items[index[pause.SubscriptionId]]
+		item.Pauses = append(item.Pauses, pause)
+	}
+	return rows.Err()
+}
+
+// attachDiscounts loads the discounts of the items, items without discounts get an empty list
+func attachDiscounts(ctx context.Context, db queryer, items []models.Subscription) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]int32, len(items))
+	index := make(map[int32]int, len(items))
+	for i := range items {
+		ids[i] = items[i].Id
+		index[items[i].Id] = i
+		items[i].Discounts = []models.Discount{}
+	}
+	rows, err := db.QueryContext(ctx, `SELECT id, subscription_id, kind, amount, start_month, months, code FROM subscription_discount
+	WHERE subscription_id = ANY($1) ORDER BY start_month`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var discount models.Discount
+		if err := rows.Scan(&discount.Id, &discount.SubscriptionId, &discount.Kind, &discount.Amount, &discount.StartMonth,
+			&discount.Months, &discount.Code); err != nil {
+			return err
+		}
+		item := &items[index[discount.SubscriptionId]]
+		item.Discounts = append(item.Discounts, discount)
+	}
+	return rows.Err()
+}
+
+// attachPriceChanges loads the price changes of the items, items without changes get an empty list
+func attachPriceChanges(ctx context.Context, db queryer, items []models.Subscription) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]int32, len(items))
+	index := make(map[int32]int, len(items))
+	for i := range items {
+		ids[i] = items[i].Id
+		index[items[i].Id] = i
+		items[i].PriceChanges = []models.PriceChange{}
+	}
+	rows, err := db.QueryContext(ctx, `SELECT id, subscription_id, effective_date, price FROM subscription_price_change
+	WHERE subscription_id = ANY($1) ORDER BY effective_date`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var change models.PriceChange
+		if err := rows.Scan(&change.Id, &change.SubscriptionId, &change.EffectiveDate, &change.Price); err != nil {
+			return err
+		}
+		item := &items[index[change.SubscriptionId]]
+		item.PriceChanges = append(item.PriceChanges, change)
+	}
+	return rows.Err()
+}
+
+// attachRelated loads the pauses, the discounts and the price changes of the items
+func attachRelated(ctx context.Context, db queryer, items []models.Subscription) error {
+	if err := attachPauses(ctx, db, items); err != nil {
+		return err
+	}
+	if err := attachDiscounts(ctx, db, items); err != nil {
+		return err
+	}
+	return attachPriceChanges(ctx, db, items)
+}
+
+// inTransaction runs fn in a transaction committed when fn succeeds
+func inTransaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := connections.PGDatabase.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockSubscription reads the subscription with its pauses and discounts and locks it until the transaction ends
+func lockSubscription(ctx context.Context, tx *sql.Tx, recordId int32) (models.Subscription, error) {
+	var item models.Subscription
+	query := "SELECT " + subscriptionColumns + " FROM subscription WHERE id = $1 FOR UPDATE"
+	if err := scanSubscription(tx.QueryRowContext(ctx, query, recordId), &item); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return item, &models.ResourceNotFoundError{Err: fmt.Errorf("subscription %d", recordId)}
+		}
+		return item, err
+	}
+	items := []models.Subscription{item}
+	err := attachRelated(ctx, tx, items)
+	return items[0], err
+}
+
+// writeError keeps validation and not found errors of a write, other failures become query errors
+func writeError(ctx context.Context, statement string, err error) error {
+	var (
+		valErr      *models.ValidationError
+		notFoundErr *models.ResourceNotFoundError
+	)
+	if errors.As(err, &valErr) || errors.As(err, &notFoundErr) {
+		return err
+	}
+	return queryError(ctx, statement, err)
+}
+
+// scanSubscription reads subscriptionColumns into item, extra receives the columns selected after them
+func scanSubscription(row interface{ Scan(...any) error }, item *models.Subscription, extra ...any) error {
+	var tags []byte
+	dest := []any{&item.Id, &item.ServiceName, &item.ServiceId, &item.Price, &item.UserId, &item.StartDate, &item.FinishDate, &item.BillingDay,
+		&item.TrialDays, &item.TrialPrice, &item.IntroMonths, &item.IntroPrice, &tags}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	return json.Unmarshal(tags, &item.Tags)
+}
+
+func SubscriptionCreate(ctx context.Context, item models.Subscription) (_ *int32, err error) {
+	ctx, done := startQuery(ctx, "subscription_create", connections.OperationWrite)
+	defer func() { done(err) }()
+	item.ApplyDefaults()
+	item.Pauses, item.Discounts, item.PriceChanges = nil, nil, nil
 	if errValid := item.IsValid(); errValid != nil {
 		return nil, errValid
 	}
-	query := "INSERT INTO subscription (service_name, price,user_id,start_date,finish_date) VALUES ($1,$2,$3,$4,$5) RETURNING id"
-	row := connections.PGDatabase.QueryRow(query, item.ServiceName, item.Price, item.UserId, item.StartDate, item.FinishDate)
+	if errResolve := resolveService(ctx, connections.PGDatabase, &item); errResolve != nil {
+		return nil, queryError(ctx, "subscription_create", errResolve)
+	}
+	tags, err := json.Marshal(item.Tags)
+	if err != nil {
+		return nil, err
+	}
+	query := `INSERT INTO subscription (service_name,service_id,price,user_id,start_date,finish_date,billing_day,trial_days,trial_price,intro_months,intro_price,tags)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) RETURNING id`
+	row := connections.PGDatabase.QueryRowContext(ctx, query, item.ServiceName, item.ServiceId, item.Price, item.UserId, item.StartDate, item.FinishDate,
+		item.BillingDay, item.TrialDays, item.TrialPrice, item.IntroMonths, item.IntroPrice, tags)
 	var num int32
-	err := row.Scan(&num)
+	err = row.Scan(&num)
 	if err != nil {
-		return nil, &models.DatabaseError{Err: err}
+		return nil, queryError(ctx, "subscription_create", err)
 	}
 	return &num, nil
 }
 
-func SubscriptionRead(recordId int32) (*Subscription, error) {
+func SubscriptionRead(ctx context.Context, recordId int32) (_ *models.Subscription, err error) {
+	ctx, done := startQuery(ctx, "subscription_read", connections.OperationRead)
+	defer func() { done(err) }()
 	if recordId < 1 {
 		return nil, &models.InvalidParameterError{ParamName: "recordId"}
 	}
-	query := "SELECT id,service_name, price,user_id,start_date,finish_date FROM subscription WHERE id = $1"
-	row := connections.PGDatabase.QueryRow(query, recordId)
-	var (
-		id                  int32
-		serviceName, userId string
-		price               int
-		startDate           time.Time
-		finishDate          sql.NullTime
-	)
-	err := row.Scan(&id, &serviceName, &price, &userId, &startDate, &finishDate)
+	query := "SELECT " + subscriptionColumns + " FROM subscription WHERE id = $1"
+	var item models.Subscription
+	err = connections.RetryRead(ctx, func(db *sql.DB) error {
+		if errScan := scanSubscription(db.QueryRowContext(ctx, query, recordId), &item); errScan != nil {
+			return errScan
+		}
+		items := []models.Subscription{item}
+		errRelated := attachRelated(ctx, db, items)
+		item = items[0]
+		return errRelated
+	})
 	if err != nil {
-		return nil, &models.DatabaseError{Err: err}
+		return nil, queryError(ctx, "subscription_read", err)
 	}
-	return &Subscription{id, serviceName, price, userId, startDate, finishDate}, nil
+	return &item, nil
 }
 
-func SubscriptionUpdate(item Subscription) error {
+func SubscriptionUpdate(ctx context.Context, item models.Subscription) (err error) {
+	ctx, done := startQuery(ctx, "subscription_update", connections.OperationWrite)
+	defer func() { done(err) }()
+	item.ApplyDefaults()
+	item.Pauses, item.Discounts, item.PriceChanges = nil, nil, nil
 	if errValid := item.IsValid(); errValid != nil {
 		return errValid
 	}
 	if item.Id < 1 {
 		return &models.ValidationError{Errors: []error{errors.New("invalid item id")}}
 	}
-	query := "UPDATE subscription SET service_name = $1, price = $2, user_id = $3, start_date = $4, finish_date = $5 WHERE id = $6 "
-	_, err := connections.PGDatabase.Exec(query, item.ServiceName, item.Price, item.UserId, item.StartDate, item.FinishDate, item.Id)
+	query := `UPDATE subscription SET service_name = $1, service_id = $2, price = $3, user_id = $4, start_date = $5, finish_date = $6,
+	billing_day = $7, trial_days = $8, trial_price = $9, intro_months = $10, intro_price = $11, tags = $12 WHERE id = $13 `
+	err = inTransaction(ctx, func(tx *sql.Tx) error {
+		// the new dates must still contain the pauses
+		stored, errLock := lockSubscription(ctx, tx, item.Id)
+		var notFoundErr *models.ResourceNotFoundError
+		if errors.As(errLock, &notFoundErr) {
+			// the update of a missing row is not an error, there is nothing to change
+			return nil
+		}
+		if errLock != nil {
+			return errLock
+		}
+		item.Pauses, item.Discounts, item.PriceChanges = stored.Pauses, stored.Discounts, stored.PriceChanges
+		if errValid := item.IsValid(); errValid != nil {
+			return errValid
+		}
+		if errResolve := resolveService(ctx, tx, &item); errResolve != nil {
+			return errResolve
+		}
+		tags, errTags := json.Marshal(item.Tags)
+		if errTags != nil {
+			return errTags
+		}
+		_, errExec := tx.ExecContext(ctx, query, item.ServiceName, item.ServiceId, item.Price, item.UserId, item.StartDate, item.FinishDate,
+			item.BillingDay, item.TrialDays, item.TrialPrice, item.IntroMonths, item.IntroPrice, tags, item.Id)
+		return errExec
+	})
 	if err != nil {
-		return &models.DatabaseError{Err: err}
+		return writeError(ctx, "subscription_update", err)
 	}
 	return nil
 }
 
-func SubscriptionDelete(recordId int32) error {
+func SubscriptionDelete(ctx context.Context, recordId int32) (err error) {
+	ctx, done := startQuery(ctx, "subscription_delete", connections.OperationWrite)
+	defer func() { done(err) }()
 	if recordId < 1 {
 		return &models.InvalidParameterError{ParamName: "recordId"}
 	}
 	query := "DELETE FROM subscription WHERE id = $1"
-	_, err := connections.PGDatabase.Exec(query, recordId)
+	_, err = connections.PGDatabase.ExecContext(ctx, query, recordId)
 	if err != nil {
-		return &models.DatabaseError{Err: err}
+		return queryError(ctx, "subscription_delete", err)
 	}
 	return nil
 }
 
-func SubscriptionList(page int) (*SubscriptionListPage, error) {
-	query := "SELECT id,service_name, price,user_id,start_date,finish_date, COUNT(*) OVER() AS total_count FROM subscription ORDER BY id DESC LIMIT $1 OFFSET $2"
+func SubscriptionList(ctx context.Context, page int, filter models.ListFilter) (_ *models.SubscriptionListPage, err error) {
+	ctx, done := startQuery(ctx, "subscription_list", connections.OperationList)
+	defer func() { done(err) }()
+	query := "SELECT " + subscriptionColumns + ", COUNT(*) OVER() AS total_count FROM subscription WHERE TRUE "
 	offset := (page - 1) * config.DefaultPageSize
-	rows, errQuery := connections.PGDatabase.Query(query, config.DefaultPageSize, offset)
-	if errQuery != nil {
-		return nil, &models.DatabaseError{Err: errQuery}
+	params := []any{config.DefaultPageSize, offset}
+	paramNum := 3
+	if filter.UserId != nil {
+		query = query + fmt.Sprintf("AND user_id = $%d ", paramNum)
+		params = append(params, *filter.UserId)
+		paramNum++
 	}
-	defer rows.Close()
-	var list SubscriptionListPage
-	list.Page = page
-	list.PerPage = config.DefaultPageSize
-	for rows.Next() {
-		var item Subscription
-		err := rows.Scan(&item.Id, &item.ServiceName, &item.Price, &item.UserId, &item.StartDate, &item.FinishDate, &list.Total)
-		if err == nil {
-			list.List = append(list.List, item)
+	if filter.ServiceName != nil {
+		query = query + serviceFilterSQL(paramNum)
+		params = append(params, *filter.ServiceName)
+	}
+	query = query + "ORDER BY id DESC LIMIT $1 OFFSET $2"
+	var list models.SubscriptionListPage
+	err = connections.RetryRead(ctx, func(db *sql.DB) error {
+		rows, errQuery := db.QueryContext(ctx, query, params...)
+		if errQuery != nil {
+			return errQuery
 		}
+		defer rows.Close()
+		list = models.SubscriptionListPage{Page: page, PerPage: config.DefaultPageSize}
+		for rows.Next() {
+			var item models.Subscription
+			errScan := scanSubscription(rows, &item, &list.Total)
+			if errScan == nil {
+				list.List = append(list.List, item)
+			}
+		}
+		if errRows := rows.Err(); errRows != nil {
+			return errRows
+		}
+		return attachRelated(ctx, db, list.List)
+	})
+	if err != nil {
+		return nil, queryError(ctx, "subscription_list", err)
 	}
 	return &list, nil
 }
 
-func SubscriptionSum(filterFrom time.Time, filterTo time.Time, userId *string, serviceName *string) (int, error) {
-	params := []any{}
-	//calculation formula:
-	// months count: (yearTo-yearFrom)*12 + (monthTo - monthFrom) + 1
-	query := `SELECT SUM(price * (
-    (EXTRACT(YEAR FROM sumTo) * 12 + EXTRACT(MONTH FROM sumTo))
-		- (EXTRACT(YEAR FROM sumFrom) * 12 + EXTRACT(MONTH FROM sumFrom)) + 1
-	)) AS total
-	FROM (
-	SELECT
-		price,
-		GREATEST(start_date, $1) AS sumFrom,
-		LEAST(COALESCE(finish_date, $2), $2) AS sumTo
-	FROM subscription
+// periodSubscriptions selects subscriptions overlapping the period, the charges are counted by Charge
+func periodSubscriptions(ctx context.Context, filterFrom time.Time, filterTo time.Time, userId *string, serviceName *string) ([]models.Subscription, error) {
+	query := "SELECT " + subscriptionColumns + ` FROM subscription
 	WHERE start_date <= $2
 		AND (finish_date >= $1 OR finish_date IS NULL)
 	`
-
-	params = append(params, filterFrom.Format("2006-01-02"), filterTo.Format("2006-01-02"))
+	params := []any{filterFrom.Format("2006-01-02"), filterTo.Format("2006-01-02")}
 	paramNum := 3
 	if userId != nil {
 		query = query + fmt.Sprintf("AND user_id = $%d ", paramNum)
@@ -120,18 +389,1134 @@ func SubscriptionSum(filterFrom time.Time, filterTo time.Time, userId *string, s
 		paramNum++
 	}
 	if serviceName != nil {
-		query = query + fmt.Sprintf("AND service_name = $%d ", paramNum)
+		query = query + serviceFilterSQL(paramNum)
 		params = append(params, serviceName)
-		paramNum++
 	}
-	//more params?
-	query = query + ") sub;"
-	log.Printf("query to execute: %s", query)
-	row := connections.PGDatabase.QueryRow(query, params...)
-	var res int
-	err := row.Scan(&res)
+	var items []models.Subscription
+	err := connections.RetryRead(ctx, func(db *sql.DB) error {
+		rows, errQuery := db.QueryContext(ctx, query, params...)
+		if errQuery != nil {
+			return errQuery
+		}
+		defer rows.Close()
+		items = nil
+		for rows.Next() {
+			var item models.Subscription
+			if errScan := scanSubscription(rows, &item); errScan != nil {
+				return errScan
+			}
+			items = append(items, item)
+		}
+		if errRows := rows.Err(); errRows != nil {
+			return errRows
+		}
+		return attachRelated(ctx, db, items)
+	})
+	return items, err
+}
+
+// SubscriptionSum returns the spend of the period before and after discounts
+func SubscriptionSum(ctx context.Context, filterFrom time.Time, filterTo time.Time, userId *string, serviceName *string, mode models.SumMode) (_ models.SpendTotals, err error) {
+	ctx, done := startQuery(ctx, "subscription_sum", connections.OperationSum)
+	defer func() { done(err) }()
+	slog.DebugContext(ctx, "subscription sum", "user_filter", userId != nil, "service_filter", serviceName != nil, "mode", mode)
+	items, err := periodSubscriptions(ctx, filterFrom, filterTo, userId, serviceName)
+	if err != nil {
+		return models.SpendTotals{}, queryError(ctx, "subscription_sum", err)
+	}
+	var total models.Totals
+	for _, item := range items {
+		total = total.Add(models.ChargeTotals(item, filterFrom, filterTo, mode))
+	}
+	return total.Rounded(), nil
+}
+
+// SubscriptionSumByService returns the spend of the period for every service with charges in it
+func SubscriptionSumByService(ctx context.Context, filterFrom time.Time, filterTo time.Time, userId *string, mode models.SumMode) (_ map[string]models.SpendTotals, err error) {
+	ctx, done := startQuery(ctx, "subscription_sum_by_service", connections.OperationSum)
+	defer func() { done(err) }()
+	items, err := periodSubscriptions(ctx, filterFrom, filterTo, userId, nil)
+	if err != nil {
+		return nil, queryError(ctx, "subscription_sum_by_service", err)
+	}
+	charges := map[string]models.Totals{}
+	for _, item := range items {
+		charges[item.ServiceName] = charges[item.ServiceName].Add(models.ChargeTotals(item, filterFrom, filterTo, mode))
+	}
+	totals := make(map[string]models.SpendTotals, len(charges))
+	for serviceName, charge := range charges {
+		totals[serviceName] = charge.Rounded()
+	}
+	return totals, nil
+}
+
+// SubscriptionSpendReport returns the spend of the period grouped by category or tag, filter selects the subscriptions by tags
+func SubscriptionSpendReport(ctx context.Context, filterFrom time.Time, filterTo time.Time, userId *string, serviceName *string, mode models.SumMode,
+	groupBy models.ReportGroup, filter models.TagFilter) (_ *models.SpendReport, err error) {
+	ctx, done := startQuery(ctx, "subscription_spend_report", connections.OperationSum)
+	defer func() { done(err) }()
+	items, err := periodSubscriptions(ctx, filterFrom, filterTo, userId, serviceName)
+	if err != nil {
+		return nil, queryError(ctx, "subscription_spend_report", err)
+	}
+	categories := map[int32]string{}
+	if groupBy == "category" {
+		err = connections.RetryRead(ctx, func(db *sql.DB) error {
+			rows, errQuery := db.QueryContext(ctx, "SELECT id, category FROM service")
+			if errQuery != nil {
+				return errQuery
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var (
+					id       int32
+					category string
+				)
+				if errScan := rows.Scan(&id, &category); errScan != nil {
+					return errScan
+				}
+				categories[id] = category
+			}
+			return rows.Err()
+		})
+		if err != nil {
+			return nil, queryError(ctx, "subscription_spend_report", err)
+		}
+	}
+	report := models.BuildSpendReport(items, categories, filterFrom, filterTo, mode, groupBy, filter)
+	return &report, nil
+}
+
+// SubscriptionForecast projects the spend of months calendar months from the month of from
+func SubscriptionForecast(ctx context.Context, from time.Time, months int, userId *string, serviceName *string) (_ *models.Forecast, err error) {
+	ctx, done := startQuery(ctx, "subscription_forecast", connections.OperationSum)
+	defer func() { done(err) }()
+	if months < 1 || months > models.MaxForecastMonths {
+		return nil, fieldError("months", "query", fmt.Sprintf("must be between 1 and %d", models.MaxForecastMonths))
+	}
+	first := models.MonthOf(from)
+	items, err := periodSubscriptions(ctx, first.AddDate(0, -1, 0), first.AddDate(0, months, -1), userId, serviceName)
+	if err != nil {
+		return nil, queryError(ctx, "subscription_forecast", err)
+	}
+	forecast := models.BuildForecast(items, first, months)
+	return &forecast, nil
+}
+
+// SubscriptionCompare returns the spend of the current period against the base one with the change of every subscription
+func SubscriptionCompare(ctx context.Context, base models.Period, current models.Period, userId *string, serviceName *string, mode models.SumMode) (_ *models.Comparison, err error) {
+	ctx, done := startQuery(ctx, "subscription_compare", connections.OperationSum)
+	defer func() { done(err) }()
+	if base.To.Before(base.From) {
+		return nil, fieldError("baseTo", "formData", "must not be before baseFrom")
+	}
+	if current.To.Before(current.From) {
+		return nil, fieldError("filterTo", "formData", "must not be before filterFrom")
+	}
+	from, to := base.From, base.To
+	if current.From.Before(from) {
+		from = current.From
+	}
+	if current.To.After(to) {
+		to = current.To
+	}
+	items, err := periodSubscriptions(ctx, from, to, userId, serviceName)
+	if err != nil {
+		return nil, queryError(ctx, "subscription_compare", err)
+	}
+	comparison := models.BuildComparison(items, base, current, mode)
+	return &comparison, nil
+}
+
+// SubscriptionListByUsers returns all subscriptions of the given users in one query, grouped by user id
+func SubscriptionListByUsers(ctx context.Context, userIds []string) (_ map[string][]models.Subscription, err error) {
+	ctx, done := startQuery(ctx, "subscription_list_by_users", connections.OperationList)
+	defer func() { done(err) }()
+	query := "SELECT " + subscriptionColumns + " FROM subscription WHERE user_id = ANY($1) ORDER BY id DESC"
+	var byUser map[string][]models.Subscription
+	err = connections.RetryRead(ctx, func(db *sql.DB) error {
+		rows, errQuery := db.QueryContext(ctx, query, userIds)
+		if errQuery != nil {
+			return errQuery
+		}
+		defer rows.Close()
+		var items []models.Subscription
+		for rows.Next() {
+			var item models.Subscription
+			if errScan := scanSubscription(rows, &item); errScan != nil {
+				return errScan
+			}
+			items = append(items, item)
+		}
+		if errRows := rows.Err(); errRows != nil {
+			return errRows
+		}
+		if errRelated := attachRelated(ctx, db, items); errRelated != nil {
+			return errRelated
+		}
+		byUser = map[string][]models.Subscription{}
+		for _, item := range items {
+			byUser[item.UserId] = append(byUser[item.UserId], item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, queryError(ctx, "subscription_list_by_users", err)
+	}
+	return byUser, nil
+}
+
+// SubscriptionSummariesByUser returns today's summary of each given user, users without subscriptions are absent
+func SubscriptionSummariesByUser(ctx context.Context, userIds []string) (map[string]models.Summary, error) {
+	return subscriptionSummaries(ctx, "subscription_summaries_by_user", "user_id", userIds)
+}
+
+// SubscriptionSummariesByService returns today's summary of each given service, unknown services are absent
+func SubscriptionSummariesByService(ctx context.Context, serviceNames []string) (map[string]models.Summary, error) {
+	return subscriptionSummaries(ctx, "subscription_summaries_by_service", "service_name", serviceNames)
+}
+
+// subscriptionSummaries groups subscriptions active today by column, which must be a trusted column name
+func subscriptionSummaries(ctx context.Context, statement string, column string, keys []string) (_ map[string]models.Summary, err error) {
+	ctx, done := startQuery(ctx, statement, connections.OperationList)
+	defer func() { done(err) }()
+	query := fmt.Sprintf(`SELECT %[1]s, COUNT(*), ROUND(SUM(%[2]s))::integer FROM subscription
+	WHERE %[1]s = ANY($1) AND start_date <= CURRENT_DATE AND (finish_date >= CURRENT_DATE OR finish_date IS NULL)
+		AND %[3]s
+	GROUP BY %[1]s`, column, currentNetPriceSQL, notPausedTodaySQL)
+	var summaries map[string]models.Summary
+	err = connections.RetryRead(ctx, func(db *sql.DB) error {
+		rows, errQuery := db.QueryContext(ctx, query, keys)
+		if errQuery != nil {
+			return errQuery
+		}
+		defer rows.Close()
+		summaries = map[string]models.Summary{}
+		for rows.Next() {
+			var (
+				key     string
+				summary models.Summary
+			)
+			if errScan := rows.Scan(&key, &summary.Active, &summary.MonthlySpend); errScan != nil {
+				return errScan
+			}
+			summaries[key] = summary
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, queryError(ctx, statement, err)
+	}
+	return summaries, nil
+}
+
+// SubscriptionActiveStats returns the number of subscriptions active today and their monthly price by service
+func SubscriptionActiveStats(ctx context.Context) (_ int, _ map[string]int, err error) {
+	ctx, done := startQuery(ctx, "subscription_active_stats", connections.OperationList)
+	defer func() { done(err) }()
+	query := `SELECT service_name, COUNT(*), ROUND(SUM(` + currentNetPriceSQL + `))::integer FROM subscription
+	WHERE start_date <= CURRENT_DATE AND (finish_date >= CURRENT_DATE OR finish_date IS NULL)
+		AND ` + notPausedTodaySQL + `
+	GROUP BY service_name`
+	var (
+		active int
+		spend  map[string]int
+	)
+	err = connections.RetryRead(ctx, func(db *sql.DB) error {
+		rows, errQuery := db.QueryContext(ctx, query)
+		if errQuery != nil {
+			return errQuery
+		}
+		defer rows.Close()
+		active, spend = 0, map[string]int{}
+		for rows.Next() {
+			var (
+				serviceName  string
+				count, price int
+			)
+			if errScan := rows.Scan(&serviceName, &count, &price); errScan != nil {
+				return errScan
+			}
+			active += count
+			spend[serviceName] = price
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return 0, nil, queryError(ctx, "subscription_active_stats", err)
+	}
+	return active, spend, nil
+}
+
+// SubscriptionTrialsEnding returns subscriptions whose trial ends within days from today, soonest first
+func SubscriptionTrialsEnding(ctx context.Context, days int) (_ []models.Subscription, err error) {
+	ctx, done := startQuery(ctx, "subscription_trials_ending", connections.OperationList)
+	defer func() { done(err) }()
+	query := "SELECT " + subscriptionColumns + ` FROM subscription
+	WHERE trial_days > 0
+		AND start_date + trial_days - 1 BETWEEN $2::date AND $2::date + $1::integer
+		AND (finish_date >= $2::date OR finish_date IS NULL)
+	ORDER BY start_date + trial_days, id`
+	// today comes from the service like in the other billing code, not from the clock of the database
+	today := models.Today()
+	items := []models.Subscription{}
+	err = connections.RetryRead(ctx, func(db *sql.DB) error {
+		rows, errQuery := db.QueryContext(ctx, query, days, today)
+		if errQuery != nil {
+			return errQuery
+		}
+		defer rows.Close()
+		items = items[:0]
+		for rows.Next() {
+			var item models.Subscription
+			if errScan := scanSubscription(rows, &item); errScan != nil {
+				return errScan
+			}
+			items = append(items, item)
+		}
+		if errRows := rows.Err(); errRows != nil {
+			return errRows
+		}
+		return attachRelated(ctx, db, items)
+	})
+	if err != nil {
+		return nil, queryError(ctx, "subscription_trials_ending", err)
+	}
+	return items, nil
+}
+
+// PauseCreate adds a pause to its subscription, it must be inside the subscription lifetime and not overlap other pauses
+func PauseCreate(ctx context.Context, pause models.Pause) (_ *int32, err error) {
+	ctx, done := startQuery(ctx, "pause_create", connections.OperationWrite)
+	defer func() { done(err) }()
+	if pause.SubscriptionId < 1 {
+		return nil, fieldError("subscription_id", "body", "must be a positive integer")
+	}
+	var id int32
+	err = inTransaction(ctx, func(tx *sql.Tx) error {
+		item, errLock := lockSubscription(ctx, tx, pause.SubscriptionId)
+		if errLock != nil {
+			return errLock
+		}
+		item.Pauses = append(item.Pauses, pause)
+		if errValid := item.IsValid(); errValid != nil {
+			return errValid
+		}
+		query := "INSERT INTO subscription_pause (subscription_id, start_date, finish_date) VALUES ($1,$2,$3) RETURNING id"
+		return tx.QueryRowContext(ctx, query, pause.SubscriptionId, pause.StartDate, pause.FinishDate).Scan(&id)
+	})
+	if err != nil {
+		return nil, writeError(ctx, "pause_create", err)
+	}
+	return &id, nil
+}
+
+// PauseResume finishes the open pause of the subscription on the day before resumeDate
+func PauseResume(ctx context.Context, subscriptionId int32, resumeDate time.Time) (err error) {
+	ctx, done := startQuery(ctx, "pause_resume", connections.OperationWrite)
+	defer func() { done(err) }()
+	if subscriptionId < 1 {
+		return &models.InvalidParameterError{ParamName: "rowId"}
+	}
+	err = inTransaction(ctx, func(tx *sql.Tx) error {
+		item, errLock := lockSubscription(ctx, tx, subscriptionId)
+		if errLock != nil {
+			return errLock
+		}
+		open := -1
+		for i, pause := range item.Pauses {
+			if !pause.FinishDate.Valid {
+				open = i
+			}
+		}
+		if open < 0 {
+			return &models.ResourceNotFoundError{Err: fmt.Errorf("open pause of subscription %d", subscriptionId)}
+		}
+		item.Pauses[open].FinishDate = sql.NullTime{Valid: true, Time: resumeDate.AddDate(0, 0, -1)}
+		if errValid := item.IsValid(); errValid != nil {
+			return errValid
+		}
+		_, errExec := tx.ExecContext(ctx, "UPDATE subscription_pause SET finish_date = $1 WHERE id = $2",
+			item.Pauses[open].FinishDate, item.Pauses[open].Id)
+		return errExec
+	})
+	if err != nil {
+		return writeError(ctx, "pause_resume", err)
+	}
+	return nil
+}
+
+func PauseDelete(ctx context.Context, recordId int32) (err error) {
+	ctx, done := startQuery(ctx, "pause_delete", connections.OperationWrite)
+	defer func() { done(err) }()
+	if recordId < 1 {
+		return &models.InvalidParameterError{ParamName: "recordId"}
+	}
+	_, err = connections.PGDatabase.ExecContext(ctx, "DELETE FROM subscription_pause WHERE id = $1", recordId)
+	if err != nil {
+		return queryError(ctx, "pause_delete", err)
+	}
+	return nil
+}
+
+// DiscountCreate adds a discount to its subscription, it must not overlap other discounts
+func DiscountCreate(ctx context.Context, discount models.Discount) (_ *int32, err error) {
+	ctx, done := startQuery(ctx, "discount_create", connections.OperationWrite)
+	defer func() { done(err) }()
+	if discount.SubscriptionId < 1 {
+		return nil, fieldError("subscription_id", "body", "must be a positive integer")
+	}
+	if errValid := discount.IsValid(); errValid != nil {
+		return nil, errValid
+	}
+	var id int32
+	err = inTransaction(ctx, func(tx *sql.Tx) error {
+		item, errLock := lockSubscription(ctx, tx, discount.SubscriptionId)
+		if errLock != nil {
+			return errLock
+		}
+		item.Discounts = append(item.Discounts, discount)
+		if errValid := item.IsValid(); errValid != nil {
+			return errValid
+		}
+		query := `INSERT INTO subscription_discount (subscription_id, kind, amount, start_month, months, code)
+		VALUES ($1,$2,$3,$4,$5,$6) RETURNING id`
+		return tx.QueryRowContext(ctx, query, discount.SubscriptionId, discount.Kind, discount.Amount, discount.StartMonth,
+			discount.Months, discount.Code).Scan(&id)
+	})
+	if err != nil {
+		return nil, writeError(ctx, "discount_create", err)
+	}
+	return &id, nil
+}
+
+func DiscountDelete(ctx context.Context, recordId int32) (err error) {
+	ctx, done := startQuery(ctx, "discount_delete", connections.OperationWrite)
+	defer func() { done(err) }()
+	if recordId < 1 {
+		return &models.InvalidParameterError{ParamName: "recordId"}
+	}
+	_, err = connections.PGDatabase.ExecContext(ctx, "DELETE FROM subscription_discount WHERE id = $1", recordId)
+	if err != nil {
+		return queryError(ctx, "discount_delete", err)
+	}
+	return nil
+}
+
+// PriceChangeCreate schedules a new regular price of its subscription
+func PriceChangeCreate(ctx context.Context, change models.PriceChange) (_ *int32, err error) {
+	ctx, done := startQuery(ctx, "price_change_create", connections.OperationWrite)
+	defer func() { done(err) }()
+	if change.SubscriptionId < 1 {
+		return nil, fieldError("subscription_id", "body", "must be a positive integer")
+	}
+	var id int32
+	err = inTransaction(ctx, func(tx *sql.Tx) error {
+		item, errLock := lockSubscription(ctx, tx, change.SubscriptionId)
+		if errLock != nil {
+			return errLock
+		}
+		item.PriceChanges = append(item.PriceChanges, change)
+		if errValid := item.IsValid(); errValid != nil {
+			return errValid
+		}
+		query := "INSERT INTO subscription_price_change (subscription_id, effective_date, price) VALUES ($1,$2,$3) RETURNING id"
+		return tx.QueryRowContext(ctx, query, change.SubscriptionId, change.EffectiveDate, change.Price).Scan(&id)
+	})
+	if err != nil {
+		return nil, writeError(ctx, "price_change_create", err)
+	}
+	return &id, nil
+}
+
+func PriceChangeDelete(ctx context.Context, recordId int32) (err error) {
+	ctx, done := startQuery(ctx, "price_change_delete", connections.OperationWrite)
+	defer func() { done(err) }()
+	if recordId < 1 {
+		return &models.InvalidParameterError{ParamName: "recordId"}
+	}
+	_, err = connections.PGDatabase.ExecContext(ctx, "DELETE FROM subscription_price_change WHERE id = $1", recordId)
+	if err != nil {
+		return queryError(ctx, "price_change_delete", err)
+	}
+	return nil
+}
+
+// userSubscriptions reads the subscriptions of the user, of all users when userId is nil, with their related rows
+func userSubscriptions(ctx context.Context, userId *string) ([]models.Subscription, error) {
+	query := "SELECT " + subscriptionColumns + " FROM subscription"
+	var params []any
+	if userId != nil {
+		query += " WHERE user_id = $1"
+		params = append(params, *userId)
+	}
+	var items []models.Subscription
+	err := connections.RetryRead(ctx, func(db *sql.DB) error {
+		rows, errQuery := db.QueryContext(ctx, query+" ORDER BY user_id, start_date, id", params...)
+		if errQuery != nil {
+			return errQuery
+		}
+		defer rows.Close()
+		items = nil
+		for rows.Next() {
+			var item models.Subscription
+			if errScan := scanSubscription(rows, &item); errScan != nil {
+				return errScan
+			}
+			items = append(items, item)
+		}
+		if errRows := rows.Err(); errRows != nil {
+			return errRows
+		}
+		return attachRelated(ctx, db, items)
+	})
+	return items, err
+}
+
+// SubscriptionDuplicates returns the overlapping subscriptions of the same user and service, of all users when userId is nil
+func SubscriptionDuplicates(ctx context.Context, userId *string) (_ []models.DuplicateGroup, err error) {
+	ctx, done := startQuery(ctx, "subscription_duplicates", connections.OperationList)
+	defer func() { done(err) }()
+	items, err := userSubscriptions(ctx, userId)
+	if err != nil {
+		return nil, queryError(ctx, "subscription_duplicates", err)
+	}
+	return models.FindDuplicates(items), nil
+}
+
+// SubscriptionDuplicatesOf returns the overlaps of the item with the other subscriptions of its user,
+// the service name is resolved through the catalog first like create and update do
+func SubscriptionDuplicatesOf(ctx context.Context, item models.Subscription) (_ []models.Overlap, err error) {
+	ctx, done := startQuery(ctx, "subscription_duplicates_of", connections.OperationRead)
+	defer func() { done(err) }()
+	if errResolve := resolveService(ctx, connections.PGDatabase, &item); errResolve != nil {
+		return nil, queryError(ctx, "subscription_duplicates_of", errResolve)
+	}
+	others, err := userSubscriptions(ctx, &item.UserId)
+	if err != nil {
+		return nil, queryError(ctx, "subscription_duplicates_of", err)
+	}
+	return models.DuplicatesOf(item, others), nil
+}
+
+// SubscriptionMerge merges the duplicates into the subscription request.Into, see MergeSubscriptions. The duplicates
+// are deleted, their rows with pauses, discounts and price changes are kept in the merge history of the survivor
+func SubscriptionMerge(ctx context.Context, request models.MergeRequest) (_ *models.Subscription, err error) {
+	ctx, done := startQuery(ctx, "subscription_merge", connections.OperationWrite)
+	defer func() { done(err) }()
+	if request.Into < 1 {
+		return nil, fieldError("into", "body", "must be a positive integer")
+	}
+	if len(request.Ids) == 0 {
+		return nil, fieldError("ids", "body", "is empty")
+	}
+	ids := append([]int32{request.Into}, request.Ids...)
+	seen := map[int32]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			return nil, fieldError("ids", "body", fmt.Sprintf("subscription %d is given twice", id))
+		}
+		seen[id] = true
+	}
+	var merged models.Subscription
+	err = inTransaction(ctx, func(tx *sql.Tx) error {
+		// rows are locked in the order of their ids, so concurrent merges do not deadlock
+		sorted := append([]int32(nil), ids...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		locked := map[int32]models.Subscription{}
+		for _, id := range sorted {
+			item, errLock := lockSubscription(ctx, tx, id)
+			if errLock != nil {
+				return errLock
+			}
+			locked[id] = item
+		}
+		survivor := locked[request.Into]
+		var duplicates []models.Subscription
+		for _, id := range request.Ids {
+			item := locked[id]
+			if item.UserId != survivor.UserId {
+				return fieldError("ids", "body", fmt.Sprintf("subscription %d belongs to another user", id))
+			}
+			if _, ok := models.MatchServices(survivor, item); !ok {
+				return fieldError("ids", "body", fmt.Sprintf("subscription %d is for another service", id))
+			}
+			duplicates = append(duplicates, item)
+		}
+		merged = models.MergeSubscriptions(survivor, duplicates)
+		if len(merged.DiscountErrors()) > 0 {
+			return fieldError("ids", "body", "discounts of the subscriptions overlap, delete the ones not to keep before merging")
+		}
+		if errValid := merged.IsValid(); errValid != nil {
+			return errValid
+		}
+		tags, errTags := json.Marshal(merged.Tags)
+		if errTags != nil {
+			return errTags
+		}
+		_, errExec := tx.ExecContext(ctx, `UPDATE subscription SET price = $1, start_date = $2, finish_date = $3, billing_day = $4,
+		trial_days = $5, trial_price = $6, intro_months = $7, intro_price = $8, tags = $9 WHERE id = $10`,
+			merged.Price, merged.StartDate, merged.FinishDate, merged.BillingDay, merged.TrialDays, merged.TrialPrice,
+			merged.IntroMonths, merged.IntroPrice, tags, merged.Id)
+		if errExec != nil {
+			return errExec
+		}
+		if _, errExec = tx.ExecContext(ctx, "DELETE FROM subscription_pause WHERE subscription_id = $1", merged.Id); errExec != nil {
+			return errExec
+		}
+		for _, pause := range merged.Pauses {
+			_, errExec = tx.ExecContext(ctx, "INSERT INTO subscription_pause (subscription_id, start_date, finish_date) VALUES ($1,$2,$3)",
+				merged.Id, pause.StartDate, pause.FinishDate)
+			if errExec != nil {
+				return errExec
+			}
+		}
+		if _, errExec = tx.ExecContext(ctx, "DELETE FROM subscription_price_change WHERE subscription_id = $1", merged.Id); errExec != nil {
+			return errExec
+		}
+		for _, change := range merged.PriceChanges {
+			_, errExec = tx.ExecContext(ctx, "INSERT INTO subscription_price_change (subscription_id, effective_date, price) VALUES ($1,$2,$3)",
+				merged.Id, change.EffectiveDate, change.Price)
+			if errExec != nil {
+				return errExec
+			}
+		}
+		for _, item := range duplicates {
+			record, errRecord := json.Marshal(&item)
+			if errRecord != nil {
+				return errRecord
+			}
+			// the history of a duplicate that was a survivor before moves to the new survivor
+			_, errExec = tx.ExecContext(ctx, "UPDATE subscription_merge SET subscription_id = $1 WHERE subscription_id = $2", merged.Id, item.Id)
+			if errExec != nil {
+				return errExec
+			}
+			_, errExec = tx.ExecContext(ctx, "UPDATE subscription_discount SET subscription_id = $1 WHERE subscription_id = $2", merged.Id, item.Id)
+			if errExec != nil {
+				return errExec
+			}
+			_, errExec = tx.ExecContext(ctx, "INSERT INTO subscription_merge (subscription_id, merged_id, record) VALUES ($1,$2,$3)",
+				merged.Id, item.Id, record)
+			if errExec != nil {
+				return errExec
+			}
+			if _, errExec = tx.ExecContext(ctx, "DELETE FROM subscription WHERE id = $1", item.Id); errExec != nil {
+				return errExec
+			}
+		}
+		var errRead error
+		merged, errRead = lockSubscription(ctx, tx, merged.Id)
+		return errRead
+	})
+	if err != nil {
+		return nil, writeError(ctx, "subscription_merge", err)
+	}
+	slog.InfoContext(ctx, "subscriptions merged", "into", request.Into, "ids", request.Ids)
+	return &merged, nil
+}
+
+// SubscriptionMerges returns the records merged into the subscription, oldest first
+func SubscriptionMerges(ctx context.Context, subscriptionId int32) (_ []models.MergedRecord, err error) {
+	ctx, done := startQuery(ctx, "subscription_merges", connections.OperationList)
+	defer func() { done(err) }()
+	if subscriptionId < 1 {
+		return nil, &models.InvalidParameterError{ParamName: "rowId"}
+	}
+	query := "SELECT id, subscription_id, merged_id, record, merged_at FROM subscription_merge WHERE subscription_id = $1 ORDER BY merged_at, id"
+	records := []models.MergedRecord{}
+	err = connections.RetryRead(ctx, func(db *sql.DB) error {
+		rows, errQuery := db.QueryContext(ctx, query, subscriptionId)
+		if errQuery != nil {
+			return errQuery
+		}
+		defer rows.Close()
+		records = records[:0]
+		for rows.Next() {
+			var (
+				record models.MergedRecord
+				body   []byte
+			)
+			if errScan := rows.Scan(&record.Id, &record.SubscriptionId, &record.MergedId, &body, &record.MergedAt); errScan != nil {
+				return errScan
+			}
+			if errJson := json.Unmarshal(body, &record.Record); errJson != nil {
+				return errJson
+			}
+			records = append(records, record)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, queryError(ctx, "subscription_merges", err)
+	}
+	return records, nil
+}
+
+// serviceColumns are the columns read by scanService, in its order
+const serviceColumns = "id, name, aliases, category, vendor_url, plans"
+
+func scanService(row interface{ Scan(...any) error }, service *models.Service) error {
+	var aliases, plans []byte
+	if err := row.Scan(&service.Id, &service.Name, &aliases, &service.Category, &service.VendorURL, &plans); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(aliases, &service.Aliases); err != nil {
+		return err
+	}
+	return json.Unmarshal(plans, &service.Plans)
+}
+
+// saveServiceKeys points the name and the aliases of the service to it and links the subscriptions matching them,
+// keys of other services are reported as validation errors
+func saveServiceKeys(ctx context.Context, tx *sql.Tx, service models.Service) error {
+	keys := service.Keys()
+	rows, err := tx.QueryContext(ctx, `SELECT a.key, s.name FROM service_alias a JOIN service s ON s.id = a.service_id
+	WHERE a.key = ANY($1) AND a.service_id <> $2 ORDER BY a.key`, keys, service.Id)
+	if err != nil {
+		return err
+	}
+	var vErr models.ValidationError
+	for rows.Next() {
+		var key, owner string
+		if err := rows.Scan(&key, &owner); err != nil {
+			rows.Close()
+			return err
+		}
+		vErr.Errors = append(vErr.Errors, &models.FieldError{Field: "aliases", In: "body", Message: fmt.Sprintf("%q already belongs to %s", key, owner)})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(vErr.Errors) != 0 {
+		return &vErr
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM service_alias WHERE service_id = $1", service.Id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO service_alias (key, service_id) SELECT unnest($1::text[]), $2", keys, service.Id); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE subscription SET service_id = $1, service_name = $2
+	WHERE service_id = $1 OR `+models.ServiceKeySQL("service_name")+` = ANY($3)`, service.Id, service.Name, keys)
+	return err
+}
+
+// serviceParams returns the json columns of the service
+func serviceParams(service models.Service) (aliases []byte, plans []byte, err error) {
+	if aliases, err = json.Marshal(service.Aliases); err != nil {
+		return nil, nil, err
+	}
+	plans, err = json.Marshal(service.Plans)
+	return aliases, plans, err
+}
+
+// ServiceCreate adds a catalog entry, existing subscriptions matching its name or aliases are linked to it
+func ServiceCreate(ctx context.Context, service models.Service) (_ *int32, err error) {
+	ctx, done := startQuery(ctx, "service_create", connections.OperationWrite)
+	defer func() { done(err) }()
+	service.Normalize()
+	if errValid := service.IsValid(); errValid != nil {
+		return nil, errValid
+	}
+	aliases, plans, err := serviceParams(service)
+	if err != nil {
+		return nil, err
+	}
+	err = inTransaction(ctx, func(tx *sql.Tx) error {
+		query := `INSERT INTO service (name, aliases, category, vendor_url, plans) VALUES ($1,$2,$3,$4,$5)
+		ON CONFLICT (name) DO NOTHING RETURNING id`
+		errInsert := tx.QueryRowContext(ctx, query, service.Name, aliases, service.Category, service.VendorURL, plans).Scan(&service.Id)
+		if errors.Is(errInsert, sql.ErrNoRows) {
+			return fieldError("name", "body", "already exists")
+		}
+		if errInsert != nil {
+			return errInsert
+		}
+		return saveServiceKeys(ctx, tx, service)
+	})
+	if err != nil {
+		return nil, writeError(ctx, "service_create", err)
+	}
+	return &service.Id, nil
+}
+
+// ServiceUpdate replaces a catalog entry, the subscriptions linked to it are renamed to the new name
+func ServiceUpdate(ctx context.Context, service models.Service) (err error) {
+	ctx, done := startQuery(ctx, "service_update", connections.OperationWrite)
+	defer func() { done(err) }()
+	service.Normalize()
+	if errValid := service.IsValid(); errValid != nil {
+		return errValid
+	}
+	if service.Id < 1 {
+		return fieldError("id", "body", "must be a positive integer")
+	}
+	aliases, plans, err := serviceParams(service)
+	if err != nil {
+		return err
+	}
+	err = inTransaction(ctx, func(tx *sql.Tx) error {
+		query := `UPDATE service SET name = $1, aliases = $2, category = $3, vendor_url = $4, plans = $5 WHERE id = $6`
+		result, errExec := tx.ExecContext(ctx, query, service.Name, aliases, service.Category, service.VendorURL, plans, service.Id)
+		if errExec != nil {
+			return errExec
+		}
+		if updated, _ := result.RowsAffected(); updated == 0 {
+			return &models.ResourceNotFoundError{Err: fmt.Errorf("service %d", service.Id)}
+		}
+		return saveServiceKeys(ctx, tx, service)
+	})
+	if err != nil {
+		return writeError(ctx, "service_update", err)
+	}
+	return nil
+}
+
+// ServiceDelete removes a catalog entry, its subscriptions keep their names and lose the link
+func ServiceDelete(ctx context.Context, recordId int32) (err error) {
+	ctx, done := startQuery(ctx, "service_delete", connections.OperationWrite)
+	defer func() { done(err) }()
+	if recordId < 1 {
+		return &models.InvalidParameterError{ParamName: "recordId"}
+	}
+	_, err = connections.PGDatabase.ExecContext(ctx, "DELETE FROM service WHERE id = $1", recordId)
+	if err != nil {
+		return queryError(ctx, "service_delete", err)
+	}
+	return nil
+}
+
+func ServiceRead(ctx context.Context, recordId int32) (_ *models.Service, err error) {
+	ctx, done := startQuery(ctx, "service_read", connections.OperationRead)
+	defer func() { done(err) }()
+	if recordId < 1 {
+		return nil, &models.InvalidParameterError{ParamName: "recordId"}
+	}
+	var service models.Service
+	err = connections.RetryRead(ctx, func(db *sql.DB) error {
+		return scanService(db.QueryRowContext(ctx, "SELECT "+serviceColumns+" FROM service WHERE id = $1", recordId), &service)
+	})
+	if err != nil {
+		return nil, queryError(ctx, "service_read", err)
+	}
+	return &service, nil
+}
+
+// ServiceResolve returns the catalog entry the name or alias belongs to, sql.ErrNoRows when there is none
+func ServiceResolve(ctx context.Context, name string) (_ *models.Service, err error) {
+	ctx, done := startQuery(ctx, "service_resolve", connections.OperationRead)
+	defer func() { done(err) }()
+	query := "SELECT " + serviceColumns + " FROM service WHERE id = (SELECT service_id FROM service_alias WHERE key = $1)"
+	var service models.Service
+	err = connections.RetryRead(ctx, func(db *sql.DB) error {
+		return scanService(db.QueryRowContext(ctx, query, models.ServiceKey(name)), &service)
+	})
+	if err != nil {
+		return nil, queryError(ctx, "service_resolve", err)
+	}
+	return &service, nil
+}
+
+// ServiceList returns the whole catalog ordered by name
+func ServiceList(ctx context.Context) (_ []models.Service, err error) {
+	ctx, done := startQuery(ctx, "service_list", connections.OperationList)
+	defer func() { done(err) }()
+	services, err := queryServices(ctx, "SELECT "+serviceColumns+" FROM service ORDER BY name")
+	if err != nil {
+		return nil, queryError(ctx, "service_list", err)
+	}
+	return services, nil
+}
+
+// ServicesByNames returns the catalog entries with the given canonical names, unknown names are absent
+func ServicesByNames(ctx context.Context, names []string) (_ map[string]models.Service, err error) {
+	ctx, done := startQuery(ctx, "services_by_names", connections.OperationList)
+	defer func() { done(err) }()
+	services, err := queryServices(ctx, "SELECT "+serviceColumns+" FROM service WHERE name = ANY($1)", names)
+	if err != nil {
+		return nil, queryError(ctx, "services_by_names", err)
+	}
+	byName := make(map[string]models.Service, len(services))
+	for _, service := range services {
+		byName[service.Name] = service
+	}
+	return byName, nil
+}
+
+func queryServices(ctx context.Context, query string, params ...any) ([]models.Service, error) {
+	services := []models.Service{}
+	err := connections.RetryRead(ctx, func(db *sql.DB) error {
+		rows, errQuery := db.QueryContext(ctx, query, params...)
+		if errQuery != nil {
+			return errQuery
+		}
+		defer rows.Close()
+		services = services[:0]
+		for rows.Next() {
+			var service models.Service
+			if errScan := scanService(rows, &service); errScan != nil {
+				return errScan
+			}
+			services = append(services, service)
+		}
+		return rows.Err()
+	})
+	return services, err
+}
+
+// budgetColumns are the columns read by scanBudget, in its order
+const budgetColumns = "id, scope, target, amount, thresholds"
+
+func scanBudget(row interface{ Scan(...any) error }, budget *models.Budget) error {
+	var thresholds []byte
+	if err := row.Scan(&budget.Id, &budget.Scope, &budget.Target, &budget.Amount, &thresholds); err != nil {
+		return err
+	}
+	return json.Unmarshal(thresholds, &budget.Thresholds)
+}
+
+// BudgetCreate adds a monthly budget, service targets are stored under the canonical catalog name
+func BudgetCreate(ctx context.Context, budget models.Budget) (_ *int32, err error) {
+	ctx, done := startQuery(ctx, "budget_create", connections.OperationWrite)
+	defer func() { done(err) }()
+	budget.Normalize()
+	if errValid := budget.IsValid(); errValid != nil {
+		return nil, errValid
+	}
+	thresholds, err := json.Marshal(budget.Thresholds)
+	if err != nil {
+		return nil, err
+	}
+	err = inTransaction(ctx, func(tx *sql.Tx) error {
+		if budget.Scope == models.BudgetService {
+			item := models.Subscription{ServiceName: budget.Target}
+			if errResolve := resolveService(ctx, tx, &item); errResolve != nil {
+				return errResolve
+			}
+			budget.Target = item.ServiceName
+		}
+		query := `INSERT INTO budget (scope, target, amount, thresholds) VALUES ($1,$2,$3,$4)
+		ON CONFLICT (scope, target) DO NOTHING RETURNING id`
+		errInsert := tx.QueryRowContext(ctx, query, budget.Scope, budget.Target, budget.Amount, thresholds).Scan(&budget.Id)
+		if errors.Is(errInsert, sql.ErrNoRows) {
+			return fieldError("target", "body", "already has a budget")
+		}
+		return errInsert
+	})
+	if err != nil {
+		return nil, writeError(ctx, "budget_create", err)
+	}
+	return &budget.Id, nil
+}
+
+// BudgetDelete removes a budget together with its alerts
+func BudgetDelete(ctx context.Context, recordId int32) (err error) {
+	ctx, done := startQuery(ctx, "budget_delete", connections.OperationWrite)
+	defer func() { done(err) }()
+	if recordId < 1 {
+		return &models.InvalidParameterError{ParamName: "recordId"}
+	}
+	_, err = connections.PGDatabase.ExecContext(ctx, "DELETE FROM budget WHERE id = $1", recordId)
+	if err != nil {
+		return queryError(ctx, "budget_delete", err)
+	}
+	return nil
+}
+
+// BudgetList returns all budgets ordered by scope and target
+func BudgetList(ctx context.Context) (_ []models.Budget, err error) {
+	ctx, done := startQuery(ctx, "budget_list", connections.OperationList)
+	defer func() { done(err) }()
+	budgets := []models.Budget{}
+	err = connections.RetryRead(ctx, func(db *sql.DB) error {
+		rows, errQuery := db.QueryContext(ctx, "SELECT "+budgetColumns+" FROM budget ORDER BY scope, target")
+		if errQuery != nil {
+			return errQuery
+		}
+		defer rows.Close()
+		budgets = budgets[:0]
+		for rows.Next() {
+			var budget models.Budget
+			if errScan := scanBudget(rows, &budget); errScan != nil {
+				return errScan
+			}
+			budgets = append(budgets, budget)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, queryError(ctx, "budget_list", err)
+	}
+	return budgets, nil
+}
+
+// budgetServiceKeys returns the match keys of every service target, the catalog aliases of the service included
+func budgetServiceKeys(ctx context.Context, budgets []models.Budget) (map[string]map[string]bool, error) {
+	keys := map[string]map[string]bool{}
+	var targets []string
+	for _, budget := range budgets {
+		if budget.Scope == models.BudgetService {
+			key := models.ServiceKey(budget.Target)
+			keys[budget.Target] = map[string]bool{key: true}
+			targets = append(targets, key)
+		}
+	}
+	if len(targets) == 0 {
+		return keys, nil
+	}
+	byKey := map[string][]string{}
+	err := connections.RetryRead(ctx, func(db *sql.DB) error {
+		rows, errQuery := db.QueryContext(ctx, `SELECT t.key, a.key FROM service_alias t
+		JOIN service_alias a ON a.service_id = t.service_id WHERE t.key = ANY($1)`, targets)
+		if errQuery != nil {
+			return errQuery
+		}
+		defer rows.Close()
+		clear(byKey)
+		for rows.Next() {
+			var target, alias string
+			if errScan := rows.Scan(&target, &alias); errScan != nil {
+				return errScan
+			}
+			byKey[target] = append(byKey[target], alias)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	for target, set := range keys {
+		for _, alias := range byKey[models.ServiceKey(target)] {
+			set[alias] = true
+		}
+	}
+	return keys, nil
+}
+
+// BudgetStatuses evaluates every budget against the spend of the month of today
+func BudgetStatuses(ctx context.Context, today time.Time) (_ []models.BudgetStatus, err error) {
+	ctx, done := startQuery(ctx, "budget_statuses", connections.OperationSum)
+	defer func() { done(err) }()
+	budgets, err := BudgetList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]models.BudgetStatus, 0, len(budgets))
+	if len(budgets) == 0 {
+		return statuses, nil
+	}
+	month := models.MonthOf(today)
+	items, err := periodSubscriptions(ctx, month, month.AddDate(0, 1, -1), nil, nil)
+	if err != nil {
+		return nil, queryError(ctx, "budget_statuses", err)
+	}
+	serviceKeys, err := budgetServiceKeys(ctx, budgets)
 	if err != nil {
-		return 0, &models.DatabaseError{Err: err}
+		return nil, queryError(ctx, "budget_statuses", err)
 	}
-	return res, nil
+	for _, budget := range budgets {
+		statuses = append(statuses, models.EvaluateBudget(budget, items, serviceKeys[budget.Target], today))
+	}
+	return statuses, nil
+}
+
+// BudgetEvaluate records an alert for every threshold reached in the month of today, thresholds alerted before are skipped
+func BudgetEvaluate(ctx context.Context, today time.Time) (created int, err error) {
+	statuses, err := BudgetStatuses(ctx, today)
+	if err != nil {
+		return 0, err
+	}
+	ctx, done := startQuery(ctx, "budget_evaluate", connections.OperationWrite)
+	defer func() { done(err) }()
+	query := `INSERT INTO budget_alert (budget_id, month, threshold, amount, spent, projected) VALUES ($1,$2,$3,$4,$5,$6)
+	ON CONFLICT (budget_id, month, threshold) DO NOTHING`
+	for _, status := range statuses {
+		for _, threshold := range status.Reached {
+			result, errExec := connections.PGDatabase.ExecContext(ctx, query, status.Budget.Id, status.Month, threshold,
+				status.Budget.Amount, status.Spent, status.Projected)
+			if errExec != nil {
+				return created, queryError(ctx, "budget_evaluate", errExec)
+			}
+			inserted, _ := result.RowsAffected()
+			created += int(inserted)
+		}
+	}
+	return created, nil
+}
+
+// budgetAlertColumns are the columns read by scanBudgetAlert, in its order
+const budgetAlertColumns = "a.id, a.budget_id, b.scope, b.target, a.month, a.threshold, a.amount, a.spent, a.projected, a.created_at"
+
+func scanBudgetAlert(row interface{ Scan(...any) error }, alert *models.BudgetAlert) error {
+	return row.Scan(&alert.Id, &alert.BudgetId, &alert.Scope, &alert.Target, &alert.Month, &alert.Threshold,
+		&alert.Amount, &alert.Spent, &alert.Projected, &alert.CreatedAt)
+}
+
+// BudgetAlerts returns the alerts of the budget, of all budgets when budgetId is nil, newest first
+func BudgetAlerts(ctx context.Context, budgetId *int32) (_ []models.BudgetAlert, err error) {
+	ctx, done := startQuery(ctx, "budget_alerts", connections.OperationList)
+	defer func() { done(err) }()
+	query := "SELECT " + budgetAlertColumns + " FROM budget_alert a JOIN budget b ON b.id = a.budget_id"
+	var params []any
+	if budgetId != nil {
+		query += " WHERE a.budget_id = $1"
+		params = append(params, *budgetId)
+	}
+	alerts, err := queryBudgetAlerts(ctx, query+" ORDER BY a.id DESC", params...)
+	if err != nil {
+		return nil, queryError(ctx, "budget_alerts", err)
+	}
+	return alerts, nil
+}
+
+// pendingBudgetAlerts returns the alerts whose notification was not delivered yet, oldest first
+func pendingBudgetAlerts(ctx context.Context) (_ []models.BudgetAlert, err error) {
+	ctx, done := startQuery(ctx, "budget_alerts_pending", connections.OperationList)
+	defer func() { done(err) }()
+	// the alerts are inserted and marked as notified on the primary, a lagging replica would send them twice
+	query := "SELECT " + budgetAlertColumns + " FROM budget_alert a JOIN budget b ON b.id = a.budget_id WHERE a.notified_at IS NULL ORDER BY a.id"
+	alerts, err := readBudgetAlerts(ctx, connections.PGDatabase, query)
+	if err != nil {
+		return nil, queryError(ctx, "budget_alerts_pending", err)
+	}
+	return alerts, nil
+}
+
+func queryBudgetAlerts(ctx context.Context, query string, params ...any) ([]models.BudgetAlert, error) {
+	var alerts []models.BudgetAlert
+	err := connections.RetryRead(ctx, func(db *sql.DB) error {
+		var errRead error
+		alerts, errRead = readBudgetAlerts(ctx, db, query, params...)
+		return errRead
+	})
+	return alerts, err
+}
+
+func readBudgetAlerts(ctx context.Context, db *sql.DB, query string, params ...any) ([]models.BudgetAlert, error) {
+	rows, err := db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	alerts := []models.BudgetAlert{}
+	for rows.Next() {
+		var alert models.BudgetAlert
+		if err := scanBudgetAlert(rows, &alert); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+// budgetAlertNotified marks the alert as delivered
+func budgetAlertNotified(ctx context.Context, recordId int32) (err error) {
+	ctx, done := startQuery(ctx, "budget_alert_notified", connections.OperationWrite)
+	defer func() { done(err) }()
+	_, err = connections.PGDatabase.ExecContext(ctx, "UPDATE budget_alert SET notified_at = now() WHERE id = $1", recordId)
+	if err != nil {
+		return queryError(ctx, "budget_alert_notified", err)
+	}
+	return nil
 }
@@ -0,0 +1,327 @@
+"use strict";
+
+// dashboard for the subscriptions api, every figure comes from the json endpoints of the service
+
+const state = { page: 1, perPage: 50, total: 0, items: [] };
+const colors = ["#1976d2", "#388e3c", "#f57c00", "#7b1fa2", "#c2185b", "#0097a7", "#607d8b"];
+const maxChartGroups = 6;
+
+const $ = (id) => document.getElementById(id);
+
+function headers(extra) {
+  const result = Object.assign({}, extra);
+  const key = $("api-key").value.trim();
+  if (key) {
+    result["Authorization"] = "Bearer " + key;
+  }
+  return result;
+}
+
+async function api(method, path, options) {
+  const response = await fetch(path, Object.assign({ method: method, headers: headers(options && options.headers) }, options && { body: options.body }));
+  const text = await response.text();
+  if (!response.ok) {
+    throw new Error(text || response.statusText);
+  }
+  return text;
+}
+
+function filters() {
+  const params = new URLSearchParams();
+  const user = $("filter-user").value.trim();
+  const service = $("filter-service").value.trim();
+  if (user) params.set("userId", user);
+  if (service) params.set("serviceName", service);
+  return params;
+}
+
+function setStatus(message, isError) {
+  $("status").textContent = message;
+  $("status").className = isError ? "error" : "";
+}
+
+// "YYYY-MM-DD" dates of the api and "YYYY-MM" values of month inputs share the month prefix
+const monthIndex = (value) => Number(value.slice(0, 4)) * 12 + Number(value.slice(5, 7)) - 1;
+const monthLabel = (index) => String(index % 12 + 1).padStart(2, "0") + "-" + Math.floor(index / 12);
+
+async function loadPage(page) {
+  const params = filters();
+  params.set("page", page);
+  try {
+    const list = JSON.parse(await api("GET", "/subscription/list?" + params));
+    Object.assign(state, { page: list.Page, perPage: list.PerPage, total: list.Total, items: list.List || [] });
+    renderTable();
+    setStatus("");
+  } catch (err) {
+    setStatus(err.message, true);
+  }
+}
+
+async function loadAll() {
+  const all = [];
+  for (let page = 1; ; page++) {
+    const params = filters();
+    params.set("page", page);
+    const list = JSON.parse(await api("GET", "/subscription/list?" + params));
+    all.push(...(list.List || []));
+    if (!list.List || list.List.length === 0 || page * list.PerPage >= list.Total) {
+      return all;
+    }
+  }
+}
+
+function cell(row, text) {
+  const td = row.insertCell();
+  td.textContent = text;
+  return td;
+}
+
+function renderTable() {
+  const body = $("subscriptions").tBodies[0];
+  body.replaceChildren();
+  for (const item of state.items) {
+    const row = body.insertRow();
+    [item.id, item.service_name, item.price, item.user_id, item.start_date, item.finish_date || "—", item.billing_day].forEach((v) => cell(row, v));
+    const actions = cell(row, "");
+    actions.className = "actions";
+    const edit = document.createElement("button");
+    edit.textContent = "Edit";
+    edit.onclick = () => openEditor(item);
+    const remove = document.createElement("button");
+    remove.textContent = "Delete";
+    remove.className = "danger";
+    remove.onclick = () => deleteSubscription(item);
+    actions.append(edit, " ", remove);
+  }
+  const pages = Math.max(1, Math.ceil(state.total / state.perPage));
+  $("page-info").textContent = `page ${state.page} of ${pages}, ${state.total} total`;
+  $("prev-page").disabled = state.page <= 1;
+  $("next-page").disabled = state.page >= pages;
+}
+
+const trialFields = ["trial_days", "trial_price", "intro_months", "intro_price"];
+
+function openEditor(item) {
+  const form = $("subscription-form");
+  form.reset();
+  $("form-error").textContent = "";
+  $("editor-title").textContent = item ? `Edit subscription ${item.id}` : "New subscription";
+  if (item) {
+    form.elements.id.value = item.id;
+    form.elements.service_name.value = item.service_name;
+    form.elements.price.value = item.price;
+    form.elements.user_id.value = item.user_id;
+    form.elements.start_date.value = item.start_date;
+    form.elements.finish_date.value = item.finish_date || "";
+    form.elements.billing_day.value = item.billing_day;
+    for (const name of trialFields) form.elements[name].value = item[name] || 0;
+  }
+  $("editor").showModal();
+}
+
+// validate mirrors Subscription.IsValid, the server remains the source of truth
+function validate(item) {
+  const errors = [];
+  if (!item.service_name) errors.push("service name is empty");
+  if (!/^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$/i.test(item.user_id)) errors.push("user id must be uuid");
+  for (const date of [item.start_date, item.finish_date]) {
+    if (date && Number(date.slice(0, 4)) < 2020) errors.push("incorrect date, years after 2020 accepted");
+  }
+  if (!item.start_date) errors.push("start date is required");
+  if (item.billing_day && (item.billing_day < 1 || item.billing_day > 31)) errors.push("billing day must be between 1 and 31");
+  for (const name of trialFields) {
+    if (item[name] < 0) errors.push(name.replace("_", " ") + " must not be negative");
+  }
+  if (item.intro_months > 0 && item.intro_price === 0) errors.push("intro price is required when intro months is set, use trial days for free periods");
+  return errors;
+}
+
+async function saveSubscription(event) {
+  event.preventDefault();
+  const form = $("subscription-form");
+  const id = Number(form.elements.id.value || 0);
+  const item = {
+    id: id,
+    service_name: form.elements.service_name.value.trim(),
+    price: Number(form.elements.price.value),
+    user_id: form.elements.user_id.value.trim(),
+    start_date: form.elements.start_date.value,
+    finish_date: form.elements.finish_date.value,
+    billing_day: Number(form.elements.billing_day.value),
+  };
+  for (const name of trialFields) item[name] = Number(form.elements[name].value);
+  const errors = validate(item);
+  if (errors.length > 0) {
+    $("form-error").textContent = errors.join(", ");
+    return;
+  }
+  try {
+    await api(id ? "PUT" : "POST", id ? "/subscription/update" : "/subscription/create", {
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify(item),
+    });
+    $("editor").close();
+    setStatus(id ? `subscription ${id} updated` : "subscription created");
+    await loadPage(state.page);
+  } catch (err) {
+    $("form-error").textContent = err.message;
+  }
+}
+
+async function deleteSubscription(item) {
+  if (!confirm(`Delete subscription ${item.id} (${item.service_name})?`)) {
+    return;
+  }
+  try {
+    await api("DELETE", "/subscription/delete?rowId=" + item.id);
+    setStatus(`subscription ${item.id} deleted`);
+    await loadPage(state.page);
+  } catch (err) {
+    setStatus(err.message, true);
+  }
+}
+
+function post(path, params) {
+  return api("POST", path, { headers: { "Content-Type": "application/x-www-form-urlencoded" }, body: params });
+}
+
+// periodParams adds the months from and to, as "MM-YYYY", to the filters of the page
+function periodParams(from, to, extra) {
+  const params = filters();
+  params.set("filterFrom", monthLabel(from));
+  params.set("filterTo", monthLabel(to));
+  for (const [k, v] of Object.entries(extra || {})) params.set(k, v);
+  return params;
+}
+
+const fetchSum = (from, to, extra) => post("/subscription/sum", periodParams(from, to, extra)).then(Number);
+
+// monthlySums asks the sum endpoint for every month of the period, extra narrows it down to one group
+function monthlySums(from, to, extra) {
+  const months = [];
+  for (let m = from; m <= to; m++) months.push(fetchSum(m, m, extra));
+  return Promise.all(months);
+}
+
+// spendByField charts the services or users with the largest spend of the period, the rest are summed up as "other"
+async function spendByField(from, to, groupBy) {
+  const field = groupBy === "user" ? "user_id" : "service_name";
+  const param = groupBy === "user" ? "userId" : "serviceName";
+  const names = [...new Set((await loadAll()).map((item) => item[field]))];
+  const totals = await Promise.all(names.map((name) => fetchSum(from, to, { [param]: name })));
+  const ranked = names.map((name, i) => [name, totals[i]]).filter((g) => g[1] > 0).sort((a, b) => b[1] - a[1]);
+  const shown = ranked.length > maxChartGroups ? ranked.slice(0, maxChartGroups - 1) : ranked;
+  const groups = await Promise.all(shown.map(async ([name]) => [name, await monthlySums(from, to, { [param]: name })]));
+  if (shown.length < ranked.length) {
+    const all = await monthlySums(from, to);
+    groups.push(["other", all.map((total, i) => total - sum(groups.map((g) => g[1][i])))]);
+  }
+  return groups;
+}
+
+// spendByCategory charts the net spend of the report endpoint, one report per month
+async function spendByCategory(from, to) {
+  const reports = [];
+  for (let m = from; m <= to; m++) {
+    reports.push(post("/subscription/report", periodParams(m, m, { groupBy: "category" })).then(JSON.parse));
+  }
+  const totals = new Map();
+  (await Promise.all(reports)).forEach((report, i) => {
+    for (const group of report.groups || []) {
+      const name = group.name || "uncategorized";
+      if (!totals.has(name)) totals.set(name, new Array(to - from + 1).fill(0));
+      totals.get(name)[i] += group.net;
+    }
+  });
+  const groups = [...totals.entries()].sort((a, b) => sum(b[1]) - sum(a[1]));
+  if (groups.length > maxChartGroups) {
+    const other = groups.splice(maxChartGroups - 1).reduce((acc, g) => acc.map((v, i) => v + g[1][i]), new Array(to - from + 1).fill(0));
+    groups.push(["other", other]);
+  }
+  return groups;
+}
+
+const sum = (values) => values.reduce((a, b) => a + b, 0);
+
+function svg(tag, attrs) {
+  const el = document.createElementNS("http://www.w3.org/2000/svg", tag);
+  for (const [k, v] of Object.entries(attrs)) el.setAttribute(k, v);
+  return el;
+}
+
+function renderChart(groups, from, to) {
+  const width = 900, height = 320, left = 60, bottom = 30;
+  const months = to - from + 1;
+  const totals = Array.from({ length: months }, (_, i) => sum(groups.map((g) => g[1][i])));
+  const peak = Math.max(1, ...totals);
+  const barWidth = (width - left) / months;
+  const chart = svg("svg", { viewBox: `0 0 ${width} ${height}` });
+  for (let i = 0; i <= 4; i++) {
+    const y = (height - bottom) * (1 - i / 4);
+    chart.append(svg("line", { x1: left, x2: width, y1: y, y2: y, stroke: "#eee" }));
+    const label = svg("text", { x: left - 6, y: y + 4, "text-anchor": "end", "font-size": 11 });
+    label.textContent = Math.round(peak * i / 4);
+    chart.append(label);
+  }
+  for (let m = 0; m < months; m++) {
+    let y = height - bottom;
+    groups.forEach((group, g) => {
+      const h = (height - bottom) * group[1][m] / peak;
+      if (h > 0) {
+        const rect = svg("rect", { x: left + m * barWidth + 2, y: y - h, width: barWidth - 4, height: h, fill: colors[g % colors.length] });
+        const title = svg("title", {});
+        title.textContent = `${group[0]}: ${group[1][m]}`;
+        rect.append(title);
+        chart.append(rect);
+        y -= h;
+      }
+    });
+    const label = svg("text", { x: left + m * barWidth + barWidth / 2, y: height - 10, "text-anchor": "middle", "font-size": 10 });
+    label.textContent = monthLabel(from + m);
+    chart.append(label);
+  }
+  $("chart").replaceChildren(chart);
+  $("legend").replaceChildren(...groups.map((group, g) => {
+    const li = document.createElement("li");
+    const swatch = document.createElement("span");
+    swatch.style.background = colors[g % colors.length];
+    li.append(swatch, `${group[0]} (${sum(group[1])})`);
+    return li;
+  }));
+}
+
+async function drawChart() {
+  const from = $("chart-from").value, to = $("chart-to").value;
+  if (!from || !to || from > to) {
+    setStatus("choose a valid chart period", true);
+    return;
+  }
+  try {
+    const fromIndex = monthIndex(from), toIndex = monthIndex(to), groupBy = $("chart-group").value;
+    const groups = groupBy === "category" ? await spendByCategory(fromIndex, toIndex) : await spendByField(fromIndex, toIndex, groupBy);
+    renderChart(groups, fromIndex, toIndex);
+  } catch (err) {
+    setStatus(err.message, true);
+  }
+}
+
+function init() {
+  const now = new Date();
+  const current = now.getFullYear() + "-" + String(now.getMonth() + 1).padStart(2, "0");
+  const yearAgo = new Date(now.getFullYear(), now.getMonth() - 11, 1);
+  $("chart-to").value = current;
+  $("chart-from").value = yearAgo.getFullYear() + "-" + String(yearAgo.getMonth() + 1).padStart(2, "0");
+  $("api-key").value = localStorage.getItem("apiKey") || "";
+  $("api-key").onchange = () => localStorage.setItem("apiKey", $("api-key").value.trim());
+
+  $("apply-filters").onclick = () => loadPage(1);
+  $("prev-page").onclick = () => loadPage(state.page - 1);
+  $("next-page").onclick = () => loadPage(state.page + 1);
+  $("new-subscription").onclick = () => openEditor(null);
+  $("save").onclick = saveSubscription;
+  $("draw-chart").onclick = drawChart;
+
+  loadPage(1).then(drawChart);
+}
+
+init();
@@ -0,0 +1,31 @@
+package web
+
+import (
+	"net/http"
+
+	httpSwagger "github.com/swaggo/http-swagger/v2"
+
+	"github.com/zakharova-e/subscriptions-info/docs"
+)
+
+// OpenAPIHandler serves the spec generated by swag from the handler annotations
+func OpenAPIHandler(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(response, "Only GET method is allowed!", http.StatusMethodNotAllowed)
+		return
+	}
+	response.Header().Set("Content-Type", "application/json")
+	_, _ = response.Write([]byte(docs.SwaggerInfo.ReadDoc()))
+}
+
+// SwaggerUIHandler serves the embedded swagger ui pointed at /openapi.json
+func SwaggerUIHandler() http.Handler {
+	ui := httpSwagger.Handler(httpSwagger.URL("/openapi.json"))
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/docs/" {
+			http.Redirect(response, request, "/docs/index.html", http.StatusMovedPermanently)
+			return
+		}
+		ui(response, request)
+	})
+}
@@ -0,0 +1,272 @@
+package web_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zakharova-e/subscriptions-info/internal/auth"
+	"github.com/zakharova-e/subscriptions-info/internal/health"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+	"github.com/zakharova-e/subscriptions-info/internal/web"
+)
+
+// testKeys enable authentication so that the maintenance toggle is routed as documented
+var testKeys = auth.NewKeys([]string{"test-key"})
+
+type specSchema struct {
+	Ref        string                `json:"$ref"`
+	AllOf      []specSchema          `json:"allOf"`
+	Type       string                `json:"type"`
+	Properties map[string]specSchema `json:"properties"`
+	Items      *specSchema           `json:"items"`
+	Example    any                   `json:"example"`
+}
+
+type specParameter struct {
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+}
+
+type specOperation struct {
+	Parameters []specParameter `json:"parameters"`
+	Responses  map[string]struct {
+		Schema *specSchema `json:"schema"`
+	} `json:"responses"`
+}
+
+type spec struct {
+	Paths       map[string]map[string]specOperation `json:"paths"`
+	Definitions map[string]specSchema               `json:"definitions"`
+}
+
+func loadSpec(t *testing.T, mux *http.ServeMux) spec {
+	t.Helper()
+	response := httptest.NewRecorder()
+	mux.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if response.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json status = %d", response.Code)
+	}
+	var s spec
+	if err := json.Unmarshal(response.Body.Bytes(), &s); err != nil {
+		t.Fatalf("spec is not valid json: %v", err)
+	}
+	return s
+}
+
+// TestOpenAPIRoutes checks that every documented operation is routed and rejects other methods as documented
+func TestOpenAPIRoutes(t *testing.T) {
+	mux := web.RegisterRoutes(health.NewChecker(), testKeys)
+	s := loadSpec(t, mux)
+	for path, operations := range s.Paths {
+		if _, pattern := mux.Handler(httptest.NewRequest(http.MethodGet, path, nil)); pattern != path {
+			t.Errorf("%s is documented but routed to %q", path, pattern)
+			continue
+		}
+		for method, operation := range operations {
+			if _, ok := operation.Responses["405"]; !ok {
+				continue
+			}
+			wrongMethod := http.MethodPatch
+			request := httptest.NewRequest(wrongMethod, path, nil)
+			response := httptest.NewRecorder()
+			mux.ServeHTTP(response, request)
+			if response.Code != http.StatusMethodNotAllowed {
+				t.Errorf("%s %s status = %d, want 405 as documented for methods other than %s", wrongMethod, path, response.Code, strings.ToUpper(method))
+			}
+		}
+	}
+}
+
+// TestOpenAPIRequiredParameters sends requests without required parameters, handlers must answer with a documented 400
+func TestOpenAPIRequiredParameters(t *testing.T) {
+	mux := web.RegisterRoutes(health.NewChecker(), testKeys)
+	s := loadSpec(t, mux)
+	for path, operations := range s.Paths {
+		for method, operation := range operations {
+			var body string
+			required := false
+			for _, parameter := range operation.Parameters {
+				if parameter.Required {
+					required = true
+				}
+				if parameter.In == "body" {
+					body = "{}"
+				}
+			}
+			if !required {
+				continue
+			}
+			if _, ok := operation.Responses["400"]; !ok {
+				t.Errorf("%s %s has required parameters but does not document 400", method, path)
+				continue
+			}
+			request := httptest.NewRequest(strings.ToUpper(method), path, strings.NewReader(body))
+			if body == "" {
+				request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			response := httptest.NewRecorder()
+			mux.ServeHTTP(response, request)
+			if response.Code != http.StatusBadRequest {
+				t.Errorf("%s %s without required parameters status = %d, want 400", method, path, response.Code)
+			}
+		}
+	}
+}
+
+// TestOpenAPIDefinitions compares the documented models with the json the handlers actually write and read
+func TestOpenAPIDefinitions(t *testing.T) {
+	s := loadSpec(t, web.RegisterRoutes(health.NewChecker(), testKeys))
+	item := models.Subscription{
+		Id:          1,
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserId:      "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+		StartDate:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		FinishDate:  sql.NullTime{Time: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	budget := models.Budget{Id: 1, Scope: models.BudgetUser, Target: item.UserId, Amount: 5000, Thresholds: []int{80, 100}}
+	month := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	percent := 14.3
+	tests := []struct {
+		name       string
+		definition string
+		value      any
+	}{
+		{"test with subscription", "models.Subscription", &item},
+		{"test with list page", "models.SubscriptionListPage",
+			models.SubscriptionListPage{List: []models.Subscription{item}, Page: 1, PerPage: 50, Total: 1}},
+		{"test with spend totals", "models.SpendTotals", models.SpendTotals{Gross: 2400, Discount: 240, Net: 2160}},
+		{"test with service", "models.Service", &models.Service{Id: 1, Name: "Netflix", Aliases: []string{"netflix.com"},
+			Category: "streaming", VendorURL: "https://www.netflix.com", Plans: []models.Plan{{Name: "Standard", Price: 799}}}},
+		{"test with spend report", "models.SpendReport", &models.SpendReport{GroupBy: "category",
+			Groups: []models.SpendGroup{{Name: "streaming", Subscriptions: 3, Gross: 2400, Discount: 240, Net: 2160}},
+			Total:  models.SpendTotals{Gross: 2400, Discount: 240, Net: 2160}}},
+		{"test with budget", "models.Budget", &budget},
+		{"test with budget status", "models.BudgetStatus",
+			&models.BudgetStatus{Budget: budget, Month: month, Spent: 3200, Projected: 4100, Reached: []int{80}}},
+		{"test with budget alert", "models.BudgetAlert", &models.BudgetAlert{Id: 1, BudgetId: 1, Scope: budget.Scope,
+			Target: budget.Target, Month: month, Threshold: 80, Amount: 5000, Spent: 3200, Projected: 4100,
+			CreatedAt: time.Date(2025, 9, 12, 10, 0, 0, 0, time.UTC)}},
+		{"test with forecast", "models.Forecast", &models.Forecast{
+			Months: []models.ForecastMonth{{Month: month, Subscriptions: 4, Gross: 2400, Discount: 240, Net: 2160}},
+			Changes: []models.ForecastChange{{Month: month, SubscriptionId: 1, ServiceName: item.ServiceName, UserId: item.UserId,
+				Reasons: []models.ChangeReason{models.ChangeTrialEnded}, Before: 0, After: 400}}}},
+		{"test with comparison", "models.Comparison", &models.Comparison{
+			Base:          models.Period{From: month.AddDate(0, -1, 0), To: month.AddDate(0, 0, -1)},
+			Current:       models.Period{From: month, To: month.AddDate(0, 1, -1)},
+			BaseTotals:    models.SpendTotals{Gross: 2100, Net: 2100},
+			CurrentTotals: models.SpendTotals{Gross: 2400, Net: 2400},
+			Delta:         models.SpendTotals{Gross: 300, Net: 300},
+			Percent:       &percent,
+			Attribution:   []models.ComparisonGroup{{Kind: models.ComparisonStarted, Subscriptions: 1, Current: 300, Delta: 300}},
+			Subscriptions: []models.SubscriptionDelta{{SubscriptionId: 1, ServiceName: item.ServiceName, UserId: item.UserId,
+				Kind: models.ComparisonStarted, Current: 300, Delta: 300}}}},
+		{"test with duplicate group", "models.DuplicateGroup", &models.DuplicateGroup{UserId: item.UserId,
+			ServiceName: item.ServiceName, Subscriptions: []models.Subscription{item, item},
+			Overlaps: []models.Overlap{{SubscriptionIds: [2]int32{1, 2}, Match: models.DuplicateSameService,
+				From: month, To: item.FinishDate}}}},
+		{"test with merge request", "models.MergeRequest", models.MergeRequest{Into: 1, Ids: []int32{2, 3}}},
+		{"test with merged record", "models.MergedRecord", &models.MergedRecord{Id: 1, SubscriptionId: 1, MergedId: 2,
+			Record: item, MergedAt: time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var actual any
+			if err := json.Unmarshal(data, &actual); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			compareSchema(t, s, tt.definition, s.Definitions[tt.definition], actual)
+		})
+	}
+
+	t.Run("test with documented examples as request body", func(t *testing.T) {
+		definition := s.Definitions["models.Subscription"]
+		example := map[string]any{}
+		for name, property := range definition.Properties {
+			example[name] = property.Example
+		}
+		body, _ := json.Marshal(example)
+		var decoded models.Subscription
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			t.Fatalf("documented example %s is rejected: %v", body, err)
+		}
+		if err := decoded.IsValid(); err != nil {
+			t.Fatalf("documented example %s is invalid: %v", body, err)
+		}
+		encoded, _ := json.Marshal(&decoded)
+		var written map[string]any
+		_ = json.Unmarshal(encoded, &written)
+		if !reflect.DeepEqual(written, example) {
+			t.Errorf("documented example %s is written back as %s", body, encoded)
+		}
+	})
+}
+
+func compareSchema(t *testing.T, s spec, path string, schema specSchema, actual any) {
+	t.Helper()
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/definitions/")
+		compareSchema(t, s, path, s.Definitions[name], actual)
+		return
+	}
+	// swag documents fields of named types with enums as allOf the type
+	if schema.Type == "" && len(schema.AllOf) > 0 {
+		for _, part := range schema.AllOf {
+			compareSchema(t, s, path, part, actual)
+		}
+		return
+	}
+	switch value := actual.(type) {
+	case map[string]any:
+		if schema.Type != "object" {
+			t.Errorf("%s is an object, documented as %q", path, schema.Type)
+			return
+		}
+		var documented, written []string
+		for name := range schema.Properties {
+			documented = append(documented, name)
+		}
+		for name, field := range value {
+			written = append(written, name)
+			if property, ok := schema.Properties[name]; ok {
+				compareSchema(t, s, path+"."+name, property, field)
+			}
+		}
+		sort.Strings(documented)
+		sort.Strings(written)
+		if strings.Join(documented, ",") != strings.Join(written, ",") {
+			t.Errorf("%s fields = %v, documented %v", path, written, documented)
+		}
+	case []any:
+		if schema.Type != "array" || schema.Items == nil {
+			t.Errorf("%s is an array, documented as %q", path, schema.Type)
+			return
+		}
+		for _, element := range value {
+			compareSchema(t, s, path+"[]", *schema.Items, element)
+		}
+	case float64:
+		if schema.Type != "integer" && schema.Type != "number" {
+			t.Errorf("%s is a number, documented as %q", path, schema.Type)
+		}
+	case string:
+		if schema.Type != "string" {
+			t.Errorf("%s is a string, documented as %q", path, schema.Type)
+		}
+	case bool:
+		if schema.Type != "boolean" {
+			t.Errorf("%s is a boolean, documented as %q", path, schema.Type)
+		}
+	}
+}
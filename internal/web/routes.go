@@ -1,17 +1,63 @@
 package web
 
 import (
+	"github.com/zakharova-e/subscriptions-info/internal/auth"
+	"github.com/zakharova-e/subscriptions-info/internal/graphqlapi"
+	"github.com/zakharova-e/subscriptions-info/internal/health"
+	"github.com/zakharova-e/subscriptions-info/internal/metrics"
 	"github.com/zakharova-e/subscriptions-info/internal/subscriptions"
+	"github.com/zakharova-e/subscriptions-info/internal/tracing"
 	"net/http"
 )
 
-func RegisterRoutes() *http.ServeMux {
+// RegisterRoutes builds the mux, the maintenance toggle is only routed when api keys protect it
+func RegisterRoutes(checker *health.Checker, keys *auth.Keys) *http.ServeMux {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/subscription/create", subscriptions.SubscriptionCreateHandler)
-	mux.HandleFunc("/subscription/read", subscriptions.SubscriptionReadHandler)
-	mux.HandleFunc("/subscription/update", subscriptions.SubscriptionUpdateHandler)
-	mux.HandleFunc("/subscription/delete", subscriptions.SubscriptionDeleteHandler)
-	mux.HandleFunc("/subscription/list", subscriptions.SubscriptionListHandler)
-	mux.HandleFunc("/subscription/sum", subscriptions.SubscriptionSumHandler)
+	mux.HandleFunc("/healthz", checker.LivenessHandler)
+	mux.HandleFunc("/readyz", checker.ReadinessHandler)
+	if keys.Enabled() {
+		mux.HandleFunc("/maintenance", checker.MaintenanceHandler)
+	}
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/dashboard/", DashboardHandler())
+	mux.HandleFunc("/openapi.json", OpenAPIHandler)
+	mux.Handle("/docs/", SwaggerUIHandler())
+	handle(mux, "/subscription/create", subscriptions.SubscriptionCreateHandler)
+	handle(mux, "/subscription/read", subscriptions.SubscriptionReadHandler)
+	handle(mux, "/subscription/update", subscriptions.SubscriptionUpdateHandler)
+	handle(mux, "/subscription/delete", subscriptions.SubscriptionDeleteHandler)
+	handle(mux, "/subscription/list", subscriptions.SubscriptionListHandler)
+	handle(mux, "/subscription/sum", subscriptions.SubscriptionSumHandler)
+	handle(mux, "/subscription/sum/totals", subscriptions.SubscriptionSumTotalsHandler)
+	handle(mux, "/subscription/report", subscriptions.SubscriptionReportHandler)
+	handle(mux, "/subscription/forecast", subscriptions.SubscriptionForecastHandler)
+	handle(mux, "/subscription/compare", subscriptions.SubscriptionCompareHandler)
+	handle(mux, "/subscription/trials", subscriptions.SubscriptionTrialsHandler)
+	handle(mux, "/subscription/pause", subscriptions.SubscriptionPauseHandler)
+	handle(mux, "/subscription/resume", subscriptions.SubscriptionResumeHandler)
+	handle(mux, "/subscription/pause/delete", subscriptions.PauseDeleteHandler)
+	handle(mux, "/subscription/discount", subscriptions.SubscriptionDiscountHandler)
+	handle(mux, "/subscription/discount/delete", subscriptions.DiscountDeleteHandler)
+	handle(mux, "/subscription/price-change", subscriptions.SubscriptionPriceChangeHandler)
+	handle(mux, "/subscription/price-change/delete", subscriptions.PriceChangeDeleteHandler)
+	handle(mux, "/subscription/duplicates", subscriptions.SubscriptionDuplicatesHandler)
+	handle(mux, "/subscription/merge", subscriptions.SubscriptionMergeHandler)
+	handle(mux, "/subscription/merges", subscriptions.SubscriptionMergesHandler)
+	handle(mux, "/service/create", subscriptions.ServiceCreateHandler)
+	handle(mux, "/service/read", subscriptions.ServiceReadHandler)
+	handle(mux, "/service/resolve", subscriptions.ServiceResolveHandler)
+	handle(mux, "/service/list", subscriptions.ServiceListHandler)
+	handle(mux, "/service/update", subscriptions.ServiceUpdateHandler)
+	handle(mux, "/service/delete", subscriptions.ServiceDeleteHandler)
+	handle(mux, "/budget/create", subscriptions.BudgetCreateHandler)
+	handle(mux, "/budget/list", subscriptions.BudgetListHandler)
+	handle(mux, "/budget/delete", subscriptions.BudgetDeleteHandler)
+	handle(mux, "/budget/alerts", subscriptions.BudgetAlertsHandler)
+	handle(mux, "/graphql", graphqlapi.Handler)
 	return mux
 }
+
+// handle registers an API handler wrapped into a server span
+func handle(mux *http.ServeMux, pattern string, handler http.HandlerFunc) {
+	mux.Handle(pattern, tracing.Handler(pattern, handler))
+}
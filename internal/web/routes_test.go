@@ -0,0 +1,35 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/auth"
+	"github.com/zakharova-e/subscriptions-info/internal/health"
+	"github.com/zakharova-e/subscriptions-info/internal/web"
+)
+
+func TestRegisterRoutes_Maintenance(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     *auth.Keys
+		wantCode int
+	}{
+		{"test without api keys", auth.NewKeys(nil), http.StatusNotFound},
+		{"test with api keys", testKeys, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.NewChecker()
+			response := httptest.NewRecorder()
+			web.RegisterRoutes(checker, tt.keys).ServeHTTP(response, httptest.NewRequest(http.MethodPut, "/maintenance?enabled=true", nil))
+			if response.Code != tt.wantCode {
+				t.Errorf("PUT /maintenance status = %d, want %d", response.Code, tt.wantCode)
+			}
+			if got := checker.Maintenance(); got != (tt.wantCode == http.StatusOK) {
+				t.Errorf("Maintenance() = %v after PUT with status %d", got, response.Code)
+			}
+		})
+	}
+}
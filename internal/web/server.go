@@ -1,30 +1,42 @@
 package web
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/zakharova-e/subscriptions-info/internal/auth"
+	"github.com/zakharova-e/subscriptions-info/internal/health"
+	"github.com/zakharova-e/subscriptions-info/internal/logging"
+	"github.com/zakharova-e/subscriptions-info/internal/metrics"
 )
 
 //	@title			Subscriptions service
 //	@version		1.0
 //	@description	Test challange
-//	@host			localhost:8080
 //	@BasePath		/
 //	@schemes		http
 
-func Run() {
-	mux := RegisterRoutes()
-	err := http.ListenAndServe(":8080", LogMiddleware(CORSMiddleware(mux)))
-	if err != nil {
-		log.Fatal(err)
+//	@securityDefinitions.apikey	ApiKeyAuth
+//	@in							header
+//	@name						X-API-Key
+//	@description				required when the service runs with API_KEYS, "Authorization: Bearer <key>" is accepted as well
+
+// publicPaths stay reachable without an api key for probes, scrapers, static files of the dashboard and the api docs
+var publicPaths = []string{"/healthz", "/readyz", "/metrics", "/dashboard/", "/openapi.json", "/docs", "/docs/"}
+
+func NewServer(addr string, checker *health.Checker, keys *auth.Keys) *http.Server {
+	mux := RegisterRoutes(checker, keys)
+	return &http.Server{
+		Addr:              addr,
+		Handler:           logging.RequestIDMiddleware(LogMiddleware(metrics.Middleware(CORSMiddleware(keys.Middleware(ValidationMiddleware(mux), publicPaths...))))),
+		ReadHeaderTimeout: 10 * time.Second,
 	}
-	log.Println("Server started")
 }
 
 func LogMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("%s: request [%s] %s %s \n", time.Now().Format("2006-01-02 15:04:05"), r.Method, r.RemoteAddr, r.RequestURI)
+		slog.InfoContext(r.Context(), "request", "method", r.Method, "remote_addr", r.RemoteAddr, "uri", r.RequestURI)
 		next.ServeHTTP(w, r)
 	})
 }
@@ -34,7 +46,7 @@ func CORSMiddleware(next http.Handler) http.Handler {
 
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, X-Request-ID")
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusOK)
@@ -0,0 +1,170 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/google/uuid"
+
+	"github.com/zakharova-e/subscriptions-info/docs"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions"
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+)
+
+func init() {
+	// "billing-date" is a day of subscription dates and period filters, bare months are accepted too
+	openapi3.DefineStringFormatValidator("billing-date", openapi3.NewCallbackValidator(func(value string) error {
+		_, err := models.ParseStartDate(value)
+		return err
+	}))
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewCallbackValidator(func(value string) error {
+		if uuid.Validate(value) != nil {
+			return errors.New("must be uuid")
+		}
+		return nil
+	}))
+	// the stock decoder turns absent optional form fields into nulls, which then fail as not nullable
+	openapi3filter.RegisterBodyDecoder("application/x-www-form-urlencoded",
+		func(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn openapi3filter.EncodingFn) (any, error) {
+			value, err := openapi3filter.UrlencodedBodyDecoder(body, header, schema, encFn)
+			if fields, ok := value.(map[string]any); ok {
+				for name, field := range fields {
+					if field == nil {
+						delete(fields, name)
+					}
+				}
+			}
+			return value, err
+		})
+}
+
+// loadRouter converts the generated swagger 2 spec into openapi 3 for the validator
+func loadRouter() (routers.Router, error) {
+	var spec2 openapi2.T
+	if err := json.Unmarshal([]byte(docs.SwaggerInfo.ReadDoc()), &spec2); err != nil {
+		return nil, fmt.Errorf("openapi spec: %w", err)
+	}
+	spec3, err := openapi2conv.ToV3(&spec2)
+	if err != nil {
+		return nil, fmt.Errorf("openapi spec: %w", err)
+	}
+	// routes are matched by path only, the service may run behind any host
+	spec3.Servers = nil
+	if err := spec3.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("openapi spec: %w", err)
+	}
+	return legacy.NewRouter(spec3)
+}
+
+// ValidationMiddleware checks query, form and json parameters of documented operations against the spec
+// before the handlers run, violations are answered with 400 and the list of invalid fields.
+// Undocumented routes and methods are passed through, the handlers answer them as before
+func ValidationMiddleware(next http.Handler) http.Handler {
+	router, err := loadRouter()
+	if err != nil {
+		panic(err)
+	}
+	options := &openapi3filter.Options{
+		MultiError: true,
+		// api keys are checked by auth.Middleware
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		// handlers apply their own defaults, and form bodies with defaults filled in cannot be encoded back
+		SkipSettingDefaults: true,
+	}
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		route, pathParams, errRoute := router.FindRoute(request)
+		if errRoute != nil {
+			next.ServeHTTP(response, request)
+			return
+		}
+		dropEmptyQueryValues(request)
+		input := &openapi3filter.RequestValidationInput{
+			Request:    request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+		if errValidate := openapi3filter.ValidateRequest(request.Context(), input); errValidate != nil {
+			var vErr models.ValidationError
+			for _, field := range fieldErrors(errValidate, "", "") {
+				vErr.Errors = append(vErr.Errors, field)
+			}
+			subscriptions.ResponseWithError(response, request, &vErr)
+			return
+		}
+		next.ServeHTTP(response, request)
+	})
+}
+
+// dropEmptyQueryValues removes "?userId=" style parameters, handlers treat them as not set
+// while the validator would reject them as empty values
+func dropEmptyQueryValues(request *http.Request) {
+	query := request.URL.Query()
+	changed := false
+	for name, values := range query {
+		if len(values) == 1 && values[0] == "" {
+			query.Del(name)
+			changed = true
+		}
+	}
+	if changed {
+		request.URL.RawQuery = query.Encode()
+	}
+}
+
+// fieldErrors flattens the errors of the validator into per-field errors, field and in
+// are inherited from the enclosing parameter or request body
+func fieldErrors(err error, field string, in string) []*models.FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var fields []*models.FieldError
+		for _, inner := range e {
+			fields = append(fields, fieldErrors(inner, field, in)...)
+		}
+		return fields
+	case *openapi3filter.RequestError:
+		switch {
+		case e.Parameter != nil:
+			field, in = e.Parameter.Name, e.Parameter.In
+		case e.RequestBody != nil:
+			in = "body"
+			if strings.HasPrefix(e.Input.Request.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+				in = "formData"
+			}
+		}
+		if e.Err == nil {
+			return []*models.FieldError{{Field: field, In: in, Message: e.Reason}}
+		}
+		var schemaErr *openapi3.SchemaError
+		var multi openapi3.MultiError
+		if errors.As(e.Err, &schemaErr) || errors.As(e.Err, &multi) {
+			return fieldErrors(e.Err, field, in)
+		}
+		return []*models.FieldError{{Field: field, In: in, Message: e.Err.Error()}}
+	case *openapi3.SchemaError:
+		if pointer := e.JSONPointer(); len(pointer) > 0 {
+			field = strings.Join(pointer, ".")
+		}
+		message := e.Reason
+		if e.SchemaField == "required" {
+			// the pointer leads to the object, the missing property is only named in the reason
+			if name, ok := strings.CutPrefix(e.Reason, "property \""); ok {
+				field, message = strings.TrimSuffix(name, "\" is missing"), "is required"
+			}
+		}
+		return []*models.FieldError{{Field: field, In: in, Message: message}}
+	default:
+		return []*models.FieldError{{Field: field, In: in, Message: err.Error()}}
+	}
+}
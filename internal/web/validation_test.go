@@ -0,0 +1,93 @@
+package web_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zakharova-e/subscriptions-info/internal/subscriptions/models"
+	"github.com/zakharova-e/subscriptions-info/internal/web"
+)
+
+func TestValidationMiddleware(t *testing.T) {
+	const item = `{"service_name":"Yandex Plus","price":400,"user_id":"60601fee-2bf1-4721-ae6f-7636e79a0cba","start_date":"07-2025"}`
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		want        []models.FieldError
+	}{
+		{"test with valid query", http.MethodGet, "/subscription/read?rowId=5", "", "", nil},
+		{"test with missing query parameter", http.MethodGet, "/subscription/read", "", "",
+			[]models.FieldError{{Field: "rowId", In: "query", Message: "value is required but missing"}}},
+		{"test with non integer query parameter", http.MethodGet, "/subscription/read?rowId=abc", "", "",
+			[]models.FieldError{{Field: "rowId", In: "query", Message: "value abc: an invalid integer: invalid syntax"}}},
+		{"test with query parameter below minimum", http.MethodDelete, "/subscription/delete?rowId=0", "", "",
+			[]models.FieldError{{Field: "rowId", In: "query", Message: "number must be at least 1"}}},
+		{"test with invalid uuid filter", http.MethodGet, "/subscription/list?userId=42", "", "",
+			[]models.FieldError{{Field: "userId", In: "query", Message: `string doesn't match the format "uuid" (must be uuid)`}}},
+		{"test with empty optional query parameter", http.MethodGet, "/subscription/list?userId=&page=2", "", "", nil},
+		{"test with valid form", http.MethodPost, "/subscription/sum", "application/x-www-form-urlencoded",
+			"filterFrom=01-2025&filterTo=2025-12-20&mode=prorated", nil},
+		{"test with invalid form field", http.MethodPost, "/subscription/sum", "application/x-www-form-urlencoded",
+			"filterFrom=2025-01&filterTo=12-2025",
+			[]models.FieldError{{Field: "filterFrom", In: "formData", Message: `string doesn't match the format "billing-date" (must be YYYY-MM-DD or MM-YYYY)`}}},
+		{"test with invalid sum mode", http.MethodPost, "/subscription/sum", "application/x-www-form-urlencoded",
+			"filterFrom=2025-01-15&filterTo=12-2025&mode=daily",
+			[]models.FieldError{{Field: "mode", In: "formData", Message: `value is not one of the allowed values ["monthly","prorated"]`}}},
+		{"test with valid body", http.MethodPost, "/subscription/create", "application/json", item, nil},
+		{"test with missing body fields", http.MethodPut, "/subscription/update", "application/json", `{"price":400}`,
+			[]models.FieldError{
+				{Field: "service_name", In: "body", Message: "is required"},
+				{Field: "start_date", In: "body", Message: "is required"},
+				{Field: "user_id", In: "body", Message: "is required"},
+			}},
+		{"test with invalid body field", http.MethodPost, "/subscription/create", "application/json",
+			strings.Replace(item, "07-2025", "2025-07", 1),
+			[]models.FieldError{{Field: "start_date", In: "body", Message: `string doesn't match the format "billing-date" (must be YYYY-MM-DD or MM-YYYY)`}}},
+		{"test with undocumented route", http.MethodGet, "/graphql", "", "", nil},
+		{"test with undocumented method", http.MethodPatch, "/subscription/read", "", "", nil},
+	}
+	// next echoes the body to check that it is still readable after validation
+	next := http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		response.WriteHeader(http.StatusAccepted)
+		_, _ = response.Write(body)
+	})
+	handler := web.ValidationMiddleware(next)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				request.Header.Set("Content-Type", tt.contentType)
+			}
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+			if tt.want == nil {
+				if response.Code != http.StatusAccepted {
+					t.Fatalf("status = %d, want the request passed to the handler, body %s", response.Code, response.Body)
+				}
+				if response.Body.String() != tt.body {
+					t.Errorf("handler read body %s, want %s", response.Body, tt.body)
+				}
+				return
+			}
+			if response.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", response.Code)
+			}
+			var got models.ValidationErrorResponse
+			if err := json.Unmarshal(response.Body.Bytes(), &got); err != nil {
+				t.Fatalf("body %s is not a validation error: %v", response.Body, err)
+			}
+			if !reflect.DeepEqual(got.Fields, tt.want) {
+				t.Errorf("fields = %+v, want %+v", got.Fields, tt.want)
+			}
+		})
+	}
+}